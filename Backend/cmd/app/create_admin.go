@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"golang.org/x/term"
+
+	"uniStore/Backend/internal/config"
+	"uniStore/Backend/internal/domain/services"
+	"uniStore/Backend/internal/infrastructure/database"
+	"uniStore/Backend/internal/infrastructure/metrics"
+	"uniStore/Backend/internal/infrastructure/repositories"
+	"uniStore/Backend/internal/interfaces/dto"
+	"uniStore/Backend/internal/utils"
+)
+
+// createAdmin runs the create-admin command, which creates the first admin account.
+// Credentials come from flags, then ADMIN_EMAIL, ADMIN_NICKNAME and ADMIN_PASSWORD,
+// then interactive prompts.
+func createAdmin(cfg *config.Config, logger *slog.Logger, args []string) {
+	flags := flag.NewFlagSet("create-admin", flag.ExitOnError)
+	flags.Usage = usage
+	email := flags.String("email", os.Getenv("ADMIN_EMAIL"), "admin email")
+	nickname := flags.String("nickname", os.Getenv("ADMIN_NICKNAME"), "admin nickname")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password from the first line of stdin")
+	flags.Parse(args)
+
+	stdin := bufio.NewReader(os.Stdin)
+	interactive := term.IsTerminal(int(os.Stdin.Fd()))
+
+	password := os.Getenv("ADMIN_PASSWORD")
+	if *passwordStdin {
+		line, err := stdin.ReadString('\n')
+		if err != nil && line == "" {
+			log.Fatalf("Failed to read password from stdin: %v", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	if *email == "" && interactive {
+		*email = prompt(stdin, "Email: ")
+	}
+	if *nickname == "" && interactive {
+		*nickname = prompt(stdin, "Nickname: ")
+	}
+	if password == "" && interactive {
+		password = promptPassword("Password: ")
+		if promptPassword("Confirm password: ") != password {
+			log.Fatal("Passwords do not match")
+		}
+	}
+
+	signupDTO := &dto.UserSignupDTO{
+		Nickname:        *nickname,
+		Email:           *email,
+		Password:        password,
+		ConfirmPassword: password,
+	}
+	if err := binding.Validator.ValidateStruct(signupDTO); err != nil {
+		log.Fatalf("Invalid admin account: %v", err)
+	}
+
+	db, err := database.NewDatabase(cfg, logger)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	userService := services.NewUserService(
+		repositories.NewUserRepository(db),
+		repositories.NewRoleRepository(db),
+		repositories.NewCartRepository(db),
+		repositories.NewFavoriteRepository(db),
+		repositories.NewLibraryRepository(db),
+		repositories.NewPointsRepository(db),
+		repositories.NewStoreCreditRepository(db),
+		utils.NewAuthUtils(cfg.Auth.JWTSecret, cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL),
+		metrics.New(),
+	)
+
+	admin, err := userService.CreateAdmin(context.Background(), signupDTO)
+	if err != nil {
+		log.Fatalf("Failed to create admin: %v", err)
+	}
+	fmt.Printf("Created admin %s <%s>\n", admin.Nickname, admin.Email)
+}
+
+// prompt asks for a line of input
+func prompt(stdin *bufio.Reader, label string) string {
+	fmt.Fprint(os.Stderr, label)
+	line, _ := stdin.ReadString('\n')
+	return strings.TrimSpace(line)
+}
+
+// promptPassword asks for a password without echoing it
+func promptPassword(label string) string {
+	fmt.Fprint(os.Stderr, label)
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		log.Fatalf("Failed to read password: %v", err)
+	}
+	return string(password)
+}
@@ -1,34 +1,32 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 
 	docs "uniStore/Backend/api/docs"
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
+	"uniStore/Backend/internal/config"
 	"uniStore/Backend/internal/infrastructure/database"
+	"uniStore/Backend/internal/infrastructure/logging"
+	"uniStore/Backend/internal/infrastructure/metrics"
+	"uniStore/Backend/internal/infrastructure/tracing"
 	"uniStore/Backend/internal/interfaces/api"
-	"uniStore/Backend/internal/utils"
 )
 
-// initConfig loads environment variables from .env file
-func initConfig() error {
-	// Load .env file but don't return error if it doesn't exist
-	// This allows environment variables to be set directly in docker-compose
-	err := godotenv.Load()
-	if err != nil {
-		log.Printf("Warning: .env file not found or could not be loaded: %v", err)
-		log.Println("Continuing with environment variables set in the system")
-		return nil
-	}
-	return nil
-}
-
 // @title		Game Store
 // @version		1.0
 // @description	REST-API for game store
@@ -41,50 +39,171 @@ func initConfig() error {
 // @name Authorization
 // @description JWT Authorization header using Bearer scheme. Example: "Bearer {token}"
 func main() {
-	// Setup logging
-	file, err := os.OpenFile("app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	cfg, args, err := config.Load(os.Args[1:], usage)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	logger, logFile, err := logging.New(cfg.Log)
 	if err != nil {
-		log.Fatal("Failed to open log file:", err)
+		log.Fatalf("Failed to set up logging: %v", err)
 	}
-	log.SetOutput(file)
+	defer logFile.Close()
+
+	// Anything still written through the log package, such as fatal errors, goes to the
+	// same logger at error level
+	slog.SetDefault(logger)
+	slog.SetLogLoggerLevel(slog.LevelError)
+
+	command := "serve"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "serve":
+		serve(cfg, logger)
+	case "migrate":
+		migrate(cfg, logger, args)
+	case "seed":
+		seed(cfg, logger, args)
+	case "create-admin":
+		createAdmin(cfg, logger, args)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", command)
+		usage()
+		os.Exit(2)
+	}
+}
+
+// usage prints the available commands
+func usage() {
+	fmt.Fprintln(os.Stderr, `Usage: backend [config flags] [command]
+
+Config flags:
+  -config FILE          Read settings from a YAML file, or set CONFIG_FILE
+  -<section>.<setting>  Override a setting, e.g. -server.port 8080 or -database.host db;
+                        these take precedence over the environment, which takes precedence
+                        over the file
 
-	// Initialize configuration
-	if err := initConfig(); err != nil {
-		log.Fatalf("Error loading .env file: %v", err)
+Commands:
+  serve                 Start the API server (default); SIGTERM drains it and stops
+  migrate up            Apply all pending database migrations
+  migrate down [steps]  Roll back the last migration, or the given number of them
+                        -force                     allow rolling back the baseline, which drops every table
+  migrate status        List migrations and when they were applied
+  seed [flags] <set>    Add a fixture set (minimal, demo, load-test) or a .yaml/.json fixture file
+                        -games, -users, -orders N  override how many load test records to generate
+                        -force                     allow sets other than minimal in production
+  create-admin [flags]  Create the first admin account; prompts for anything not given
+                        -email, -nickname          or ADMIN_EMAIL, ADMIN_NICKNAME
+                        -password-stdin            read the password from stdin, or set ADMIN_PASSWORD`)
+}
+
+// serve runs the API server until it receives SIGINT or SIGTERM, then stops taking new
+// requests and lets in-flight ones finish
+func serve(cfg *config.Config, logger *slog.Logger) {
+	// Export spans of requests, service calls and queries
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
 	}
 
 	// Initialize database
-	db, err := database.NewDatabase()
+	db, err := database.NewDatabase(cfg, logger)
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
-	if err := db.Migrate(); err != nil {
-		log.Fatalf("Failed to migrate database: %v", err)
+	if err := tracing.InstrumentDB(db.DB); err != nil {
+		log.Fatalf("Failed to instrument database: %v", err)
 	}
-	if err := db.CheckAdminAndRoles(); err != nil {
-		log.Fatalf("Failed to check admin and roles: %v", err)
+
+	// Time queries and watch the connection pool
+	stats := metrics.New()
+	if err := stats.InstrumentDB(db.DB, cfg.Database.Name); err != nil {
+		log.Fatalf("Failed to instrument database: %v", err)
 	}
 
 	// Initialize router and services
-	router := gin.Default()
-	server := api.NewServer(db, router)
+	if cfg.Log.Level != "debug" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	router := gin.New()
+	server := api.NewServer(cfg, db, router, logger, stats)
 	server.SetupRoutes()
 
 	// Configure Swagger
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "9090"
+	port := strconv.Itoa(cfg.Server.Port)
+	if cfg.Features.Swagger {
+		docs.SwaggerInfo.Host = "localhost:" + port
+		if !cfg.IsProduction() {
+			logger.Info("Swagger UI is available", "url", "http://127.0.0.1:"+port+"/swagger/index.html")
+		}
+		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
+	}
+
+	// Start the server before migrating, so probes can tell a starting instance from a dead one.
+	// Requests other than probes are turned away until the instance is ready.
+	httpServer := &http.Server{
+		Addr:              ":" + port,
+		Handler:           router,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
 	}
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- httpServer.ListenAndServe()
+	}()
 
-	docs.SwaggerInfo.Host = "localhost:" + port
+	if cfg.Features.AutoMigrate {
+		if err := db.Migrate(); err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+	}
 
-	if !utils.IsProd() {
-		log.Printf("Swagger UI is available at: http://127.0.0.1:%s/swagger/index.html\n", port)
+	// Start background jobs
+	if cfg.Features.BackgroundJobs {
+		server.Scheduler.Start()
 	}
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
-	// Start the server
-	if err := router.Run(":" + port); err != nil {
+	server.HealthHandler.SetReady()
+	logger.Info("Server is ready", "port", cfg.Server.Port)
+
+	// Wait for a shutdown signal
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-serverErrors:
 		log.Fatalf("Error starting server on port %s: %v", port, err)
+	case sig := <-signals:
+		logger.Info("Shutting down", "signal", sig.String())
+	}
+
+	// Fail readiness and turn away requests on open connections, close the listener and
+	// wait for in-flight requests
+	server.HealthHandler.SetDraining()
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(ctx); err != nil {
+		logger.Error("Failed to finish in-flight requests", "error", err)
+	}
+
+	// Stop background jobs, waiting for running ones, before closing the database
+	if cfg.Features.BackgroundJobs {
+		server.Scheduler.Stop()
+	}
+	if err := db.Close(); err != nil {
+		logger.Error("Failed to close database", "error", err)
+	}
+
+	// Send the spans still buffered
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("Failed to flush traces", "error", err)
 	}
+	logger.Info("Server stopped")
 }
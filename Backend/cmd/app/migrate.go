@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"uniStore/Backend/internal/config"
+	"uniStore/Backend/internal/infrastructure/database"
+)
+
+// migrate runs the migrate command: up, down [-force] [steps] or status
+func migrate(cfg *config.Config, logger *slog.Logger, args []string) {
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	db, err := database.NewDatabase(cfg, logger)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	switch args[0] {
+	case "up":
+		if err := db.Migrate(); err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+		fmt.Println("Database is up to date")
+	case "down":
+		flags := flag.NewFlagSet("migrate down", flag.ExitOnError)
+		flags.Usage = usage
+		force := flags.Bool("force", false, "allow rolling back the baseline, which drops every table")
+		flags.Parse(args[1:])
+
+		steps := 1
+		if flags.NArg() > 0 {
+			if steps, err = strconv.Atoi(flags.Arg(0)); err != nil || steps < 1 {
+				log.Fatalf("Invalid number of steps: %s", flags.Arg(0))
+			}
+		}
+		if err := db.Rollback(steps, *force); err != nil {
+			log.Fatalf("Failed to roll back database: %v", err)
+		}
+		fmt.Printf("Rolled back %d migration(s)\n", steps)
+	case "status":
+		statuses, err := db.MigrationStatus()
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d  %-30s %s\n", status.Version, status.Name, applied)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown migrate command %q\n\n", args[0])
+		usage()
+		os.Exit(2)
+	}
+}
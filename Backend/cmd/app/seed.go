@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+
+	"uniStore/Backend/internal/config"
+	"uniStore/Backend/internal/infrastructure/database"
+)
+
+// seed runs the seed command, adding a fixture set to the database
+func seed(cfg *config.Config, logger *slog.Logger, args []string) {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	flags.Usage = usage
+	games := flags.Int("games", -1, "number of load test games to generate")
+	users := flags.Int("users", -1, "number of load test users to generate")
+	orders := flags.Int("orders", -1, "number of load test orders to generate")
+	force := flags.Bool("force", false, "allow sets other than minimal in production")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		usage()
+		os.Exit(2)
+	}
+	set := flags.Arg(0)
+
+	// Demo and load test data come with known passwords
+	if cfg.IsProduction() && set != "minimal" && !*force {
+		log.Fatalf("Refusing to seed %s in production; use -force if you really mean it", set)
+	}
+
+	fixtures, err := database.LoadFixtures(set)
+	if err != nil {
+		log.Fatalf("Failed to load fixtures: %v", err)
+	}
+	if *games >= 0 || *users >= 0 || *orders >= 0 {
+		if fixtures.Generate == nil {
+			fixtures.Generate = &database.GenerateFixture{}
+		}
+		if *games >= 0 {
+			fixtures.Generate.Games = *games
+		}
+		if *users >= 0 {
+			fixtures.Generate.Users = *users
+		}
+		if *orders >= 0 {
+			fixtures.Generate.Orders = *orders
+		}
+	}
+
+	db, err := database.NewDatabase(cfg, logger)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	if err := db.Seed(fixtures); err != nil {
+		log.Fatalf("Failed to seed %s: %v", set, err)
+	}
+	fmt.Printf("Seeded %s\n", set)
+}
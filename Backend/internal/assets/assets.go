@@ -0,0 +1,9 @@
+// Package assets embeds the static files bundled with the backend
+package assets
+
+import _ "embed"
+
+// GameBlankImage is the cover of games seeded without an image of their own
+//
+//go:embed images/gameBlankImage.png
+var GameBlankImage []byte
@@ -0,0 +1,336 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// Development secrets, refused in production
+const (
+	devJWTSecret       = "your-secret-key"
+	devGuestCartSecret = "your-guest-cart-secret"
+)
+
+// minSecretLength is the shortest secret accepted in production
+const minSecretLength = 32
+
+// Config holds the application settings. Every field is read from, in increasing
+// precedence: its default, the YAML config file, its environment variable and its flag.
+type Config struct {
+	Env      string         `yaml:"env" env:"APP_ENV" usage:"environment: development or production"`
+	Log      LogConfig      `yaml:"log"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+	Server   ServerConfig   `yaml:"server"`
+	Database DatabaseConfig `yaml:"database"`
+	Auth     AuthConfig     `yaml:"auth"`
+	CORS     CORSConfig     `yaml:"cors"`
+	Store    StoreConfig    `yaml:"store"`
+	Points   PointsConfig   `yaml:"points"`
+	Reviews  ReviewsConfig  `yaml:"reviews"`
+	Invoice  InvoiceConfig  `yaml:"invoice"`
+	SMTP     SMTPConfig     `yaml:"smtp"`
+	Jobs     JobsConfig     `yaml:"jobs"`
+	Features FeaturesConfig `yaml:"features"`
+}
+
+// LogConfig configures the application logger
+type LogConfig struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL" usage:"minimum level logged: debug, info, warn or error"`
+	Format string `yaml:"format" env:"LOG_FORMAT" usage:"log format: json or text"`
+	Output string `yaml:"output" env:"LOG_OUTPUT" usage:"where logs go: stdout, stderr or a file path"`
+}
+
+// TracingConfig configures where OpenTelemetry spans are exported
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" usage:"span exporter: none or otlp"`
+	Endpoint    string  `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" usage:"OTLP/HTTP collector URL, e.g. http://jaeger:4318"`
+	ServiceName string  `yaml:"service_name" env:"OTEL_SERVICE_NAME" usage:"service name spans are reported under"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" usage:"share of new traces recorded, from 0 to 1; traces started upstream follow their sampling decision"`
+}
+
+// ServerConfig configures the HTTP server
+type ServerConfig struct {
+	Port              int           `yaml:"port" env:"PORT" usage:"port the API listens on"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT" usage:"time allowed to read request headers"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT" usage:"time allowed to read a whole request"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT" usage:"time allowed to write a response"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT" usage:"how long idle keep-alive connections stay open"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT" usage:"how long in-flight requests may finish on shutdown"`
+}
+
+// DatabaseConfig configures the Postgres connection and its pool
+type DatabaseConfig struct {
+	Host            string        `yaml:"host" env:"DB_HOST" usage:"database host"`
+	Port            int           `yaml:"port" env:"DB_PORT" usage:"database port"`
+	User            string        `yaml:"user" env:"DB_USER" usage:"database user"`
+	Password        string        `yaml:"password" env:"DB_PASSWORD" usage:"database password"`
+	Name            string        `yaml:"name" env:"DB_NAME" usage:"database name"`
+	SSLMode         string        `yaml:"sslmode" env:"DB_SSLMODE" usage:"Postgres sslmode"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" usage:"maximum open connections, 0 for unlimited"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" usage:"maximum idle connections"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" usage:"how long a connection is reused, 0 for ever"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" usage:"how long a connection may stay idle, 0 for ever"`
+	SlowQuery       time.Duration `yaml:"slow_query" env:"DB_SLOW_QUERY" usage:"queries taking longer are logged as warnings, 0 to disable"`
+}
+
+// DSN returns the Postgres connection string
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, c.Password, c.Name, c.SSLMode)
+}
+
+// AuthConfig configures authentication tokens
+type AuthConfig struct {
+	JWTSecret       string        `yaml:"jwt_secret" env:"JWT_SECRET_KEY" usage:"secret signing access and refresh tokens"`
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl" env:"JWT_ACCESS_TTL" usage:"lifetime of access tokens"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" env:"JWT_REFRESH_TTL" usage:"lifetime of refresh tokens"`
+	GuestCartSecret string        `yaml:"guest_cart_secret" env:"GUEST_CART_SECRET" usage:"secret signing guest cart tokens, shared by every instance"`
+}
+
+// CORSConfig configures cross-origin requests
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" usage:"origins allowed to call the API; empty allows any"`
+}
+
+// StoreConfig configures the currencies prices are set and paid in
+type StoreConfig struct {
+	Currency         string            `yaml:"currency" env:"STORE_CURRENCY" usage:"currency prices are set in"`
+	RegionCurrencies map[string]string `yaml:"region_currencies" env:"REGION_CURRENCIES" usage:"currency users pay in by region, e.g. DE:EUR,GB:GBP"`
+}
+
+// PointsConfig configures loyalty points
+type PointsConfig struct {
+	OrderPercent     float64            `yaml:"order_percent" env:"POINTS_ORDER_PERCENT" usage:"percentage of an order's total earned as points"`
+	FirstReviewBonus int                `yaml:"first_review_bonus" env:"POINTS_FIRST_REVIEW_BONUS" usage:"points for a user's first review of a game"`
+	Value            float64            `yaml:"value" env:"POINTS_VALUE" usage:"value of a point in major units of the store currency"`
+	MaxRedeemPercent float64            `yaml:"max_redeem_percent" env:"POINTS_MAX_REDEEM_PERCENT" usage:"largest percentage of an order payable with points"`
+	CurrencyValues   map[string]float64 `yaml:"currency_values" env:"POINTS_CURRENCY_VALUES" usage:"value of a point in major units of other currencies, e.g. EUR:0.01,RUB:1"`
+}
+
+// ReviewsConfig configures review moderation
+type ReviewsConfig struct {
+	RequirePurchase bool     `yaml:"require_purchase" env:"REVIEWS_REQUIRE_PURCHASE" usage:"only let owners of a game review it"`
+	BannedWords     []string `yaml:"banned_words" env:"REVIEW_BANNED_WORDS" usage:"words reviews may not contain"`
+}
+
+// InvoiceConfig holds the seller details printed on invoices
+type InvoiceConfig struct {
+	SellerName    string `yaml:"seller_name" env:"INVOICE_SELLER_NAME" usage:"seller name"`
+	SellerAddress string `yaml:"seller_address" env:"INVOICE_SELLER_ADDRESS" usage:"seller address, \\n breaks lines"`
+	SellerTaxID   string `yaml:"seller_tax_id" env:"INVOICE_SELLER_TAX_ID" usage:"seller tax ID"`
+}
+
+// SMTPConfig configures outgoing email. Emails are only logged when Host is empty.
+type SMTPConfig struct {
+	Host     string `yaml:"host" env:"SMTP_HOST" usage:"SMTP server; emails are logged when empty"`
+	Port     int    `yaml:"port" env:"SMTP_PORT" usage:"SMTP port"`
+	Username string `yaml:"username" env:"SMTP_USERNAME" usage:"SMTP user"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD" usage:"SMTP password"`
+	From     string `yaml:"from" env:"SMTP_FROM" usage:"sender address"`
+}
+
+// JobsConfig configures how often background jobs run and what they clean up
+type JobsConfig struct {
+	WishlistCheckMinutes    int           `yaml:"wishlist_check_minutes" env:"WISHLIST_CHECK_MINUTES" usage:"minutes between wishlist notification checks"`
+	PreorderUnlockMinutes   int           `yaml:"preorder_unlock_minutes" env:"PREORDER_UNLOCK_MINUTES" usage:"minutes between pre-order unlocks"`
+	GuestCartCleanupMinutes int           `yaml:"guest_cart_cleanup_minutes" env:"GUEST_CART_CLEANUP_MINUTES" usage:"minutes between deletions of stale guest carts"`
+	GuestCartTTL            time.Duration `yaml:"guest_cart_ttl" env:"GUEST_CART_TTL" usage:"how long a guest cart is kept after it was last changed"`
+}
+
+// FeaturesConfig switches optional behaviour on and off
+type FeaturesConfig struct {
+	AutoMigrate    bool `yaml:"auto_migrate" env:"AUTO_MIGRATE" usage:"apply pending migrations when the server starts"`
+	BackgroundJobs bool `yaml:"background_jobs" env:"BACKGROUND_JOBS" usage:"run background jobs on this instance"`
+	Swagger        bool `yaml:"swagger" env:"SWAGGER_ENABLED" usage:"serve the Swagger UI"`
+	Metrics        bool `yaml:"metrics" env:"METRICS_ENABLED" usage:"serve Prometheus metrics on /metrics"`
+}
+
+// Default returns the configuration used for anything that is not set
+func Default() *Config {
+	return &Config{
+		Env: "development",
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+			Output: "stdout",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "unistore-backend",
+			SampleRatio: 1,
+		},
+		Server: ServerConfig{
+			Port:              9090,
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   20 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Name:            "unistore",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			SlowQuery:       time.Second,
+		},
+		Auth: AuthConfig{
+			JWTSecret:       devJWTSecret,
+			AccessTokenTTL:  24 * time.Hour,
+			RefreshTokenTTL: 7 * 24 * time.Hour,
+			GuestCartSecret: devGuestCartSecret,
+		},
+		Store: StoreConfig{
+			Currency: models.DefaultCurrency,
+		},
+		Points: PointsConfig{
+			OrderPercent:     5,
+			FirstReviewBonus: 50,
+			Value:            0.01,
+			MaxRedeemPercent: 50,
+		},
+		Invoice: InvoiceConfig{
+			SellerName: "uniStore",
+		},
+		SMTP: SMTPConfig{
+			Port: 587,
+			From: "no-reply@localhost",
+		},
+		Jobs: JobsConfig{
+			WishlistCheckMinutes:    15,
+			PreorderUnlockMinutes:   5,
+			GuestCartCleanupMinutes: 60,
+			GuestCartTTL:            30 * 24 * time.Hour,
+		},
+		Features: FeaturesConfig{
+			AutoMigrate:    true,
+			BackgroundJobs: true,
+			Swagger:        true,
+			Metrics:        true,
+		},
+	}
+}
+
+// IsProduction reports whether the application runs in production
+func (c *Config) IsProduction() bool {
+	return c.Env == "production"
+}
+
+// PointValues returns the value of a point in major units by currency, including the store currency
+func (c *Config) PointValues() map[string]float64 {
+	values := make(map[string]float64, len(c.Points.CurrencyValues)+1)
+	for currency, value := range c.Points.CurrencyValues {
+		values[currency] = value
+	}
+	values[c.Store.Currency] = c.Points.Value
+	return values
+}
+
+// normalize tidies values that may be written in several ways
+func (c *Config) normalize() {
+	c.Env = strings.ToLower(strings.TrimSpace(c.Env))
+	c.Log.Level = strings.ToLower(strings.TrimSpace(c.Log.Level))
+	c.Log.Format = strings.ToLower(strings.TrimSpace(c.Log.Format))
+	c.Tracing.Exporter = strings.ToLower(strings.TrimSpace(c.Tracing.Exporter))
+	c.Store.Currency = models.NormalizeCurrency(c.Store.Currency)
+	regions := make(map[string]string, len(c.Store.RegionCurrencies))
+	for region, currency := range c.Store.RegionCurrencies {
+		regions[strings.ToUpper(strings.TrimSpace(region))] = models.NormalizeCurrency(currency)
+	}
+	c.Store.RegionCurrencies = regions
+	pointValues := make(map[string]float64, len(c.Points.CurrencyValues))
+	for currency, value := range c.Points.CurrencyValues {
+		pointValues[models.NormalizeCurrency(currency)] = value
+	}
+	c.Points.CurrencyValues = pointValues
+	c.Invoice.SellerAddress = strings.ReplaceAll(c.Invoice.SellerAddress, `\n`, "\n")
+}
+
+// Validate reports every setting that would stop the application from working correctly
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Env == "development" || c.Env == "production", "env must be development or production, got %q", c.Env)
+
+	check(slices.Contains([]string{"debug", "info", "warn", "error"}, c.Log.Level), "log.level must be debug, info, warn or error, got %q", c.Log.Level)
+	check(c.Log.Format == "json" || c.Log.Format == "text", "log.format must be json or text, got %q", c.Log.Format)
+	check(c.Log.Output != "", "log.output is required")
+
+	check(c.Tracing.Exporter == "none" || c.Tracing.Exporter == "otlp", "tracing.exporter must be none or otlp, got %q", c.Tracing.Exporter)
+	check(c.Tracing.Endpoint == "" || strings.HasPrefix(c.Tracing.Endpoint, "http://") || strings.HasPrefix(c.Tracing.Endpoint, "https://"),
+		"tracing.endpoint must start with http:// or https://")
+	check(c.Tracing.ServiceName != "", "tracing.service_name is required")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "server.port must be between 1 and 65535")
+	check(c.Server.ReadHeaderTimeout >= 0 && c.Server.ReadTimeout >= 0 && c.Server.WriteTimeout >= 0 && c.Server.IdleTimeout >= 0,
+		"server timeouts must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+
+	check(c.Database.Host != "", "database.host is required")
+	check(c.Database.Port > 0 && c.Database.Port <= 65535, "database.port must be between 1 and 65535")
+	check(c.Database.User != "", "database.user is required")
+	check(c.Database.Name != "", "database.name is required")
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns must not be negative")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns must not exceed database.max_open_conns")
+	check(c.Database.ConnMaxLifetime >= 0 && c.Database.ConnMaxIdleTime >= 0, "database connection lifetimes must not be negative")
+	check(c.Database.SlowQuery >= 0, "database.slow_query must not be negative")
+
+	check(c.Auth.JWTSecret != "", "auth.jwt_secret is required")
+	check(c.Auth.GuestCartSecret != "", "auth.guest_cart_secret is required")
+	check(c.Auth.AccessTokenTTL > 0, "auth.access_token_ttl must be positive")
+	check(c.Auth.RefreshTokenTTL >= c.Auth.AccessTokenTTL, "auth.refresh_token_ttl must not be shorter than auth.access_token_ttl")
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		check(origin == "*" || strings.HasPrefix(origin, "http://") || strings.HasPrefix(origin, "https://"),
+			"cors.allowed_origins entry %q must be * or start with http:// or https://", origin)
+	}
+
+	check(models.IsCurrency(c.Store.Currency), "store.currency %q is not supported", c.Store.Currency)
+	for region, currency := range c.Store.RegionCurrencies {
+		check(region != "" && models.IsCurrency(currency), "store.region_currencies entry %s:%s is not supported", region, currency)
+	}
+
+	check(c.Points.OrderPercent >= 0 && c.Points.OrderPercent <= 100, "points.order_percent must be between 0 and 100")
+	check(c.Points.FirstReviewBonus >= 0, "points.first_review_bonus must not be negative")
+	check(c.Points.Value >= 0, "points.value must not be negative")
+	for currency, value := range c.Points.CurrencyValues {
+		check(models.IsCurrency(currency) && value >= 0, "points.currency_values entry %s:%g is not supported", currency, value)
+	}
+	check(c.Points.MaxRedeemPercent >= 0 && c.Points.MaxRedeemPercent <= 100, "points.max_redeem_percent must be between 0 and 100")
+
+	check(c.SMTP.Host == "" || (c.SMTP.Port > 0 && c.SMTP.Port <= 65535), "smtp.port must be between 1 and 65535")
+	check(c.SMTP.Host == "" || c.SMTP.From != "", "smtp.from is required when smtp.host is set")
+
+	check(c.Jobs.WishlistCheckMinutes > 0, "jobs.wishlist_check_minutes must be positive")
+	check(c.Jobs.PreorderUnlockMinutes > 0, "jobs.preorder_unlock_minutes must be positive")
+	check(c.Jobs.GuestCartCleanupMinutes > 0, "jobs.guest_cart_cleanup_minutes must be positive")
+	check(c.Jobs.GuestCartTTL > 0, "jobs.guest_cart_ttl must be positive")
+
+	if c.IsProduction() {
+		check(c.Database.Password != "", "database.password is required in production")
+		check(c.Auth.JWTSecret != devJWTSecret && len(c.Auth.JWTSecret) >= minSecretLength,
+			"auth.jwt_secret must be a random secret of at least %d characters in production", minSecretLength)
+		check(c.Auth.GuestCartSecret != devGuestCartSecret && len(c.Auth.GuestCartSecret) >= minSecretLength,
+			"auth.guest_cart_secret must be a random secret of at least %d characters in production", minSecretLength)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// setting is a single configurable value, named by its path in the config file, e.g. database.host
+type setting struct {
+	name  string
+	env   string
+	usage string
+	value reflect.Value
+}
+
+// Load reads the configuration from its defaults, the YAML file named by -config or
+// CONFIG_FILE, the environment (including a .env file) and the flags in args, each
+// overriding the one before, and validates it. Flags are named after the file's keys,
+// e.g. -database.host, and parsing stops at the first argument that is not a flag;
+// the remaining arguments are returned.
+func Load(args []string, usage func()) (*Config, []string, error) {
+	// A missing .env file is fine, e.g. when docker-compose sets the environment
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("failed to load .env file: %w", err)
+	}
+
+	cfg := Default()
+	settings := settingsOf(cfg)
+
+	flags := flag.NewFlagSet("backend", flag.ContinueOnError)
+	flags.Usage = usage
+	file := flags.String("config", os.Getenv("CONFIG_FILE"), "YAML config file")
+	var overrides []func() error
+	for _, s := range settings {
+		s := s
+		flags.Func(s.name, s.usage, func(raw string) error {
+			if err := parseValue(s.value.Type(), raw); err != nil {
+				return err
+			}
+			overrides = append(overrides, func() error { return setValue(s.value, raw) })
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *file != "" {
+		if err := loadFile(cfg, *file); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, s := range settings {
+		raw := os.Getenv(s.env)
+		if raw == "" {
+			continue
+		}
+		if err := setValue(s.value, raw); err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %w", s.env, err)
+		}
+	}
+
+	for _, override := range overrides {
+		if err := override(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	cfg.normalize()
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return cfg, flags.Args(), nil
+}
+
+// loadFile overrides cfg with the settings in a YAML file, rejecting unknown keys
+func loadFile(cfg *Config, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// settingsOf lists the settings of cfg, pointing into it
+func settingsOf(cfg *Config) []setting {
+	var settings []setting
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name := prefix + field.Tag.Get("yaml")
+			if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+				walk(v.Field(i), name+".")
+				continue
+			}
+			settings = append(settings, setting{
+				name:  name,
+				env:   field.Tag.Get("env"),
+				usage: fmt.Sprintf("%s (env %s)", field.Tag.Get("usage"), field.Tag.Get("env")),
+				value: v.Field(i),
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return settings
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// parseValue checks that raw can be stored in a value of type t
+func parseValue(t reflect.Type, raw string) error {
+	return setValue(reflect.New(t).Elem(), raw)
+}
+
+// setValue parses raw into v. Lists are comma-separated and maps are comma-separated
+// key:value pairs.
+func setValue(v reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		v.Set(reflect.ValueOf(splitList(raw)))
+	case reflect.Map:
+		values := reflect.MakeMap(v.Type())
+		for _, entry := range splitList(raw) {
+			key, value, ok := strings.Cut(entry, ":")
+			if key, value = strings.TrimSpace(key), strings.TrimSpace(value); !ok || key == "" || value == "" {
+				return fmt.Errorf("%q is not a key:value pair", entry)
+			}
+			parsed := reflect.New(v.Type().Elem()).Elem()
+			if err := setValue(parsed, value); err != nil {
+				return fmt.Errorf("%q: %w", entry, err)
+			}
+			values.SetMapIndex(reflect.ValueOf(key), parsed)
+		}
+		v.Set(values)
+	default:
+		return fmt.Errorf("unsupported setting type %s", v.Type())
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, skipping empty entries
+func splitList(raw string) []string {
+	values := []string{}
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Bundle represents several games sold together as one product
+type Bundle struct {
+	ID          int    `gorm:"primaryKey"`
+	Name        string `gorm:"not null" validate:"required"`
+	Description string `gorm:"type:text"`
+	Price       int64  `gorm:"not null" validate:"gte=0"`                    // Price of the whole bundle for a buyer owning none of its games, in minor units
+	Currency    string `gorm:"type:varchar(3);not null" validate:"required"` // Currency of the price; other currencies keep the same saving on the games' regional prices
+	Active      bool   `gorm:"not null;default:true"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	Games []*Game `gorm:"many2many:bundle_game"`
+}
+
+// BundleRepository defines the interface for bundle data access
+type BundleRepository interface {
+	Create(ctx context.Context, bundle *Bundle) error
+	FindByID(ctx context.Context, id int) (*Bundle, error)
+	FindAll(ctx context.Context, limit, offset int, activeOnly bool) ([]*Bundle, error)
+	Update(ctx context.Context, bundle *Bundle) error
+	Delete(ctx context.Context, id int) error
+}
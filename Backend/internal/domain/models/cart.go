@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"time"
 
 	"gorm.io/gorm"
@@ -8,9 +9,11 @@ import (
 
 // ShoppingCart represents a user's shopping cart
 type ShoppingCart struct {
-	ID        int   `gorm:"primaryKey"`
-	UserID    int   `gorm:"not null;uniqueIndex" validate:"required"`
-	User      *User `gorm:"foreignKey:UserID"`
+	ID        int     `gorm:"primaryKey"`
+	UserID    int     `gorm:"not null;uniqueIndex" validate:"required"`
+	User      *User   `gorm:"foreignKey:UserID"`
+	CouponID  *int    // Coupon applied to the cart
+	Coupon    *Coupon `gorm:"foreignKey:CouponID"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -21,15 +24,22 @@ type ShoppingCart struct {
 
 // CartItem represents an item in a shopping cart
 type CartItem struct {
-	ID             int           `gorm:"primaryKey"`
-	ShoppingCartID int           `gorm:"not null" validate:"required"`
-	ShoppingCart   *ShoppingCart `gorm:"foreignKey:ShoppingCartID"`
-	GameID         int           `gorm:"not null" validate:"required"`
-	Game           *Game         `gorm:"foreignKey:GameID"`
-	Quantity       int           `gorm:"not null;default:1" validate:"required,min=1"`
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	DeletedAt      gorm.DeletedAt `gorm:"index"`
+	ID              int           `gorm:"primaryKey"`
+	ShoppingCartID  int           `gorm:"not null" validate:"required"`
+	ShoppingCart    *ShoppingCart `gorm:"foreignKey:ShoppingCartID"`
+	GameID          *int          // Set for a single game
+	Game            *Game         `gorm:"foreignKey:GameID"`
+	BundleID        *int          // Or for a bundle of games
+	Bundle          *Bundle       `gorm:"foreignKey:BundleID"`
+	Quantity        int           `gorm:"not null;default:1" validate:"required,min=1"`
+	AddedPrice      *int64        // Unit price in minor units when the item was added; nil for items added before prices were recorded
+	AddedCurrency   string        `gorm:"type:varchar(3)"` // Currency of AddedPrice
+	GiftRecipientID *int          // Set when the item is bought for another user
+	GiftRecipient   *User         `gorm:"foreignKey:GiftRecipientID"`
+	GiftMessage     string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
 }
 
 // Favorite represents a user's favorite games list
@@ -55,6 +65,11 @@ type FavoriteItem struct {
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 	DeletedAt  gorm.DeletedAt `gorm:"index"`
+
+	// Wishlist watch state, so each price drop and release is notified once
+	WatchedPrice    *int64 // Sale price in minor units when last checked
+	WatchedCurrency string `gorm:"type:varchar(3)"` // Currency of WatchedPrice, the owner's currency at the time
+	ReleaseNotified bool   `gorm:"not null;default:false"`
 }
 
 // Library represents a user's game library
@@ -77,35 +92,49 @@ type LibraryItem struct {
 	Library   *Library `gorm:"foreignKey:LibraryID"`
 	GameID    int      `gorm:"not null" validate:"required"`
 	Game      *Game    `gorm:"foreignKey:GameID"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	Locked    bool     `gorm:"not null;default:false"` // Pre-ordered game awaiting its release
+	// Order item that granted the game, so a refund takes back only what it paid for
+	OrderItemID *int `gorm:"index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
 }
 
 // CartRepository defines the interface for cart data access
 type CartRepository interface {
-	Create(cart *ShoppingCart) error
-	FindByUserID(userID int) (*ShoppingCart, error)
-	AddGameToCart(userID, gameID int, quantity int) error
-	RemoveGameFromCart(userID, gameID int) error
-	ClearCart(userID int) error
-	GetCartItems(userID int) ([]*CartItem, error)
+	Create(ctx context.Context, cart *ShoppingCart) error
+	FindByUserID(ctx context.Context, userID int) (*ShoppingCart, error)
+	AddGameToCart(ctx context.Context, userID, gameID int, quantity int, price Money) error
+	RemoveGameFromCart(ctx context.Context, userID, gameID int) error
+	ClearCart(ctx context.Context, userID int) error
+	GetCartItems(ctx context.Context, userID int) ([]*CartItem, error)
+	SetGift(ctx context.Context, userID, gameID, recipientID int, message string) error
+	AddGiftCopy(ctx context.Context, userID, gameID, recipientID int, message string, price Money) error
+	RemoveGift(ctx context.Context, userID, gameID, recipientID int) error
+	SetCoupon(ctx context.Context, userID int, couponID *int) error
+	AddBundleToCart(ctx context.Context, userID, bundleID int, price Money) error
+	RemoveBundleFromCart(ctx context.Context, userID, bundleID int) error
 }
 
 // FavoriteRepository defines the interface for favorite data access
 type FavoriteRepository interface {
-	Create(favorite *Favorite) error
-	FindByUserID(userID int) (*Favorite, error)
-	AddGameToFavorite(userID, gameID int) error
-	RemoveGameFromFavorite(userID, gameID int) error
-	ClearFavorite(userID int) error
-	GetFavoriteItems(userID int) ([]*FavoriteItem, error)
+	Create(ctx context.Context, favorite *Favorite) error
+	FindByUserID(ctx context.Context, userID int) (*Favorite, error)
+	AddGameToFavorite(ctx context.Context, userID, gameID int, price *Money, released bool) error
+	RemoveGameFromFavorite(ctx context.Context, userID, gameID int) error
+	ClearFavorite(ctx context.Context, userID int) error
+	GetFavoriteItems(ctx context.Context, userID int) ([]*FavoriteItem, error)
+	FindWatchedItems(ctx context.Context, afterID, limit int) ([]*FavoriteItem, error)
+	UpdateWatchedPrice(ctx context.Context, itemID int, from *Money, to Money) (bool, error)
+	MarkReleaseNotified(ctx context.Context, itemID int) (bool, error)
 }
 
 // LibraryRepository defines the interface for library data access
 type LibraryRepository interface {
-	Create(library *Library) error
-	FindByUserID(userID int) (*Library, error)
-	AddGameToLibrary(userID, gameID int) error
-	GetLibraryItems(userID int) ([]*LibraryItem, error)
+	Create(ctx context.Context, library *Library) error
+	FindByUserID(ctx context.Context, userID int) (*Library, error)
+	AddGameToLibrary(ctx context.Context, userID, gameID int) error
+	GetLibraryItems(ctx context.Context, userID int) ([]*LibraryItem, error)
+	HasGame(ctx context.Context, userID, gameID int) (bool, error)
+	UnlockReleased(ctx context.Context, now time.Time) (int64, error)
 }
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Coupon discount types
+const (
+	CouponTypePercent = "percent"
+	CouponTypeFixed   = "fixed"
+)
+
+// Coupon represents a promo code giving a discount at checkout
+type Coupon struct {
+	ID             int    `gorm:"primaryKey"`
+	Code           string `gorm:"not null;uniqueIndex" validate:"required"`
+	Type           string `gorm:"not null" validate:"required,oneof=percent fixed"`
+	Value          int64  `gorm:"not null" validate:"required,gt=0"`            // Percentage, or fixed amount off in minor units of Currency
+	MinTotal       int64  `gorm:"not null;default:0"`                           // Minimum cart subtotal in minor units of Currency
+	Currency       string `gorm:"type:varchar(3);not null" validate:"required"` // Currency of a fixed amount and the minimum subtotal
+	MaxUses        int    `gorm:"not null;default:0"`                           // Total uses allowed; 0 means unlimited
+	MaxUsesPerUser int    `gorm:"not null;default:1"`                           // Uses allowed per user; 0 means unlimited
+	Uses           int    `gorm:"not null;default:0"`
+	StartsAt       *time.Time
+	EndsAt         *time.Time
+	Active         bool `gorm:"not null;default:true"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+
+	// Restrictions; the coupon applies to every game when both are empty
+	Categories []*Category  `gorm:"many2many:coupon_category"`
+	Developers []*Developer `gorm:"many2many:coupon_developer"`
+}
+
+// CouponRedemption records a coupon used on an order
+type CouponRedemption struct {
+	ID        int     `gorm:"primaryKey"`
+	CouponID  int     `gorm:"not null;index:idx_coupon_redemption_coupon_user" validate:"required"`
+	Coupon    *Coupon `gorm:"foreignKey:CouponID"`
+	UserID    int     `gorm:"not null;index:idx_coupon_redemption_coupon_user" validate:"required"`
+	User      *User   `gorm:"foreignKey:UserID"`
+	OrderID   int     `gorm:"not null;uniqueIndex" validate:"required"`
+	Order     *Order  `gorm:"foreignKey:OrderID"`
+	Discount  int64   `gorm:"not null"` // In minor units of the order currency
+	CreatedAt time.Time
+}
+
+// CouponRepository defines the interface for coupon data access
+type CouponRepository interface {
+	Create(ctx context.Context, coupon *Coupon) error
+	FindByID(ctx context.Context, id int) (*Coupon, error)
+	FindByCode(ctx context.Context, code string) (*Coupon, error)
+	FindAll(ctx context.Context, limit, offset int) ([]*Coupon, error)
+	Update(ctx context.Context, coupon *Coupon) error
+	CountUserRedemptions(ctx context.Context, couponID, userID int) (int64, error)
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"time"
 
 	"gorm.io/gorm"
@@ -11,7 +12,9 @@ type Game struct {
 	ID          int    `gorm:"primaryKey"`
 	Title       string `gorm:"type:varchar(255);not null" validate:"required"`
 	Description string
-	Price       float64 `gorm:"not null" validate:"required,gte=0"`
+	Price       int64  `gorm:"not null" validate:"gte=0"`                    // Base price in minor units of Currency
+	Currency    string `gorm:"type:varchar(3);not null" validate:"required"` // Currency of the base price
+	Discount    int    `gorm:"not null;default:0" validate:"gte=0,lte=100"`  // Percent off every price while the game is on sale
 	ReleaseDate time.Time
 	DeveloperID int        `gorm:"not null" validate:"required"`
 	Developer   *Developer `gorm:"foreignKey:DeveloperID"`
@@ -30,6 +33,41 @@ type Game struct {
 	OrderItems    []*OrderItem
 	Reviews       []*Review
 	Restricts     []*Restrict
+	Prices        []*GamePrice // Regional prices in other currencies
+}
+
+// GamePrice is a game's price in a currency other than its base currency
+type GamePrice struct {
+	ID        int    `gorm:"primaryKey"`
+	GameID    int    `gorm:"not null;uniqueIndex:idx_game_price_currency" validate:"required"`
+	Game      *Game  `gorm:"foreignKey:GameID"`
+	Currency  string `gorm:"type:varchar(3);not null;uniqueIndex:idx_game_price_currency" validate:"required"`
+	Price     int64  `gorm:"not null" validate:"gte=0"` // In minor units of Currency
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PriceIn returns the game's price in a currency, from its base price or regional prices.
+// Reports false when the game has no price in the currency.
+func (g *Game) PriceIn(currency string) (int64, bool) {
+	if g.Currency == currency {
+		return g.Price, true
+	}
+	for _, price := range g.Prices {
+		if price.Currency == currency {
+			return price.Price, true
+		}
+	}
+	return 0, false
+}
+
+// SalePriceIn returns the game's price in a currency after its discount
+func (g *Game) SalePriceIn(currency string) (int64, bool) {
+	price, ok := g.PriceIn(currency)
+	if !ok {
+		return 0, false
+	}
+	return PercentOff(price, int64(g.Discount)), true
 }
 
 // Developer represents a game developer
@@ -44,7 +82,21 @@ type Developer struct {
 	DeletedAt   gorm.DeletedAt `gorm:"index"`
 
 	// Relations
-	Games []*Game
+	Games   []*Game
+	Members []*DeveloperMember
+}
+
+// DeveloperMember links a user account to a developer.
+// Members may act on behalf of the developer, e.g. reply to reviews of its games.
+type DeveloperMember struct {
+	ID          int        `gorm:"primaryKey"`
+	DeveloperID int        `gorm:"not null;uniqueIndex:idx_developer_member,where:deleted_at IS NULL" validate:"required"`
+	Developer   *Developer `gorm:"foreignKey:DeveloperID"`
+	UserID      int        `gorm:"not null;uniqueIndex:idx_developer_member,where:deleted_at IS NULL;index" validate:"required"`
+	User        *User      `gorm:"foreignKey:UserID"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
 }
 
 // Category represents a game category/genre
@@ -71,66 +123,158 @@ type Restrict struct {
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
-// Review represents a user review for a game
+// Review represents a user review for a game.
+// A user can have at most one live review per game.
 type Review struct {
-	ID          int    `gorm:"primaryKey"`
-	GameID      int    `gorm:"not null" validate:"required"`
-	Game        *Game  `gorm:"foreignKey:GameID"`
-	UserID      int    `gorm:"not null" validate:"required"`
-	User        *User  `gorm:"foreignKey:UserID"`
-	Title       string `gorm:"not null" validate:"required"`
-	Description string
-	Rating      int `gorm:"not null" validate:"required,min=1,max=5"`
+	ID               int    `gorm:"primaryKey"`
+	GameID           int    `gorm:"not null;uniqueIndex:idx_review_game_user,where:deleted_at IS NULL;index:idx_review_listing,priority:1" validate:"required"`
+	Game             *Game  `gorm:"foreignKey:GameID"`
+	UserID           int    `gorm:"not null;uniqueIndex:idx_review_game_user,where:deleted_at IS NULL" validate:"required"`
+	User             *User  `gorm:"foreignKey:UserID"`
+	Title            string `gorm:"not null" validate:"required"`
+	Description      string
+	Rating           int    `gorm:"not null" validate:"required,min=1,max=5"`
+	VerifiedPurchase bool   `gorm:"not null;default:false"` // Author owned the game when the review was written
+	Status           string `gorm:"not null;default:'visible';index;index:idx_review_listing,priority:2"`
+	HelpfulCount     int    `gorm:"not null;default:0"`
+	UnhelpfulCount   int    `gorm:"not null;default:0"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	Reports []*ReviewReport
+	Reply   *ReviewReply
+}
+
+// ReviewReply represents a developer's public response to a review.
+// A review has at most one reply.
+type ReviewReply struct {
+	ID          int        `gorm:"primaryKey"`
+	ReviewID    int        `gorm:"not null;uniqueIndex:idx_review_reply_review,where:deleted_at IS NULL" validate:"required"`
+	Review      *Review    `gorm:"foreignKey:ReviewID"`
+	DeveloperID int        `gorm:"not null" validate:"required"`
+	Developer   *Developer `gorm:"foreignKey:DeveloperID"`
+	UserID      int        `gorm:"not null" validate:"required"` // Account that wrote the reply
+	User        *User      `gorm:"foreignKey:UserID"`
+	Body        string     `gorm:"type:text;not null" validate:"required"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   gorm.DeletedAt `gorm:"index"`
 }
 
+// Review moderation statuses
+const (
+	ReviewStatusVisible = "visible"
+	ReviewStatusPending = "pending"
+	ReviewStatusHidden  = "hidden"
+)
+
+// Review list sort modes
+const (
+	ReviewSortHelpful = "helpful"
+	ReviewSortNewest  = "newest"
+	ReviewSortHighest = "highest"
+	ReviewSortLowest  = "lowest"
+)
+
+// ReviewQuery describes filtering, sorting and pagination of a review list
+type ReviewQuery struct {
+	Rating int // Only reviews with this rating; 0 means any rating
+	Sort   string
+	Limit  int
+	Offset int
+}
+
+// ReviewVote represents a user's helpfulness vote on a review.
+// A user can vote on a review only once, but may change the vote.
+type ReviewVote struct {
+	ID        int     `gorm:"primaryKey"`
+	ReviewID  int     `gorm:"not null;uniqueIndex:idx_review_vote_review_user,where:deleted_at IS NULL" validate:"required"`
+	Review    *Review `gorm:"foreignKey:ReviewID"`
+	UserID    int     `gorm:"not null;uniqueIndex:idx_review_vote_review_user,where:deleted_at IS NULL" validate:"required"`
+	User      *User   `gorm:"foreignKey:UserID"`
+	Helpful   bool    `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// ReviewReport represents a user's complaint about a review.
+// A user can report a review only once.
+type ReviewReport struct {
+	ID        int     `gorm:"primaryKey"`
+	ReviewID  int     `gorm:"not null;uniqueIndex:idx_review_report_review_user,where:deleted_at IS NULL" validate:"required"`
+	Review    *Review `gorm:"foreignKey:ReviewID"`
+	UserID    int     `gorm:"not null;uniqueIndex:idx_review_report_review_user,where:deleted_at IS NULL" validate:"required"`
+	User      *User   `gorm:"foreignKey:UserID"`
+	Reason    string  `gorm:"not null" validate:"required"`
+	Resolved  bool    `gorm:"not null;default:false;index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
 // GameRepository defines the interface for game data access
 type GameRepository interface {
-	Create(game *Game) error
-	FindByID(id int) (*Game, error)
-	Update(game *Game) error
-	Delete(id int) error
-	FindAll(limit, offset int) ([]*Game, error)
-	FindByCategory(categoryID int) ([]*Game, error)
-	FindByDeveloper(developerID int) ([]*Game, error)
+	Create(ctx context.Context, game *Game) error
+	FindByID(ctx context.Context, id int) (*Game, error)
+	Update(ctx context.Context, game *Game) error
+	Delete(ctx context.Context, id int) error
+	FindAll(ctx context.Context, limit, offset int) ([]*Game, error)
+	FindDiscounted(ctx context.Context, limit int) ([]*Game, error)
+	FindByCategory(ctx context.Context, categoryID int) ([]*Game, error)
+	FindByDeveloper(ctx context.Context, developerID int) ([]*Game, error)
 }
 
 // DeveloperRepository defines the interface for developer data access
 type DeveloperRepository interface {
-	Create(developer *Developer) error
-	FindByID(id int) (*Developer, error)
-	Update(developer *Developer) error
-	Delete(id int) error
-	FindAll(limit, offset int) ([]*Developer, error)
+	Create(ctx context.Context, developer *Developer) error
+	FindByID(ctx context.Context, id int) (*Developer, error)
+	Update(ctx context.Context, developer *Developer) error
+	Delete(ctx context.Context, id int) error
+	FindAll(ctx context.Context, limit, offset int) ([]*Developer, error)
+	AddMember(ctx context.Context, developerID, userID int) error
+	RemoveMember(ctx context.Context, developerID, userID int) error
+	IsMember(ctx context.Context, developerID, userID int) (bool, error)
+	FindMembers(ctx context.Context, developerID int) ([]*DeveloperMember, error)
 }
 
 // CategoryRepository defines the interface for category data access
 type CategoryRepository interface {
-	Create(category *Category) error
-	FindByID(id int) (*Category, error)
-	Update(category *Category) error
-	Delete(id int) error
-	FindAll(limit, offset int) ([]*Category, error)
+	Create(ctx context.Context, category *Category) error
+	FindByID(ctx context.Context, id int) (*Category, error)
+	Update(ctx context.Context, category *Category) error
+	Delete(ctx context.Context, id int) error
+	FindAll(ctx context.Context, limit, offset int) ([]*Category, error)
 }
 
 // RestrictRepository defines the interface for restrict data access
 type RestrictRepository interface {
-	Create(restrict *Restrict) error
-	FindByID(id int) (*Restrict, error)
-	FindByGameID(gameID int) ([]*Restrict, error)
-	Update(restrict *Restrict) error
-	Delete(id int) error
-	FindAll(limit, offset int) ([]*Restrict, error)
+	Create(ctx context.Context, restrict *Restrict) error
+	FindByID(ctx context.Context, id int) (*Restrict, error)
+	FindByGameID(ctx context.Context, gameID int) ([]*Restrict, error)
+	Update(ctx context.Context, restrict *Restrict) error
+	Delete(ctx context.Context, id int) error
+	FindAll(ctx context.Context, limit, offset int) ([]*Restrict, error)
 }
 
 // ReviewRepository defines the interface for review data access
 type ReviewRepository interface {
-	Create(review *Review) error
-	FindByID(id int) (*Review, error)
-	FindByGameID(gameID int) ([]*Review, error)
-	FindByUserID(userID int) ([]*Review, error)
-	Update(review *Review) error
-	Delete(id int) error
+	Create(ctx context.Context, review *Review) error
+	FindByID(ctx context.Context, id int) (*Review, error)
+	FindByGameID(ctx context.Context, gameID int, query ReviewQuery) ([]*Review, int64, error)
+	FindByUserID(ctx context.Context, userID int) ([]*Review, error)
+	FindByGameAndUser(ctx context.Context, gameID, userID int) (*Review, error)
+	Update(ctx context.Context, review *Review) error
+	Delete(ctx context.Context, id int) error
+	CreateReport(ctx context.Context, report *ReviewReport) error
+	FindReport(ctx context.Context, reviewID, userID int) (*ReviewReport, error)
+	FindModerationQueue(ctx context.Context, limit, offset int) ([]*Review, error)
+	Moderate(ctx context.Context, reviewID int, status string) error
+	CreateWithBonus(ctx context.Context, review *Review, bonus *PointsLedger) error
+	Vote(ctx context.Context, reviewID, userID int, helpful bool) error
+	FindReply(ctx context.Context, reviewID int) (*ReviewReply, error)
+	SaveReply(ctx context.Context, reply *ReviewReply) error
+	DeleteReply(ctx context.Context, reviewID int) error
 }
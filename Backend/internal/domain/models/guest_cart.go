@@ -0,0 +1,39 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// GuestCart represents the shopping cart of a visitor who is not logged in.
+// It is stored in the database so that any backend instance can serve it.
+type GuestCart struct {
+	ID        int    `gorm:"primaryKey"`
+	Token     string `gorm:"not null;uniqueIndex" validate:"required"` // Random identifier handed to the visitor
+	CreatedAt time.Time
+	UpdatedAt time.Time `gorm:"index"`
+
+	// Relations
+	Items []*GuestCartItem
+}
+
+// GuestCartItem represents a game in a guest cart
+type GuestCartItem struct {
+	ID          int        `gorm:"primaryKey"`
+	GuestCartID int        `gorm:"not null;uniqueIndex:idx_guest_cart_item" validate:"required"`
+	GuestCart   *GuestCart `gorm:"foreignKey:GuestCartID"`
+	GameID      int        `gorm:"not null;uniqueIndex:idx_guest_cart_item" validate:"required"`
+	Game        *Game      `gorm:"foreignKey:GameID"`
+	CreatedAt   time.Time
+}
+
+// GuestCartRepository defines the interface for guest cart data access
+type GuestCartRepository interface {
+	Create(ctx context.Context, cart *GuestCart) error
+	FindByToken(ctx context.Context, token string) (*GuestCart, error)
+	AddGame(ctx context.Context, cartID, gameID int) error
+	RemoveGame(ctx context.Context, cartID, gameID int) error
+	Clear(ctx context.Context, cartID int) error
+	MergeIntoUserCart(ctx context.Context, cartID, userID int, games []*Game, currency string) error
+	DeleteInactiveSince(ctx context.Context, since time.Time) (int64, error)
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvoiceIssued is returned when an issued invoice would be changed
+var ErrInvoiceIssued = errors.New("invoices cannot be changed once issued")
+
+// Invoice is the receipt of a paid order. It copies everything it shows from the order and
+// the buyer when issued, so later changes to either never alter it.
+type Invoice struct {
+	ID             int    `gorm:"primaryKey"`
+	Sequence       int    `gorm:"not null;uniqueIndex" validate:"required"` // Gapless, in issue order
+	Number         string `gorm:"not null;uniqueIndex" validate:"required"`
+	OrderID        int    `gorm:"not null;uniqueIndex" validate:"required"`
+	Order          *Order `gorm:"foreignKey:OrderID"`
+	UserID         int    `gorm:"not null;index" validate:"required"`
+	BuyerName      string `gorm:"not null"`
+	BuyerEmail     string `gorm:"not null"`
+	BuyerRegion    string
+	Currency       string `gorm:"type:varchar(3);not null" validate:"required"`
+	Subtotal       int64  `gorm:"not null"` // Sum of the lines before discounts
+	CouponDiscount int64  `gorm:"not null"`
+	TaxName        string // Empty when no tax was charged
+	TaxRate        int    `gorm:"not null"` // In basis points
+	TaxInclusive   bool   `gorm:"not null"`
+	NetTotal       int64  `gorm:"not null"`
+	TaxTotal       int64  `gorm:"not null"`
+	GrossTotal     int64  `gorm:"not null"`
+	PointsDiscount int64  `gorm:"not null"` // Paid with loyalty points
+	CreditPaid     int64  `gorm:"not null"` // Paid with store credit
+	TotalPaid      int64  `gorm:"not null"`
+	OrderedAt      time.Time
+	IssuedAt       time.Time `gorm:"not null"`
+
+	// Relations
+	Lines []*InvoiceLine
+}
+
+// InvoiceLine is a line of an invoice
+type InvoiceLine struct {
+	ID          int    `gorm:"primaryKey"`
+	InvoiceID   int    `gorm:"not null;index" validate:"required"`
+	Description string `gorm:"not null"`
+	Quantity    int    `gorm:"not null"`
+	UnitPrice   int64  `gorm:"not null"`
+	NetAmount   int64  `gorm:"not null"` // After the line's share of the coupon
+	TaxAmount   int64  `gorm:"not null"`
+	GrossAmount int64  `gorm:"not null"`
+}
+
+// InvoiceNumber formats the number of the invoice issued in the given position
+func InvoiceNumber(sequence int) string {
+	return fmt.Sprintf("INV-%06d", sequence)
+}
+
+// BeforeUpdate refuses to change an issued invoice
+func (i *Invoice) BeforeUpdate(tx *gorm.DB) error {
+	return ErrInvoiceIssued
+}
+
+// BeforeDelete refuses to delete an issued invoice
+func (i *Invoice) BeforeDelete(tx *gorm.DB) error {
+	return ErrInvoiceIssued
+}
+
+// BeforeUpdate refuses to change a line of an issued invoice
+func (l *InvoiceLine) BeforeUpdate(tx *gorm.DB) error {
+	return ErrInvoiceIssued
+}
+
+// BeforeDelete refuses to delete a line of an issued invoice
+func (l *InvoiceLine) BeforeDelete(tx *gorm.DB) error {
+	return ErrInvoiceIssued
+}
+
+// InvoiceRepository interface for invoice data access. Invoices are only ever added.
+type InvoiceRepository interface {
+	// Issue numbers and saves the invoice, unless its order already has one, which is returned instead
+	Issue(ctx context.Context, invoice *Invoice) (*Invoice, error)
+	FindByOrderID(ctx context.Context, orderID int) (*Invoice, error)
+}
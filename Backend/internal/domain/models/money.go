@@ -0,0 +1,125 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Amounts of money are stored as int64 counts of the currency's minor unit, e.g. cents,
+// next to an ISO 4217 currency code. All rounding goes through the helpers below, so the
+// cart, the order and refunds always round the same way: half up, to a whole minor unit.
+
+// DefaultCurrency is the store currency when none is configured
+const DefaultCurrency = "USD"
+
+// Money is an amount in minor units of a currency
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// String formats the amount for people, e.g. "19.99 USD"
+func (m Money) String() string {
+	return FormatMoney(m.Amount, m.Currency)
+}
+
+// currencyExponents holds the number of minor-unit digits of each supported currency
+var currencyExponents = map[string]int{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"RUB": 2,
+	"UAH": 2,
+	"KZT": 2,
+	"PLN": 2,
+	"CAD": 2,
+	"AUD": 2,
+	"CNY": 2,
+	"BRL": 2,
+	"JPY": 0,
+	"KRW": 0,
+}
+
+// IsCurrency reports whether prices can be set in the currency
+func IsCurrency(code string) bool {
+	_, ok := currencyExponents[code]
+	return ok
+}
+
+// CurrencyExponent returns the number of minor-unit digits of a supported currency
+func CurrencyExponent(code string) int {
+	return currencyExponents[code]
+}
+
+// NormalizeCurrency upper-cases a currency code and trims spaces around it
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// FormatMoney formats an amount in minor units for people, e.g. "19.99 USD"
+func FormatMoney(amount int64, currency string) string {
+	exponent := CurrencyExponent(currency)
+	if exponent == 0 {
+		return fmt.Sprintf("%d %s", amount, currency)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	unit := int64(1)
+	for i := 0; i < exponent; i++ {
+		unit *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/unit, exponent, amount%unit, currency)
+}
+
+// MajorUnits converts an amount in minor units to a decimal in major units, e.g. 1999 cents
+// to 19.99. Decimals are for the API and display only; amounts are never computed with them.
+func MajorUnits(amount int64, currency string) float64 {
+	return float64(amount) / math.Pow10(CurrencyExponent(currency))
+}
+
+// MinorUnits converts a decimal amount in major units to minor units, rounded half away from zero
+func MinorUnits(amount float64, currency string) int64 {
+	return int64(math.Round(amount * math.Pow10(CurrencyExponent(currency))))
+}
+
+// MulDiv returns amount * numerator / denominator rounded half up, without overflowing
+// on large amounts. It is used for every proportional share of an amount.
+func MulDiv(amount, numerator, denominator int64) int64 {
+	if denominator == 0 {
+		return 0
+	}
+
+	product := new(big.Int).Mul(big.NewInt(amount), big.NewInt(numerator))
+	d := big.NewInt(denominator)
+	if d.Sign() < 0 {
+		product.Neg(product)
+		d.Neg(d)
+	}
+
+	// Round half up: floor((2 * product + d) / (2 * d))
+	product.Mul(product, big.NewInt(2)).Add(product, d)
+	d.Mul(d, big.NewInt(2))
+	return new(big.Int).Div(product, d).Int64() // Euclidean division floors for a positive divisor
+}
+
+// PercentOf returns percent percent of an amount, rounded half up
+func PercentOf(amount int64, percent int64) int64 {
+	return MulDiv(amount, percent, 100)
+}
+
+// PercentOff returns an amount reduced by percent percent. The reduction is rounded,
+// not the result, so a discount and a coupon of the same percentage take off the same amount.
+func PercentOff(amount int64, percent int64) int64 {
+	if percent <= 0 {
+		return amount
+	}
+	if percent >= 100 {
+		return 0
+	}
+	return amount - PercentOf(amount, percent)
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Notification types
+const (
+	NotificationTypePriceDrop = "price_drop" // A wishlisted game got cheaper
+	NotificationTypeDiscount  = "discount"   // A wishlisted game went on sale
+	NotificationTypeRelease   = "release"    // A wishlisted game came out
+)
+
+// Notification represents an in-app notification for a user
+type Notification struct {
+	ID        int    `gorm:"primaryKey"`
+	UserID    int    `gorm:"not null;index" validate:"required"`
+	User      *User  `gorm:"foreignKey:UserID"`
+	GameID    *int   // Game the notification is about, if any
+	Game      *Game  `gorm:"foreignKey:GameID"`
+	Type      string `gorm:"not null" validate:"required"`
+	Title     string `gorm:"not null" validate:"required"`
+	Message   string `gorm:"type:text"`
+	ReadAt    *time.Time
+	EmailedAt *time.Time // Set once the notification was also sent by email
+	CreatedAt time.Time
+}
+
+// NotificationPreference holds which notifications a user wants and how.
+// Users without a stored preference get the defaults of the notification repository.
+type NotificationPreference struct {
+	ID         int   `gorm:"primaryKey"`
+	UserID     int   `gorm:"not null;uniqueIndex" validate:"required"`
+	User       *User `gorm:"foreignKey:UserID"`
+	PriceDrops bool  `gorm:"not null"` // Price drops and sales of wishlisted games
+	Releases   bool  `gorm:"not null"` // Releases of wishlisted games
+	Email      bool  `gorm:"not null"` // Also send notifications by email
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NotificationRepository defines the interface for notification data access
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *Notification) error
+	FindByUserID(ctx context.Context, userID int, unreadOnly bool, limit, offset int) ([]*Notification, error)
+	CountUnread(ctx context.Context, userID int) (int64, error)
+	MarkRead(ctx context.Context, userID, id int) error
+	MarkAllRead(ctx context.Context, userID int) error
+	MarkEmailed(ctx context.Context, id int) error
+	FindPreference(ctx context.Context, userID int) (*NotificationPreference, error)
+	SavePreference(ctx context.Context, preference *NotificationPreference) error
+}
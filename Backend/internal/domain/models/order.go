@@ -1,21 +1,47 @@
 package models
 
 import (
+	"context"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// Order statuses
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusCancelled = "cancelled"
+	OrderStatusRefunded  = "refunded"
+)
+
 // Order represents a user's order
 type Order struct {
-	ID        int     `gorm:"primaryKey"`
-	UserID    int     `gorm:"not null" validate:"required"`
-	User      *User   `gorm:"foreignKey:UserID"`
-	TotalCost float64 `gorm:"not null" validate:"required,gte=0"`
-	Status    string  `gorm:"not null;default:'pending'"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID             int     `gorm:"primaryKey"`
+	UserID         int     `gorm:"not null" validate:"required"`
+	User           *User   `gorm:"foreignKey:UserID"`
+	Currency       string  `gorm:"type:varchar(3);not null" validate:"required"` // Currency of every amount of the order
+	Subtotal       int64   `gorm:"not null;default:0" validate:"gte=0"`          // Sum of the items before discounts
+	CouponID       *int    // Coupon used on the order
+	Coupon         *Coupon `gorm:"foreignKey:CouponID"`
+	CouponDiscount int64   `gorm:"not null;default:0" validate:"gte=0"` // Amount taken off by the coupon
+	TaxRegion      string  // Region whose tax was charged; empty when none was
+	TaxName        string
+	TaxRate        int    `gorm:"not null;default:0"` // In basis points
+	TaxInclusive   bool   `gorm:"not null;default:false"`
+	NetTotal       int64  `gorm:"not null;default:0" validate:"gte=0"` // Discounted items before tax
+	TaxTotal       int64  `gorm:"not null;default:0" validate:"gte=0"`
+	GrossTotal     int64  `gorm:"not null;default:0" validate:"gte=0"` // Discounted items with tax, before points and store credit
+	PointsRedeemed int    `gorm:"not null;default:0" validate:"gte=0"` // Loyalty points spent on the order
+	PointsDiscount int64  `gorm:"not null;default:0" validate:"gte=0"` // Value of the redeemed points
+	PointsEarned   int    `gorm:"not null;default:0" validate:"gte=0"` // Loyalty points credited once paid
+	CreditPaid     int64  `gorm:"not null;default:0" validate:"gte=0"` // Store credit spent on the order
+	TotalCost      int64  `gorm:"not null" validate:"gte=0"`           // Amount to pay
+	RefundedAmount int64  `gorm:"not null;default:0" validate:"gte=0"` // Amount paid back to the user
+	Status         string `gorm:"not null;default:'pending'"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
 
 	// Relations
 	OrderItems []*OrderItem
@@ -23,24 +49,83 @@ type Order struct {
 
 // OrderItem represents an item in an order
 type OrderItem struct {
-	ID        int     `gorm:"primaryKey"`
-	OrderID   int     `gorm:"not null" validate:"required"`
-	Order     *Order  `gorm:"foreignKey:OrderID"`
-	GameID    int     `gorm:"not null" validate:"required"`
-	Game      *Game   `gorm:"foreignKey:GameID"`
-	Price     float64 `gorm:"not null" validate:"required,gte=0"`
-	Quantity  int     `gorm:"not null;default:1" validate:"required,min=1"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID              int              `gorm:"primaryKey"`
+	OrderID         int              `gorm:"not null" validate:"required"`
+	Order           *Order           `gorm:"foreignKey:OrderID"`
+	GameID          *int             // Set for a single game
+	Game            *Game            `gorm:"foreignKey:GameID"`
+	BundleID        *int             // Or for a bundle, granting each of its games
+	Bundle          *Bundle          `gorm:"foreignKey:BundleID"`
+	BundleGames     []*OrderItemGame `gorm:"foreignKey:OrderItemID"`    // Games of the bundle when it was ordered
+	Price           int64            `gorm:"not null" validate:"gte=0"` // Unit price in minor units of the order currency
+	Quantity        int              `gorm:"not null;default:1" validate:"required,min=1"`
+	NetAmount       int64            `gorm:"not null;default:0" validate:"gte=0"` // Line total after its share of the coupon, before tax
+	TaxAmount       int64            `gorm:"not null;default:0" validate:"gte=0"`
+	GrossAmount     int64            `gorm:"not null;default:0" validate:"gte=0"` // Line total after its share of the coupon, with tax
+	PreOrder        bool             `gorm:"not null;default:false"`              // Game was not released yet when ordered
+	GiftRecipientID *int             // Set when the item is bought for another user
+	GiftRecipient   *User            `gorm:"foreignKey:GiftRecipientID"`
+	GiftMessage     string
+	RefundedAt      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+// OrderItemGame is a game of an ordered bundle, as the bundle was when ordered, so later
+// changes to the bundle do not change what the order grants
+type OrderItemGame struct {
+	OrderItemID int `gorm:"primaryKey;autoIncrement:false"`
+	GameID      int `gorm:"primaryKey;autoIncrement:false"`
+}
+
+// Gift statuses
+const (
+	GiftStatusPending  = "pending"
+	GiftStatusAccepted = "accepted"
+	GiftStatusDeclined = "declined"
+)
+
+// Gift represents a game bought by one user for another, awaiting the recipient's answer
+type Gift struct {
+	ID          int        `gorm:"primaryKey"`
+	OrderItemID int        `gorm:"not null;uniqueIndex" validate:"required"`
+	OrderItem   *OrderItem `gorm:"foreignKey:OrderItemID"`
+	SenderID    int        `gorm:"not null;index" validate:"required"`
+	Sender      *User      `gorm:"foreignKey:SenderID"`
+	RecipientID int        `gorm:"not null;index" validate:"required"`
+	Recipient   *User      `gorm:"foreignKey:RecipientID"`
+	GameID      int        `gorm:"not null" validate:"required"`
+	Game        *Game      `gorm:"foreignKey:GameID"`
+	Message     string
+	Status      string `gorm:"not null;default:'pending';index"`
+	RespondedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
 }
 
 // OrderRepository defines the interface for order data access
 type OrderRepository interface {
-	Create(order *Order) error
-	FindByID(id int) (*Order, error)
-	FindByUserID(userID int) ([]*Order, error)
-	Update(order *Order) error
-	FindAll(limit, offset int) ([]*Order, error)
-	CreateFromCart(userID int) (*Order, error)
+	Create(ctx context.Context, order *Order) error
+	FindByID(ctx context.Context, id int) (*Order, error)
+	FindByUserID(ctx context.Context, userID int) ([]*Order, error)
+	Update(ctx context.Context, order *Order) error
+	FindAll(ctx context.Context, limit, offset int) ([]*Order, error)
+	// PlaceOrder saves the order and takes the cart items it was made from out of the cart
+	PlaceOrder(ctx context.Context, order *Order, cartItemIDs []int) error
+	// TransitionStatus moves the order out of fromStatus, recording the points and store credit entries and, once paid, issuing the invoice
+	TransitionStatus(ctx context.Context, order *Order, fromStatus string, entries []*PointsLedger, credit *StoreCreditLedger, invoice *Invoice) error
+	HasPendingGame(ctx context.Context, userID, gameID int) (bool, error)
+	FindItemByID(ctx context.Context, id int) (*OrderItem, error)
+	CancelPreOrderItem(ctx context.Context, order *Order, item *OrderItem, refundAmount int64, entries []*PointsLedger, credit *StoreCreditLedger) error
+}
+
+// GiftRepository defines the interface for gift data access
+type GiftRepository interface {
+	FindByID(ctx context.Context, id int) (*Gift, error)
+	FindByRecipientID(ctx context.Context, recipientID int) ([]*Gift, error)
+	HasPending(ctx context.Context, recipientID, gameID int) (bool, error)
+	Accept(ctx context.Context, gift *Gift) error
+	Decline(ctx context.Context, gift *Gift, refundAmount int64, entries []*PointsLedger, credit *StoreCreditLedger) error
 }
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Points ledger reasons
+const (
+	PointsReasonOrderEarned        = "order_earned"
+	PointsReasonOrderRedeemed      = "order_redeemed"
+	PointsReasonOrderReversed      = "order_reversed"
+	PointsReasonRedemptionRefunded = "redemption_refunded"
+	PointsReasonReviewBonus        = "review_bonus"
+	PointsReasonCodeRedeemed       = "code_redeemed"
+	PointsReasonAdjustment         = "adjustment"
+)
+
+// PointsLedger records a single credit (positive amount) or debit (negative amount)
+// of a user's loyalty points. User.Points always equals the sum of the user's entries.
+type PointsLedger struct {
+	ID        int    `gorm:"primaryKey"`
+	UserID    int    `gorm:"not null;index" validate:"required"`
+	User      *User  `gorm:"foreignKey:UserID"`
+	Amount    int    `gorm:"not null"`
+	Balance   int    `gorm:"not null"` // Balance after the entry was applied
+	Reason    string `gorm:"not null;index"`
+	OrderID   *int   `gorm:"index"`
+	Order     *Order `gorm:"foreignKey:OrderID"`
+	GameID    *int   `gorm:"index"`
+	Game      *Game  `gorm:"foreignKey:GameID"`
+	Note      string
+	CreatedAt time.Time
+}
+
+// PointsRepository defines the interface for loyalty points data access
+type PointsRepository interface {
+	Record(ctx context.Context, entry *PointsLedger) error
+	FindByUserID(ctx context.Context, userID, limit, offset int) ([]*PointsLedger, error)
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RedeemCode represents a key that grants a game or store credit when redeemed
+type RedeemCode struct {
+	ID             int    `gorm:"primaryKey"`
+	Code           string `gorm:"not null;uniqueIndex" validate:"required"`
+	GameID         *int   // Game granted by the code; nil for store-credit codes
+	Game           *Game  `gorm:"foreignKey:GameID"`
+	CreditAmount   int64  `gorm:"not null;default:0"` // Store credit granted, in minor units of CreditCurrency
+	CreditCurrency string `gorm:"type:varchar(3)"`
+	CreditPoints   int    `gorm:"not null;default:0"` // Loyalty points credited by codes generated before store credit had its own balance
+	MaxUses        int    `gorm:"not null;default:1" validate:"min=1"`
+	Uses           int    `gorm:"not null;default:0"`
+	ExpiresAt      *time.Time
+	Note           string // e.g. the partner store or giveaway the code was made for
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	RedeemCodeUses []*RedeemCodeUse
+}
+
+// RedeemCodeUse records a user redeeming a code
+type RedeemCodeUse struct {
+	ID           int         `gorm:"primaryKey"`
+	RedeemCodeID int         `gorm:"not null;uniqueIndex:idx_redeem_code_use_code_user" validate:"required"`
+	RedeemCode   *RedeemCode `gorm:"foreignKey:RedeemCodeID"`
+	UserID       int         `gorm:"not null;uniqueIndex:idx_redeem_code_use_code_user;index" validate:"required"`
+	User         *User       `gorm:"foreignKey:UserID"`
+	CreatedAt    time.Time
+}
+
+// RedeemCodeRepository defines the interface for redeem code data access
+type RedeemCodeRepository interface {
+	CreateBatch(ctx context.Context, codes []*RedeemCode) error
+	FindAll(ctx context.Context, limit, offset int) ([]*RedeemCode, error)
+	Redeem(ctx context.Context, code string, userID int) (*RedeemCode, error)
+}
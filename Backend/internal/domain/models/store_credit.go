@@ -0,0 +1,49 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Store credit ledger reasons
+const (
+	CreditReasonCodeRedeemed  = "code_redeemed"
+	CreditReasonOrderPaid     = "order_paid"
+	CreditReasonOrderRefunded = "order_refunded"
+)
+
+// StoreCredit holds a user's store credit in one currency. Unlike loyalty points, credit is
+// money owed to the user, so it is kept in minor units and never converted between currencies.
+type StoreCredit struct {
+	ID        int    `gorm:"primaryKey"`
+	UserID    int    `gorm:"not null;uniqueIndex:idx_store_credit_user_currency" validate:"required"`
+	User      *User  `gorm:"foreignKey:UserID"`
+	Currency  string `gorm:"type:varchar(3);not null;uniqueIndex:idx_store_credit_user_currency" validate:"required"`
+	Balance   int64  `gorm:"not null;default:0" validate:"gte=0"` // In minor units
+	UpdatedAt time.Time
+}
+
+// StoreCreditLedger records a single credit (positive amount) or debit (negative amount)
+// of a user's store credit. StoreCredit.Balance always equals the sum of the user's entries
+// in its currency.
+type StoreCreditLedger struct {
+	ID           int         `gorm:"primaryKey"`
+	UserID       int         `gorm:"not null;index" validate:"required"`
+	User         *User       `gorm:"foreignKey:UserID"`
+	Currency     string      `gorm:"type:varchar(3);not null" validate:"required"`
+	Amount       int64       `gorm:"not null"` // In minor units
+	Balance      int64       `gorm:"not null"` // Balance in the currency after the entry was applied
+	Reason       string      `gorm:"not null;index"`
+	RedeemCodeID *int        `gorm:"index"`
+	RedeemCode   *RedeemCode `gorm:"foreignKey:RedeemCodeID"`
+	OrderID      *int        `gorm:"index"`
+	Order        *Order      `gorm:"foreignKey:OrderID"`
+	Note         string
+	CreatedAt    time.Time
+}
+
+// StoreCreditRepository defines the interface for store credit data access
+type StoreCreditRepository interface {
+	FindBalances(ctx context.Context, userID int) ([]*StoreCredit, error)
+	FindByUserID(ctx context.Context, userID, limit, offset int) ([]*StoreCreditLedger, error)
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// TaxRule is the sales tax, such as VAT, charged to buyers from a region
+type TaxRule struct {
+	ID        int    `gorm:"primaryKey"`
+	Region    string `gorm:"type:varchar(8);not null;uniqueIndex" validate:"required"` // Region code, as on users
+	Name      string `gorm:"not null" validate:"required"`                             // Shown to buyers, e.g. "VAT"
+	Rate      int    `gorm:"not null" validate:"gte=0,lte=10000"`                      // In basis points, 2000 is 20%
+	Inclusive bool   `gorm:"not null"`                                                 // Prices already include the tax
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TaxRuleRepository interface for tax rule data access
+type TaxRuleRepository interface {
+	Create(ctx context.Context, rule *TaxRule) error
+	FindByID(ctx context.Context, id int) (*TaxRule, error)
+	FindByRegion(ctx context.Context, region string) (*TaxRule, error)
+	FindAll(ctx context.Context) ([]*TaxRule, error)
+	Update(ctx context.Context, rule *TaxRule) error
+	Delete(ctx context.Context, id int) error
+}
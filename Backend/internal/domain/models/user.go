@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,6 +16,8 @@ type User struct {
 	RoleID       int    `gorm:"not null;default:2"`
 	Role         *Role  `gorm:"foreignKey:RoleID"`
 	Points       int    `gorm:"default:0"`
+	Region       string `gorm:"type:varchar(8)"` // Region the user shops from, matched against game restrictions
+	Currency     string `gorm:"type:varchar(3)"` // Currency the user pays in; chosen from the region when empty
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	DeletedAt    gorm.DeletedAt `gorm:"index"`
@@ -44,21 +47,22 @@ type Role struct {
 
 // UserRepository defines the interface for user data access
 type UserRepository interface {
-	Create(user *User) error
-	FindByID(id int) (*User, error)
-	FindByEmail(email string) (*User, error)
-	FindByNickname(nickname string) (*User, error)
-	Update(user *User) error
-	Delete(id int) error
-	FindAll(limit, offset int) ([]*User, error)
+	Create(ctx context.Context, user *User) error
+	FindByID(ctx context.Context, id int) (*User, error)
+	FindByEmail(ctx context.Context, email string) (*User, error)
+	FindByNickname(ctx context.Context, nickname string) (*User, error)
+	Update(ctx context.Context, user *User) error
+	Delete(ctx context.Context, id int) error
+	FindAll(ctx context.Context, limit, offset int) ([]*User, error)
+	CountByRole(ctx context.Context, roleID int) (int64, error)
 }
 
 // RoleRepository defines the interface for role data access
 type RoleRepository interface {
-	Create(role *Role) error
-	FindByID(id int) (*Role, error)
-	FindByType(roleType string) (*Role, error)
-	Update(role *Role) error
-	Delete(id int) error
-	FindAll() ([]*Role, error)
+	Create(ctx context.Context, role *Role) error
+	FindByID(ctx context.Context, id int) (*Role, error)
+	FindByType(ctx context.Context, roleType string) (*Role, error)
+	Update(ctx context.Context, role *Role) error
+	Delete(ctx context.Context, id int) error
+	FindAll(ctx context.Context) ([]*Role, error)
 }
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -26,7 +27,10 @@ func NewAuthService(userRepo models.UserRepository, roleRepo models.RoleReposito
 }
 
 // VerifyToken verifies a JWT token and returns the user ID and role
-func (s *AuthServiceImpl) VerifyToken(token string) (int, string, error) {
+func (s *AuthServiceImpl) VerifyToken(ctx context.Context, token string) (int, string, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.VerifyToken")
+	defer span.End()
+
 	// Verify the token
 	parsedToken, err := s.authUtils.VerifyToken(token)
 	if err != nil {
@@ -59,12 +63,18 @@ func (s *AuthServiceImpl) VerifyToken(token string) (int, string, error) {
 }
 
 // RefreshUserToken refreshes a user's token
-func (s *AuthServiceImpl) RefreshUserToken(token string) (string, string, error) {
+func (s *AuthServiceImpl) RefreshUserToken(ctx context.Context, token string) (string, string, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.RefreshUserToken")
+	defer span.End()
+
 	return s.authUtils.RefreshToken(token)
 }
 
 // MatchUserTypeToID checks if a user can access a resource
-func (s *AuthServiceImpl) MatchUserTypeToID(userID int, roleType string) error {
+func (s *AuthServiceImpl) MatchUserTypeToID(ctx context.Context, userID int, roleType string) error {
+	ctx, span := tracer.Start(ctx, "AuthService.MatchUserTypeToID")
+	defer span.End()
+
 	// Admin can access all resources
 	if roleType == "admin" {
 		return nil
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/interfaces/dto"
+)
+
+// BundleServiceImpl implements BundleService interface
+type BundleServiceImpl struct {
+	bundleRepo models.BundleRepository
+	gameRepo   models.GameRepository
+	currencies CurrencyRules
+}
+
+// NewBundleService creates a new bundle service
+func NewBundleService(bundleRepo models.BundleRepository, gameRepo models.GameRepository, currencies CurrencyRules) BundleService {
+	return &BundleServiceImpl{
+		bundleRepo: bundleRepo,
+		gameRepo:   gameRepo,
+		currencies: currencies,
+	}
+}
+
+// CreateBundle creates a bundle of existing games
+func (s *BundleServiceImpl) CreateBundle(ctx context.Context, bundleDTO *dto.BundleCreateDTO) (*dto.BundleDTO, error) {
+	ctx, span := tracer.Start(ctx, "BundleService.CreateBundle")
+	defer span.End()
+
+	currency, err := s.currencies.resolve(bundleDTO.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.findGames(ctx, bundleDTO.GameIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &models.Bundle{
+		Name:        bundleDTO.Name,
+		Description: bundleDTO.Description,
+		Price:       models.MinorUnits(bundleDTO.Price, currency),
+		Currency:    currency,
+		Active:      true,
+		Games:       games,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.bundleRepo.Create(ctx, bundle); err != nil {
+		return nil, err
+	}
+
+	return dto.BundleDTOFromModel(bundle), nil
+}
+
+// GetBundleByID gets a bundle with its games
+func (s *BundleServiceImpl) GetBundleByID(ctx context.Context, id int) (*dto.BundleDTO, error) {
+	ctx, span := tracer.Start(ctx, "BundleService.GetBundleByID")
+	defer span.End()
+
+	bundle, err := s.bundleRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("bundle not found")
+		}
+		return nil, err
+	}
+
+	return dto.BundleDTOFromModel(bundle), nil
+}
+
+// GetBundles gets the bundles on sale
+func (s *BundleServiceImpl) GetBundles(ctx context.Context, limit, offset int) ([]*dto.BundleDTO, error) {
+	ctx, span := tracer.Start(ctx, "BundleService.GetBundles")
+	defer span.End()
+
+	bundles, err := s.bundleRepo.FindAll(ctx, limit, offset, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.BundleDTOsFromModels(bundles), nil
+}
+
+// UpdateBundle updates a bundle; orders already placed keep the price they were charged
+func (s *BundleServiceImpl) UpdateBundle(ctx context.Context, id int, bundleDTO *dto.BundleUpdateDTO) (*dto.BundleDTO, error) {
+	ctx, span := tracer.Start(ctx, "BundleService.UpdateBundle")
+	defer span.End()
+
+	bundle, err := s.bundleRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("bundle not found")
+		}
+		return nil, err
+	}
+
+	if bundleDTO.Name != "" {
+		bundle.Name = bundleDTO.Name
+	}
+	if bundleDTO.Description != "" {
+		bundle.Description = bundleDTO.Description
+	}
+	if bundleDTO.Currency != "" {
+		if bundle.Currency, err = s.currencies.resolve(bundleDTO.Currency); err != nil {
+			return nil, err
+		}
+	}
+	if bundleDTO.Price != nil {
+		bundle.Price = models.MinorUnits(*bundleDTO.Price, bundle.Currency)
+	}
+	if bundleDTO.Active != nil {
+		bundle.Active = *bundleDTO.Active
+	}
+	if bundleDTO.GameIDs != nil {
+		if bundle.Games, err = s.findGames(ctx, bundleDTO.GameIDs); err != nil {
+			return nil, err
+		}
+	}
+	bundle.UpdatedAt = time.Now()
+
+	if err := s.bundleRepo.Update(ctx, bundle); err != nil {
+		return nil, err
+	}
+
+	return dto.BundleDTOFromModel(bundle), nil
+}
+
+// DeleteBundle deletes a bundle
+func (s *BundleServiceImpl) DeleteBundle(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "BundleService.DeleteBundle")
+	defer span.End()
+
+	if _, err := s.bundleRepo.FindByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("bundle not found")
+		}
+		return err
+	}
+
+	return s.bundleRepo.Delete(ctx, id)
+}
+
+// findGames loads the games of a bundle, rejecting unknown and repeated games
+func (s *BundleServiceImpl) findGames(ctx context.Context, gameIDs []int) ([]*models.Game, error) {
+	games := make([]*models.Game, 0, len(gameIDs))
+	seen := make(map[int]bool, len(gameIDs))
+	for _, gameID := range gameIDs {
+		if seen[gameID] {
+			return nil, errors.New("a bundle cannot contain the same game twice")
+		}
+		seen[gameID] = true
+
+		game, err := s.gameRepo.FindByID(ctx, gameID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("game not found")
+			}
+			return nil, err
+		}
+		games = append(games, game)
+	}
+	return games, nil
+}
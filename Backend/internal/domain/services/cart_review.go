@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/interfaces/dto"
+)
+
+// cartReviewer prices a user's cart and finds what changed since its items were added,
+// so the cart view and checkout always agree on what will be charged
+type cartReviewer struct {
+	cartRepo   models.CartRepository
+	couponRepo models.CouponRepository
+	userRepo   models.UserRepository
+	pricing    pricing
+}
+
+// cartReview is a priced cart with the warnings the user should see before checking out
+type cartReview struct {
+	Cart      *models.ShoppingCart
+	Items     []*models.CartItem
+	Lines     []priceLine                      // Lines of the items that can still be bought
+	Warnings  map[int][]dto.CartItemWarningDTO // By cart item ID
+	Blocked   bool                             // Some item can no longer be bought
+	NoRegion  bool                             // The user has no region, so the tax is unknown and checkout is refused
+	Breakdown priceBreakdown
+	Coupon    *models.Coupon
+	CouponErr error  // Why the coupon currently gives no discount
+	Version   string // Changes whenever the amount to be charged changes
+
+	lines map[int]priceLine // By cart item ID
+}
+
+// review prices the user's cart and checks every item is still for sale at the price it was added at
+func (r cartReviewer) review(ctx context.Context, userID int) (*cartReview, error) {
+	cart, err := r.cartRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := r.cartRepo.GetCartItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := r.pricing.currencies.forUser(user)
+	lines, err := r.pricing.cartLines(ctx, userID, currency, items)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &cartReview{
+		Cart:     cart,
+		Items:    items,
+		Lines:    lines,
+		Warnings: make(map[int][]dto.CartItemWarningDTO),
+		lines:    make(map[int]priceLine, len(lines)),
+	}
+	for _, line := range lines {
+		review.lines[line.CartItem.ID] = line
+	}
+
+	for _, item := range items {
+		line, ok := review.lines[item.ID]
+		if !ok {
+			review.warn(item, dto.CartWarningUnavailable, unavailableMessage(item, currency), nil)
+			review.Blocked = true
+			continue
+		}
+		if restrictedIn(line, user.Region) {
+			review.warn(item, dto.CartWarningRestricted, "Not available in your region", nil)
+			review.Blocked = true
+		}
+		if item.AddedPrice != nil && (*item.AddedPrice != line.Price || item.AddedCurrency != currency) {
+			previous := &models.Money{Amount: *item.AddedPrice, Currency: item.AddedCurrency}
+			message := fmt.Sprintf("Price changed from %s to %s since it was added", previous, models.FormatMoney(line.Price, currency))
+			review.warn(item, dto.CartWarningPriceChanged, message, previous)
+		}
+	}
+
+	// The cart can still be shown without tax, but not checked out
+	taxRule, err := r.pricing.taxes.ruleFor(ctx, user.Region)
+	if errors.Is(err, errNoRegion) {
+		review.NoRegion = true
+	} else if err != nil {
+		return nil, err
+	}
+
+	review.Breakdown, review.Coupon, review.CouponErr, err = r.priceCart(ctx, cart, currency, lines, taxRule)
+	if err != nil {
+		return nil, err
+	}
+	review.Version = review.version()
+
+	return review, nil
+}
+
+// priceCart prices the cart lines in the currency with the cart's coupon and taxes them under the rule.
+// A coupon that can no longer be used gives no discount; the reason is returned alongside the coupon.
+func (r cartReviewer) priceCart(ctx context.Context, cart *models.ShoppingCart, currency string, lines []priceLine, taxRule *models.TaxRule) (priceBreakdown, *models.Coupon, error, error) {
+	if cart.CouponID == nil {
+		return r.pricing.price(currency, lines, nil, taxRule), nil, nil, nil
+	}
+
+	coupon, err := r.couponRepo.FindByID(ctx, *cart.CouponID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return r.pricing.price(currency, lines, nil, taxRule), nil, nil, nil
+		}
+		return priceBreakdown{}, nil, nil, err
+	}
+
+	if couponErr := r.pricing.checkCoupon(ctx, coupon, cart.UserID, currency, lines); couponErr != nil {
+		return r.pricing.price(currency, lines, nil, taxRule), coupon, couponErr, nil
+	}
+
+	return r.pricing.price(currency, lines, coupon, taxRule), coupon, nil, nil
+}
+
+// line returns the price line of a cart item, if it can still be bought
+func (r *cartReview) line(itemID int) (priceLine, bool) {
+	line, ok := r.lines[itemID]
+	return line, ok
+}
+
+// amounts returns the discounted and taxed amounts of a cart item, if it can still be bought
+func (r *cartReview) amounts(itemID int) (taxAmounts, bool) {
+	for i, line := range r.Lines {
+		if line.CartItem.ID == itemID {
+			return r.Breakdown.Lines[i], true
+		}
+	}
+	return taxAmounts{}, false
+}
+
+// warn adds a warning to a cart item
+func (r *cartReview) warn(item *models.CartItem, code, message string, previousPrice *models.Money) {
+	warning := dto.CartItemWarningDTO{
+		Code:    code,
+		Message: message,
+	}
+	if previousPrice != nil {
+		warning.PreviousPrice = &previousPrice.Amount
+		warning.PreviousCurrency = previousPrice.Currency
+	}
+	r.Warnings[item.ID] = append(r.Warnings[item.ID], warning)
+}
+
+// version hashes everything the charged amount depends on: the currency, the items, their prices,
+// the coupon, the tax and the total
+func (r *cartReview) version() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "currency:%s\n", r.Breakdown.Currency)
+	for _, item := range r.Items {
+		fmt.Fprintf(&b, "item:%d:%s:%s:%s:", item.ID, intOrNone(item.GameID), intOrNone(item.BundleID), intOrNone(item.GiftRecipientID))
+		if line, ok := r.lines[item.ID]; ok {
+			fmt.Fprintf(&b, "%dx%d\n", line.Price, line.Quantity)
+		} else {
+			b.WriteString("unavailable\n")
+		}
+	}
+	if r.Coupon != nil && r.CouponErr == nil {
+		fmt.Fprintf(&b, "coupon:%d:%d\n", r.Coupon.ID, r.Breakdown.CouponDiscount)
+	}
+	if rule := r.Breakdown.TaxRule; rule != nil {
+		fmt.Fprintf(&b, "tax:%s:%d:%t:%d\n", rule.Region, rule.Rate, rule.Inclusive, r.Breakdown.Tax)
+	}
+	fmt.Fprintf(&b, "total:%d", r.Breakdown.Total)
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:12])
+}
+
+// intOrNone formats an optional ID
+func intOrNone(id *int) string {
+	if id == nil {
+		return "-"
+	}
+	return fmt.Sprint(*id)
+}
+
+// unavailableMessage explains why a cart item can no longer be bought in the currency
+func unavailableMessage(item *models.CartItem, currency string) string {
+	if item.Bundle != nil && item.Bundle.Active && !item.Bundle.DeletedAt.Valid {
+		return fmt.Sprintf("This bundle is not sold in %s", currency)
+	}
+	if item.Game != nil && !item.Game.DeletedAt.Valid {
+		return fmt.Sprintf("This game is not sold in %s", currency)
+	}
+	if item.BundleID != nil {
+		return "This bundle is no longer sold"
+	}
+	return "This game is no longer sold"
+}
+
+// restrictedIn reports whether the line's game, or a game of its bundle, is restricted in the region
+func restrictedIn(line priceLine, region string) bool {
+	if region == "" {
+		return false
+	}
+
+	games := []*models.Game{line.Game}
+	if line.Bundle != nil {
+		games = line.Bundle.Games
+	}
+	for _, game := range games {
+		for _, restrict := range game.Restricts {
+			if strings.EqualFold(restrict.Region, region) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,109 +1,599 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
 
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/interfaces/dto"
+	"uniStore/Backend/internal/utils"
 )
 
 // CartServiceImpl implements CartService interface
 type CartServiceImpl struct {
-	cartRepo models.CartRepository
-	gameRepo models.GameRepository
+	cartRepo   models.CartRepository
+	gameRepo   models.GameRepository
+	couponRepo models.CouponRepository
+	bundleRepo models.BundleRepository
+	guestRepo  models.GuestCartRepository
+	guestTTL   time.Duration
+	giftRules  giftRules
+	ownership  ownershipRules
+	pricing    pricing
+	reviewer   cartReviewer
+	logger     *slog.Logger
 }
 
 // NewCartService creates a new cart service
-func NewCartService(cartRepo models.CartRepository, gameRepo models.GameRepository) CartService {
+func NewCartService(
+	cartRepo models.CartRepository,
+	gameRepo models.GameRepository,
+	userRepo models.UserRepository,
+	libraryRepo models.LibraryRepository,
+	giftRepo models.GiftRepository,
+	couponRepo models.CouponRepository,
+	bundleRepo models.BundleRepository,
+	orderRepo models.OrderRepository,
+	guestRepo models.GuestCartRepository,
+	guestTTL time.Duration,
+	currencies CurrencyRules,
+	taxes TaxCalculator,
+	logger *slog.Logger,
+) CartService {
+	pricing := pricing{couponRepo: couponRepo, libraryRepo: libraryRepo, userRepo: userRepo, currencies: currencies, taxes: taxes}
 	return &CartServiceImpl{
-		cartRepo: cartRepo,
-		gameRepo: gameRepo,
+		cartRepo:   cartRepo,
+		gameRepo:   gameRepo,
+		couponRepo: couponRepo,
+		bundleRepo: bundleRepo,
+		guestRepo:  guestRepo,
+		guestTTL:   guestTTL,
+		pricing:    pricing,
+		ownership:  ownershipRules{libraryRepo: libraryRepo, orderRepo: orderRepo},
+		giftRules: giftRules{
+			userRepo:    userRepo,
+			libraryRepo: libraryRepo,
+			giftRepo:    giftRepo,
+		},
+		reviewer: cartReviewer{
+			cartRepo:   cartRepo,
+			couponRepo: couponRepo,
+			userRepo:   userRepo,
+			pricing:    pricing,
+		},
+		logger: logger,
 	}
 }
 
-// GetCart retrieves a user's shopping cart
-func (s *CartServiceImpl) GetCart(userID int) (*dto.CartResponseDTO, error) {
-	// Get cart from repository
-	cart, err := s.cartRepo.FindByUserID(userID)
-	if err != nil {
-		return nil, err
-	}
+// GetCart retrieves a user's shopping cart, with warnings for items whose price changed
+// since they were added or that can no longer be bought
+func (s *CartServiceImpl) GetCart(ctx context.Context, userID int) (*dto.CartResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "CartService.GetCart")
+	defer span.End()
 
-	// Get cart items
-	cartItems, err := s.cartRepo.GetCartItems(userID)
+	// Price the cart for the user
+	review, err := s.reviewer.review(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert cart items to DTOs
-	cartItemDTOs := make([]dto.CartItemDTO, len(cartItems))
-	for i, item := range cartItems {
-		if item.Game != nil {
-			cartItemDTO := dto.CartItemDTOFromModel(item, item.Game)
-			cartItemDTOs[i] = *cartItemDTO
+	// Convert cart items to DTOs; items no longer for sale are not charged
+	cartItemDTOs := make([]dto.CartItemDTO, len(review.Items))
+	for i, item := range review.Items {
+		cartItemDTO := dto.CartItemDTOFromModel(item, item.Game)
+		cartItemDTO.Price = 0
+		cartItemDTO.Currency = review.Breakdown.Currency
+		if line, ok := review.line(item.ID); ok {
+			cartItemDTO.Price = line.Price
 		}
+		if amounts, ok := review.amounts(item.ID); ok {
+			cartItemDTO.NetAmount = amounts.Net
+			cartItemDTO.TaxAmount = amounts.Tax
+			cartItemDTO.GrossAmount = amounts.Gross
+		}
+		cartItemDTO.Warnings = review.Warnings[item.ID]
+		cartItemDTOs[i] = *cartItemDTO
 	}
 
 	// Create cart response DTO
-	return dto.CartResponseDTOFromModel(cart, cartItems, cartItemDTOs), nil
+	cartDTO := dto.CartResponseDTOFromModel(review.Cart, review.Items, cartItemDTOs)
+
+	// Apply the cart's coupon
+	cartDTO.Currency = review.Breakdown.Currency
+	cartDTO.Subtotal = review.Breakdown.Subtotal
+	cartDTO.Tax = dto.TaxDTOFromRule(review.Breakdown.TaxRule)
+	cartDTO.NetTotal = review.Breakdown.Net
+	cartDTO.TaxTotal = review.Breakdown.Tax
+	cartDTO.TotalCost = review.Breakdown.Total
+	if review.Coupon != nil {
+		cartDTO.Coupon = &dto.CartCouponDTO{
+			Code:     review.Coupon.Code,
+			Discount: review.Breakdown.CouponDiscount,
+			Currency: review.Breakdown.Currency,
+		}
+		if review.CouponErr != nil {
+			cartDTO.Coupon.Error = review.CouponErr.Error()
+		}
+	}
+	cartDTO.RegionRequired = review.NoRegion
+	cartDTO.Version = review.Version
+
+	return cartDTO, nil
 }
 
-// AddGameToCart adds a game to a user's shopping cart
-func (s *CartServiceImpl) AddGameToCart(userID int, cartItemDTO *dto.CartItemCreateDTO) error {
+// AddGameToCart adds a game to a user's shopping cart. Digital games are a single copy,
+// so games the user owns or has in a pending order are refused.
+func (s *CartServiceImpl) AddGameToCart(ctx context.Context, userID int, cartItemDTO *dto.CartItemCreateDTO) error {
+	ctx, span := tracer.Start(ctx, "CartService.AddGameToCart")
+	defer span.End()
+
 	// Check if the game exists
-	game, err := s.gameRepo.FindByID(cartItemDTO.GameID)
+	game, err := s.gameRepo.FindByID(ctx, cartItemDTO.GameID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("game not found")
+		}
+		return err
+	}
+
+	if err := s.ownership.check(ctx, userID, game.ID); err != nil {
+		return err
+	}
+
+	price, err := s.gamePrice(ctx, userID, game)
 	if err != nil {
-		return fmt.Errorf("game not found: %w", err)
+		return err
 	}
 
-	if game == nil {
-		return errors.New("game not found")
+	return s.cartRepo.AddGameToCart(ctx, userID, game.ID, 1, price)
+}
+
+// gamePrice returns the sale price of the game in the currency the user pays in
+func (s *CartServiceImpl) gamePrice(ctx context.Context, userID int, game *models.Game) (models.Money, error) {
+	currency, err := s.pricing.currencyFor(ctx, userID)
+	if err != nil {
+		return models.Money{}, err
 	}
 
-	// Add game to cart with specified quantity
-	return s.cartRepo.AddGameToCart(userID, cartItemDTO.GameID, cartItemDTO.Quantity)
+	amount, ok := game.SalePriceIn(currency)
+	if !ok {
+		return models.Money{}, errors.New("game is not sold in your currency")
+	}
+	return models.Money{Amount: amount, Currency: currency}, nil
 }
 
 // RemoveGameFromCart removes a game from a user's shopping cart
-func (s *CartServiceImpl) RemoveGameFromCart(userID, gameID int) error {
-	return s.cartRepo.RemoveGameFromCart(userID, gameID)
+func (s *CartServiceImpl) RemoveGameFromCart(ctx context.Context, userID, gameID int) error {
+	ctx, span := tracer.Start(ctx, "CartService.RemoveGameFromCart")
+	defer span.End()
+
+	return s.cartRepo.RemoveGameFromCart(ctx, userID, gameID)
 }
 
 // ClearCart clears a user's shopping cart
-func (s *CartServiceImpl) ClearCart(userID int) error {
-	return s.cartRepo.ClearCart(userID)
+func (s *CartServiceImpl) ClearCart(ctx context.Context, userID int) error {
+	ctx, span := tracer.Start(ctx, "CartService.ClearCart")
+	defer span.End()
+
+	return s.cartRepo.ClearCart(ctx, userID)
 }
 
-// UpdateCartItemQuantity updates the quantity of a game in a user's shopping cart
-func (s *CartServiceImpl) UpdateCartItemQuantity(userID, gameID int, quantityDTO *dto.CartItemUpdateDTO) error {
+// UpdateCartItemQuantity updates the quantity of a game in a user's shopping cart.
+// A user can only buy one copy of a game for themselves; more copies must be gift copies.
+func (s *CartServiceImpl) UpdateCartItemQuantity(ctx context.Context, userID, gameID int, quantityDTO *dto.CartItemUpdateDTO) error {
+	ctx, span := tracer.Start(ctx, "CartService.UpdateCartItemQuantity")
+	defer span.End()
+
 	if quantityDTO.Quantity <= 0 {
 		return errors.New("quantity must be greater than 0")
 	}
+	if quantityDTO.Quantity > 1 {
+		return errors.New("a game can only be bought once for yourself, add gift copies to buy it for others")
+	}
+
+	return s.AddGameToCart(ctx, userID, &dto.CartItemCreateDTO{GameID: gameID, Quantity: 1})
+}
+
+// CalculateCartTotal calculates the total cost of a user's shopping cart, including its coupon discount and tax
+func (s *CartServiceImpl) CalculateCartTotal(ctx context.Context, userID int) (int64, error) {
+	ctx, span := tracer.Start(ctx, "CartService.CalculateCartTotal")
+	defer span.End()
+
+	review, err := s.reviewer.review(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return review.Breakdown.Total, nil
+}
+
+// ApplyCoupon applies a coupon code to the user's cart
+func (s *CartServiceImpl) ApplyCoupon(ctx context.Context, userID int, couponDTO *dto.CartCouponApplyDTO) (*dto.CartResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "CartService.ApplyCoupon")
+	defer span.End()
+
+	coupon, err := s.couponRepo.FindByCode(ctx, utils.NormalizeCode(couponDTO.Code))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("coupon not found")
+		}
+		return nil, err
+	}
+
+	cartItems, err := s.cartRepo.GetCartItems(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("cart is empty")
+		}
+		return nil, err
+	}
+	if len(cartItems) == 0 {
+		return nil, errors.New("cart is empty")
+	}
+
+	currency, err := s.pricing.currencyFor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := s.pricing.cartLines(ctx, userID, currency, cartItems)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.pricing.checkCoupon(ctx, coupon, userID, currency, lines); err != nil {
+		return nil, err
+	}
+
+	if err := s.cartRepo.SetCoupon(ctx, userID, &coupon.ID); err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(ctx, userID)
+}
+
+// RemoveCoupon removes the coupon from the user's cart
+func (s *CartServiceImpl) RemoveCoupon(ctx context.Context, userID int) error {
+	ctx, span := tracer.Start(ctx, "CartService.RemoveCoupon")
+	defer span.End()
+
+	if err := s.cartRepo.SetCoupon(ctx, userID, nil); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("cart not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// SetGift marks the user's own copy of a game in the cart as a gift for another user
+func (s *CartServiceImpl) SetGift(ctx context.Context, userID, gameID int, giftDTO *dto.CartGiftCreateDTO) error {
+	ctx, span := tracer.Start(ctx, "CartService.SetGift")
+	defer span.End()
 
-	// First remove the game from cart
-	if err := s.cartRepo.RemoveGameFromCart(userID, gameID); err != nil {
+	recipient, err := s.checkGiftCopy(ctx, userID, gameID, giftDTO)
+	if err != nil {
+		return err
+	}
+
+	if err := s.cartRepo.SetGift(ctx, userID, gameID, recipient.ID, giftDTO.Message); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("game not in cart")
+		}
 		return err
 	}
 
-	// Then add it back with the new quantity
-	return s.cartRepo.AddGameToCart(userID, gameID, quantityDTO.Quantity)
+	return nil
 }
 
-// CalculateCartTotal calculates the total cost of a user's shopping cart
-func (s *CartServiceImpl) CalculateCartTotal(userID int) (float64, error) {
-	// Get cart items
-	cartItems, err := s.cartRepo.GetCartItems(userID)
+// AddGiftCopy adds another copy of a game to the cart as a gift for another user
+func (s *CartServiceImpl) AddGiftCopy(ctx context.Context, userID, gameID int, giftDTO *dto.CartGiftCreateDTO) error {
+	ctx, span := tracer.Start(ctx, "CartService.AddGiftCopy")
+	defer span.End()
+
+	game, err := s.gameRepo.FindByID(ctx, gameID)
 	if err != nil {
-		return 0, err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("game not found")
+		}
+		return err
+	}
+
+	recipient, err := s.checkGiftCopy(ctx, userID, gameID, giftDTO)
+	if err != nil {
+		return err
+	}
+
+	price, err := s.gamePrice(ctx, userID, game)
+	if err != nil {
+		return err
+	}
+
+	return s.cartRepo.AddGiftCopy(ctx, userID, gameID, recipient.ID, giftDTO.Message, price)
+}
+
+// RemoveGift turns a gift copy in the user's cart back into a purchase for the user.
+// The recipient may be omitted when the cart holds a single gift copy of the game.
+func (s *CartServiceImpl) RemoveGift(ctx context.Context, userID, gameID int, recipientID *int) error {
+	ctx, span := tracer.Start(ctx, "CartService.RemoveGift")
+	defer span.End()
+
+	cartItems, err := s.cartRepo.GetCartItems(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("game not in cart")
+		}
+		return err
 	}
 
-	var total float64
+	// Find the gift copy and whether the user's own copy is in the cart
+	var gift *models.CartItem
+	giftCopies, ownCopy := 0, false
 	for _, item := range cartItems {
-		if item.Game != nil {
-			total += item.Game.Price * float64(item.Quantity)
+		if item.GameID == nil || *item.GameID != gameID {
+			continue
+		}
+		if item.GiftRecipientID == nil {
+			ownCopy = true
+			continue
 		}
+		giftCopies++
+		if recipientID == nil || *item.GiftRecipientID == *recipientID {
+			gift = item
+		}
+	}
+	if gift == nil {
+		return errors.New("game not in cart")
+	}
+	if recipientID == nil && giftCopies > 1 {
+		return errors.New("several gift copies of this game are in the cart, choose one by recipient")
+	}
+
+	// The copy becomes a purchase for the user unless their own copy is already in the cart
+	if !ownCopy {
+		if err := s.ownership.check(ctx, userID, gameID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.cartRepo.RemoveGift(ctx, userID, gameID, *gift.GiftRecipientID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("game not in cart")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// checkGiftCopy finds the gift recipient and checks the game can be gifted to them
+// and is not already in the cart for them
+func (s *CartServiceImpl) checkGiftCopy(ctx context.Context, userID, gameID int, giftDTO *dto.CartGiftCreateDTO) (*models.User, error) {
+	recipient, err := s.giftRules.findRecipient(ctx, giftDTO.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.giftRules.check(ctx, userID, recipient.ID, gameID); err != nil {
+		return nil, err
+	}
+
+	cartItems, err := s.cartRepo.GetCartItems(ctx, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	for _, item := range cartItems {
+		if item.GameID != nil && *item.GameID == gameID &&
+			item.GiftRecipientID != nil && *item.GiftRecipientID == recipient.ID {
+			return nil, errors.New("game is already in the cart as a gift for this recipient")
+		}
+	}
+
+	return recipient, nil
+}
+
+// AddBundleToCart adds a bundle to the user's cart
+func (s *CartServiceImpl) AddBundleToCart(ctx context.Context, userID, bundleID int) error {
+	ctx, span := tracer.Start(ctx, "CartService.AddBundleToCart")
+	defer span.End()
+
+	bundle, err := s.bundleRepo.FindByID(ctx, bundleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("bundle not found")
+		}
+		return err
+	}
+	if !bundle.Active {
+		return errors.New("bundle is not available")
+	}
+
+	currency, err := s.pricing.currencyFor(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Buyers owning part of the bundle pay for the rest, but not for nothing
+	price, owned, ok, err := s.pricing.bundlePrice(ctx, userID, bundle, currency)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("bundle is not sold in your currency")
+	}
+	if owned == len(bundle.Games) {
+		return errors.New("you already own every game in this bundle")
+	}
+
+	return s.cartRepo.AddBundleToCart(ctx, userID, bundleID, models.Money{Amount: price, Currency: currency})
+}
+
+// RemoveBundleFromCart removes a bundle from the user's cart
+func (s *CartServiceImpl) RemoveBundleFromCart(ctx context.Context, userID, bundleID int) error {
+	ctx, span := tracer.Start(ctx, "CartService.RemoveBundleFromCart")
+	defer span.End()
+
+	if err := s.cartRepo.RemoveBundleFromCart(ctx, userID, bundleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("bundle not in cart")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetGuestCart retrieves a guest cart by its token; an unknown token gives an empty cart
+func (s *CartServiceImpl) GetGuestCart(ctx context.Context, token string) (*dto.GuestCartDTO, error) {
+	ctx, span := tracer.Start(ctx, "CartService.GetGuestCart")
+	defer span.End()
+
+	cart, err := s.findGuestCart(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		return &dto.GuestCartDTO{Items: []dto.CartItemDTO{}, Currency: s.pricing.currencies.Default}, nil
+	}
+
+	// Guests have no region yet, so they see the store currency
+	return dto.GuestCartDTOFromModel(cart, s.pricing.currencies.Default), nil
+}
+
+// AddGameToGuestCart adds a game to a guest cart, starting a new cart when the token is unknown
+func (s *CartServiceImpl) AddGameToGuestCart(ctx context.Context, token string, gameID int) (*dto.GuestCartDTO, error) {
+	ctx, span := tracer.Start(ctx, "CartService.AddGameToGuestCart")
+	defer span.End()
+
+	if _, err := s.gameRepo.FindByID(ctx, gameID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("game not found")
+		}
+		return nil, err
+	}
+
+	cart, err := s.findGuestCart(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		if token, err = utils.GenerateRandomToken(32); err != nil {
+			return nil, err
+		}
+		cart = &models.GuestCart{Token: token}
+		if err := s.guestRepo.Create(ctx, cart); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.guestRepo.AddGame(ctx, cart.ID, gameID); err != nil {
+		return nil, err
+	}
+
+	return s.GetGuestCart(ctx, cart.Token)
+}
+
+// RemoveGameFromGuestCart removes a game from a guest cart
+func (s *CartServiceImpl) RemoveGameFromGuestCart(ctx context.Context, token string, gameID int) (*dto.GuestCartDTO, error) {
+	ctx, span := tracer.Start(ctx, "CartService.RemoveGameFromGuestCart")
+	defer span.End()
+
+	cart, err := s.findGuestCart(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		return nil, errors.New("cart not found")
+	}
+
+	if err := s.guestRepo.RemoveGame(ctx, cart.ID, gameID); err != nil {
+		return nil, err
+	}
+
+	return s.GetGuestCart(ctx, cart.Token)
+}
+
+// ClearGuestCart removes every game from a guest cart
+func (s *CartServiceImpl) ClearGuestCart(ctx context.Context, token string) error {
+	ctx, span := tracer.Start(ctx, "CartService.ClearGuestCart")
+	defer span.End()
+
+	cart, err := s.findGuestCart(ctx, token)
+	if err != nil {
+		return err
+	}
+	if cart == nil {
+		return errors.New("cart not found")
+	}
+
+	return s.guestRepo.Clear(ctx, cart.ID)
+}
+
+// MergeGuestCart moves the games of a guest cart into the user's cart and deletes the
+// guest cart. Games no longer sold, already in the cart, owned or in a pending order are left out.
+func (s *CartServiceImpl) MergeGuestCart(ctx context.Context, token string, userID int) error {
+	ctx, span := tracer.Start(ctx, "CartService.MergeGuestCart")
+	defer span.End()
+
+	cart, err := s.findGuestCart(ctx, token)
+	if err != nil || cart == nil {
+		return err
+	}
+
+	currency, err := s.pricing.currencyFor(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	games := make([]*models.Game, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		if item.Game == nil {
+			continue
+		}
+		if err := s.ownership.check(ctx, userID, item.GameID); err != nil {
+			if err.Error() == "you already own this game" || err.Error() == "game is already in a pending order" {
+				continue
+			}
+			return err
+		}
+		games = append(games, item.Game)
+	}
+
+	return s.guestRepo.MergeIntoUserCart(ctx, cart.ID, userID, games, currency)
+}
+
+// DeleteStaleGuestCarts deletes guest carts nobody has touched for longer than the guest cart TTL
+func (s *CartServiceImpl) DeleteStaleGuestCarts(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "CartService.DeleteStaleGuestCarts")
+	defer span.End()
+
+	deleted, err := s.guestRepo.DeleteInactiveSince(ctx, time.Now().Add(-s.guestTTL))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		s.logger.InfoContext(ctx, "Deleted stale guest carts", "count", deleted)
+	}
+	return nil
+}
+
+// findGuestCart finds a guest cart by token, returning nil when there is none
+func (s *CartServiceImpl) findGuestCart(ctx context.Context, token string) (*models.GuestCart, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	cart, err := s.guestRepo.FindByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
 	}
 
-	return total, nil
+	return cart, nil
 }
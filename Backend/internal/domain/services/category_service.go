@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"time"
 
 	"uniStore/Backend/internal/domain/models"
@@ -20,7 +21,10 @@ func NewCategoryService(categoryRepo models.CategoryRepository) CategoryService
 }
 
 // CreateCategory creates a new category
-func (s *CategoryServiceImpl) CreateCategory(categoryDTO *dto.CategoryCreateDTO) (*dto.CategoryDTO, error) {
+func (s *CategoryServiceImpl) CreateCategory(ctx context.Context, categoryDTO *dto.CategoryCreateDTO) (*dto.CategoryDTO, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.CreateCategory")
+	defer span.End()
+
 	// Convert DTO to model
 	category := categoryDTO.ToModel()
 
@@ -29,7 +33,7 @@ func (s *CategoryServiceImpl) CreateCategory(categoryDTO *dto.CategoryCreateDTO)
 	category.UpdatedAt = time.Now()
 
 	// Create category in repository
-	if err := s.categoryRepo.Create(category); err != nil {
+	if err := s.categoryRepo.Create(ctx, category); err != nil {
 		return nil, err
 	}
 
@@ -38,9 +42,12 @@ func (s *CategoryServiceImpl) CreateCategory(categoryDTO *dto.CategoryCreateDTO)
 }
 
 // GetCategoryByID gets a category by ID
-func (s *CategoryServiceImpl) GetCategoryByID(id int) (*dto.CategoryDTO, error) {
+func (s *CategoryServiceImpl) GetCategoryByID(ctx context.Context, id int) (*dto.CategoryDTO, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.GetCategoryByID")
+	defer span.End()
+
 	// Get category from repository
-	category, err := s.categoryRepo.FindByID(id)
+	category, err := s.categoryRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -50,9 +57,12 @@ func (s *CategoryServiceImpl) GetCategoryByID(id int) (*dto.CategoryDTO, error)
 }
 
 // GetAllCategories gets all categories
-func (s *CategoryServiceImpl) GetAllCategories(limit, offset int) ([]*dto.CategoryDTO, error) {
+func (s *CategoryServiceImpl) GetAllCategories(ctx context.Context, limit, offset int) ([]*dto.CategoryDTO, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.GetAllCategories")
+	defer span.End()
+
 	// Get categories from repository
-	categories, err := s.categoryRepo.FindAll(limit, offset)
+	categories, err := s.categoryRepo.FindAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -62,9 +72,12 @@ func (s *CategoryServiceImpl) GetAllCategories(limit, offset int) ([]*dto.Catego
 }
 
 // UpdateCategory updates a category
-func (s *CategoryServiceImpl) UpdateCategory(id int, categoryDTO *dto.CategoryUpdateDTO) (*dto.CategoryDTO, error) {
+func (s *CategoryServiceImpl) UpdateCategory(ctx context.Context, id int, categoryDTO *dto.CategoryUpdateDTO) (*dto.CategoryDTO, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.UpdateCategory")
+	defer span.End()
+
 	// Get existing category
-	existingCategory, err := s.categoryRepo.FindByID(id)
+	existingCategory, err := s.categoryRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +97,7 @@ func (s *CategoryServiceImpl) UpdateCategory(id int, categoryDTO *dto.CategoryUp
 	existingCategory.UpdatedAt = time.Now()
 
 	// Update category in repository
-	if err := s.categoryRepo.Update(existingCategory); err != nil {
+	if err := s.categoryRepo.Update(ctx, existingCategory); err != nil {
 		return nil, err
 	}
 
@@ -93,6 +106,9 @@ func (s *CategoryServiceImpl) UpdateCategory(id int, categoryDTO *dto.CategoryUp
 }
 
 // DeleteCategory deletes a category
-func (s *CategoryServiceImpl) DeleteCategory(id int) error {
-	return s.categoryRepo.Delete(id)
+func (s *CategoryServiceImpl) DeleteCategory(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "CategoryService.DeleteCategory")
+	defer span.End()
+
+	return s.categoryRepo.Delete(ctx, id)
 }
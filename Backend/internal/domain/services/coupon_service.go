@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/interfaces/dto"
+	"uniStore/Backend/internal/utils"
+)
+
+// CouponServiceImpl implements CouponService interface
+type CouponServiceImpl struct {
+	couponRepo    models.CouponRepository
+	categoryRepo  models.CategoryRepository
+	developerRepo models.DeveloperRepository
+	currencies    CurrencyRules
+}
+
+// NewCouponService creates a new coupon service
+func NewCouponService(
+	couponRepo models.CouponRepository,
+	categoryRepo models.CategoryRepository,
+	developerRepo models.DeveloperRepository,
+	currencies CurrencyRules,
+) CouponService {
+	return &CouponServiceImpl{
+		couponRepo:    couponRepo,
+		categoryRepo:  categoryRepo,
+		developerRepo: developerRepo,
+		currencies:    currencies,
+	}
+}
+
+// CreateCoupon creates a coupon, optionally restricted to categories or developers
+func (s *CouponServiceImpl) CreateCoupon(ctx context.Context, couponDTO *dto.CouponCreateDTO) (*dto.CouponDTO, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.CreateCoupon")
+	defer span.End()
+
+	if couponDTO.Type == models.CouponTypePercent && couponDTO.Value > 100 {
+		return nil, errors.New("percent coupons cannot exceed 100")
+	}
+	if couponDTO.Type == models.CouponTypePercent && couponDTO.Value != math.Trunc(couponDTO.Value) {
+		return nil, errors.New("percent coupons must be a whole percentage")
+	}
+	if couponDTO.StartsAt != nil && couponDTO.EndsAt != nil && !couponDTO.EndsAt.After(*couponDTO.StartsAt) {
+		return nil, errors.New("coupon must end after it starts")
+	}
+	currency, err := s.currencies.resolve(couponDTO.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	code := utils.NormalizeCode(couponDTO.Code)
+	if code == "" {
+		return nil, errors.New("coupon code is required")
+	}
+	if _, err := s.couponRepo.FindByCode(ctx, code); err == nil {
+		return nil, errors.New("coupon code already exists")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Percentages are stored as they are, fixed amounts in minor units
+	value := int64(couponDTO.Value)
+	if couponDTO.Type == models.CouponTypeFixed {
+		value = models.MinorUnits(couponDTO.Value, currency)
+	}
+
+	coupon := &models.Coupon{
+		Code:           code,
+		Type:           couponDTO.Type,
+		Value:          value,
+		MinTotal:       models.MinorUnits(couponDTO.MinTotal, currency),
+		Currency:       currency,
+		MaxUses:        couponDTO.MaxUses,
+		MaxUsesPerUser: 1,
+		StartsAt:       couponDTO.StartsAt,
+		EndsAt:         couponDTO.EndsAt,
+		Active:         true,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if couponDTO.MaxUsesPerUser != nil {
+		coupon.MaxUsesPerUser = *couponDTO.MaxUsesPerUser
+	}
+
+	// Resolve the restrictions
+	for _, categoryID := range couponDTO.CategoryIDs {
+		category, err := s.categoryRepo.FindByID(ctx, categoryID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("category not found")
+			}
+			return nil, err
+		}
+		coupon.Categories = append(coupon.Categories, category)
+	}
+	for _, developerID := range couponDTO.DeveloperIDs {
+		developer, err := s.developerRepo.FindByID(ctx, developerID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("developer not found")
+			}
+			return nil, err
+		}
+		coupon.Developers = append(coupon.Developers, developer)
+	}
+
+	if err := s.couponRepo.Create(ctx, coupon); err != nil {
+		return nil, err
+	}
+
+	return dto.CouponDTOFromModel(coupon), nil
+}
+
+// GetCoupons gets coupons, newest first
+func (s *CouponServiceImpl) GetCoupons(ctx context.Context, limit, offset int) ([]*dto.CouponDTO, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.GetCoupons")
+	defer span.End()
+
+	coupons, err := s.couponRepo.FindAll(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.CouponDTOsFromModels(coupons), nil
+}
+
+// DeactivateCoupon stops a coupon from being applied; past orders keep their discount
+func (s *CouponServiceImpl) DeactivateCoupon(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "CouponService.DeactivateCoupon")
+	defer span.End()
+
+	coupon, err := s.couponRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("coupon not found")
+		}
+		return err
+	}
+
+	coupon.Active = false
+	coupon.UpdatedAt = time.Now()
+	return s.couponRepo.Update(ctx, coupon)
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// CurrencyRules configures which currency users pay in
+type CurrencyRules struct {
+	// Default is the store currency, used for new prices and for users whose region has no currency
+	Default string
+	// Regions maps a region code to the currency its users pay in
+	Regions map[string]string
+}
+
+// forUser returns the currency the user pays in: their own choice, else their region's, else the store's
+func (r CurrencyRules) forUser(user *models.User) string {
+	if user != nil {
+		if user.Currency != "" {
+			return user.Currency
+		}
+		if currency, ok := r.Regions[strings.ToUpper(user.Region)]; ok {
+			return currency
+		}
+	}
+	return r.Default
+}
+
+// resolve returns a normalized supported currency, defaulting to the store currency when empty
+func (r CurrencyRules) resolve(code string) (string, error) {
+	if code = models.NormalizeCurrency(code); code == "" {
+		return r.Default, nil
+	}
+	if !models.IsCurrency(code) {
+		return "", errors.New("unsupported currency")
+	}
+	return code, nil
+}
@@ -1,8 +1,12 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"time"
 
+	"gorm.io/gorm"
+
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/interfaces/dto"
 )
@@ -10,17 +14,22 @@ import (
 // DeveloperServiceImpl implements DeveloperService interface
 type DeveloperServiceImpl struct {
 	developerRepo models.DeveloperRepository
+	userRepo      models.UserRepository
 }
 
 // NewDeveloperService creates a new developer service
-func NewDeveloperService(developerRepo models.DeveloperRepository) DeveloperService {
+func NewDeveloperService(developerRepo models.DeveloperRepository, userRepo models.UserRepository) DeveloperService {
 	return &DeveloperServiceImpl{
 		developerRepo: developerRepo,
+		userRepo:      userRepo,
 	}
 }
 
 // CreateDeveloper creates a new developer
-func (s *DeveloperServiceImpl) CreateDeveloper(developerDTO *dto.DeveloperCreateDTO) (*dto.DeveloperDTO, error) {
+func (s *DeveloperServiceImpl) CreateDeveloper(ctx context.Context, developerDTO *dto.DeveloperCreateDTO) (*dto.DeveloperDTO, error) {
+	ctx, span := tracer.Start(ctx, "DeveloperService.CreateDeveloper")
+	defer span.End()
+
 	// Convert DTO to model
 	developer := developerDTO.ToModel()
 
@@ -29,7 +38,7 @@ func (s *DeveloperServiceImpl) CreateDeveloper(developerDTO *dto.DeveloperCreate
 	developer.UpdatedAt = time.Now()
 
 	// Create developer in repository
-	if err := s.developerRepo.Create(developer); err != nil {
+	if err := s.developerRepo.Create(ctx, developer); err != nil {
 		return nil, err
 	}
 
@@ -38,9 +47,12 @@ func (s *DeveloperServiceImpl) CreateDeveloper(developerDTO *dto.DeveloperCreate
 }
 
 // GetDeveloperByID gets a developer by ID
-func (s *DeveloperServiceImpl) GetDeveloperByID(id int) (*dto.DeveloperDTO, error) {
+func (s *DeveloperServiceImpl) GetDeveloperByID(ctx context.Context, id int) (*dto.DeveloperDTO, error) {
+	ctx, span := tracer.Start(ctx, "DeveloperService.GetDeveloperByID")
+	defer span.End()
+
 	// Get developer from repository
-	developer, err := s.developerRepo.FindByID(id)
+	developer, err := s.developerRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -50,9 +62,12 @@ func (s *DeveloperServiceImpl) GetDeveloperByID(id int) (*dto.DeveloperDTO, erro
 }
 
 // GetAllDevelopers gets all developers
-func (s *DeveloperServiceImpl) GetAllDevelopers(limit, offset int) ([]*dto.DeveloperDTO, error) {
+func (s *DeveloperServiceImpl) GetAllDevelopers(ctx context.Context, limit, offset int) ([]*dto.DeveloperDTO, error) {
+	ctx, span := tracer.Start(ctx, "DeveloperService.GetAllDevelopers")
+	defer span.End()
+
 	// Get developers from repository
-	developers, err := s.developerRepo.FindAll(limit, offset)
+	developers, err := s.developerRepo.FindAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -62,9 +77,12 @@ func (s *DeveloperServiceImpl) GetAllDevelopers(limit, offset int) ([]*dto.Devel
 }
 
 // UpdateDeveloper updates a developer
-func (s *DeveloperServiceImpl) UpdateDeveloper(id int, developerDTO *dto.DeveloperUpdateDTO) (*dto.DeveloperDTO, error) {
+func (s *DeveloperServiceImpl) UpdateDeveloper(ctx context.Context, id int, developerDTO *dto.DeveloperUpdateDTO) (*dto.DeveloperDTO, error) {
+	ctx, span := tracer.Start(ctx, "DeveloperService.UpdateDeveloper")
+	defer span.End()
+
 	// Get existing developer
-	existingDeveloper, err := s.developerRepo.FindByID(id)
+	existingDeveloper, err := s.developerRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +108,7 @@ func (s *DeveloperServiceImpl) UpdateDeveloper(id int, developerDTO *dto.Develop
 	existingDeveloper.UpdatedAt = time.Now()
 
 	// Update developer in repository
-	if err := s.developerRepo.Update(existingDeveloper); err != nil {
+	if err := s.developerRepo.Update(ctx, existingDeveloper); err != nil {
 		return nil, err
 	}
 
@@ -99,6 +117,59 @@ func (s *DeveloperServiceImpl) UpdateDeveloper(id int, developerDTO *dto.Develop
 }
 
 // DeleteDeveloper deletes a developer
-func (s *DeveloperServiceImpl) DeleteDeveloper(id int) error {
-	return s.developerRepo.Delete(id)
+func (s *DeveloperServiceImpl) DeleteDeveloper(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "DeveloperService.DeleteDeveloper")
+	defer span.End()
+
+	return s.developerRepo.Delete(ctx, id)
+}
+
+// AddDeveloperMember links a user account to a developer
+func (s *DeveloperServiceImpl) AddDeveloperMember(ctx context.Context, developerID, userID int) error {
+	ctx, span := tracer.Start(ctx, "DeveloperService.AddDeveloperMember")
+	defer span.End()
+
+	if _, err := s.developerRepo.FindByID(ctx, developerID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("developer not found")
+		}
+		return err
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	return s.developerRepo.AddMember(ctx, developerID, userID)
+}
+
+// RemoveDeveloperMember unlinks a user account from a developer
+func (s *DeveloperServiceImpl) RemoveDeveloperMember(ctx context.Context, developerID, userID int) error {
+	ctx, span := tracer.Start(ctx, "DeveloperService.RemoveDeveloperMember")
+	defer span.End()
+
+	return s.developerRepo.RemoveMember(ctx, developerID, userID)
+}
+
+// GetDeveloperMembers gets the user accounts linked to a developer
+func (s *DeveloperServiceImpl) GetDeveloperMembers(ctx context.Context, developerID int) ([]*dto.DeveloperMemberDTO, error) {
+	ctx, span := tracer.Start(ctx, "DeveloperService.GetDeveloperMembers")
+	defer span.End()
+
+	if _, err := s.developerRepo.FindByID(ctx, developerID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("developer not found")
+		}
+		return nil, err
+	}
+
+	members, err := s.developerRepo.FindMembers(ctx, developerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.DeveloperMemberDTOsFromModels(members), nil
 }
@@ -1,8 +1,10 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/interfaces/dto"
@@ -12,26 +14,33 @@ import (
 type FavoriteServiceImpl struct {
 	favoriteRepo models.FavoriteRepository
 	gameRepo     models.GameRepository
+	userRepo     models.UserRepository
+	currencies   CurrencyRules
 }
 
 // NewFavoriteService creates a new favorite service
-func NewFavoriteService(favoriteRepo models.FavoriteRepository, gameRepo models.GameRepository) FavoriteService {
+func NewFavoriteService(favoriteRepo models.FavoriteRepository, gameRepo models.GameRepository, userRepo models.UserRepository, currencies CurrencyRules) FavoriteService {
 	return &FavoriteServiceImpl{
 		favoriteRepo: favoriteRepo,
 		gameRepo:     gameRepo,
+		userRepo:     userRepo,
+		currencies:   currencies,
 	}
 }
 
 // GetFavorite gets a user's favorite games
-func (s *FavoriteServiceImpl) GetFavorite(userID int) (*dto.FavoriteResponseDTO, error) {
+func (s *FavoriteServiceImpl) GetFavorite(ctx context.Context, userID int) (*dto.FavoriteResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "FavoriteService.GetFavorite")
+	defer span.End()
+
 	// Get favorite from repository
-	favorite, err := s.favoriteRepo.FindByUserID(userID)
+	favorite, err := s.favoriteRepo.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get favorite items
-	favoriteItems, err := s.favoriteRepo.GetFavoriteItems(userID)
+	favoriteItems, err := s.favoriteRepo.GetFavoriteItems(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -50,9 +59,12 @@ func (s *FavoriteServiceImpl) GetFavorite(userID int) (*dto.FavoriteResponseDTO,
 }
 
 // AddGameToFavorite adds a game to a user's favorites
-func (s *FavoriteServiceImpl) AddGameToFavorite(userID, gameID int) error {
+func (s *FavoriteServiceImpl) AddGameToFavorite(ctx context.Context, userID, gameID int) error {
+	ctx, span := tracer.Start(ctx, "FavoriteService.AddGameToFavorite")
+	defer span.End()
+
 	// Check if the game exists
-	game, err := s.gameRepo.FindByID(gameID)
+	game, err := s.gameRepo.FindByID(ctx, gameID)
 	if err != nil {
 		return fmt.Errorf("game not found: %w", err)
 	}
@@ -61,16 +73,33 @@ func (s *FavoriteServiceImpl) AddGameToFavorite(userID, gameID int) error {
 		return errors.New("game not found")
 	}
 
-	// Add game to favorites
-	return s.favoriteRepo.AddGameToFavorite(userID, gameID)
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Add game to favorites; wishlist notifications start from its current price in the user's
+	// currency and its release state
+	var price *models.Money
+	currency := s.currencies.forUser(user)
+	if amount, ok := game.SalePriceIn(currency); ok {
+		price = &models.Money{Amount: amount, Currency: currency}
+	}
+	return s.favoriteRepo.AddGameToFavorite(ctx, userID, gameID, price, released(game, time.Now()))
 }
 
 // RemoveGameFromFavorite removes a game from a user's favorites
-func (s *FavoriteServiceImpl) RemoveGameFromFavorite(userID, gameID int) error {
-	return s.favoriteRepo.RemoveGameFromFavorite(userID, gameID)
+func (s *FavoriteServiceImpl) RemoveGameFromFavorite(ctx context.Context, userID, gameID int) error {
+	ctx, span := tracer.Start(ctx, "FavoriteService.RemoveGameFromFavorite")
+	defer span.End()
+
+	return s.favoriteRepo.RemoveGameFromFavorite(ctx, userID, gameID)
 }
 
 // ClearFavorite clears a user's favorites
-func (s *FavoriteServiceImpl) ClearFavorite(userID int) error {
-	return s.favoriteRepo.ClearFavorite(userID)
+func (s *FavoriteServiceImpl) ClearFavorite(ctx context.Context, userID int) error {
+	ctx, span := tracer.Start(ctx, "FavoriteService.ClearFavorite")
+	defer span.End()
+
+	return s.favoriteRepo.ClearFavorite(ctx, userID)
 }
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"time"
@@ -15,30 +16,46 @@ type GameServiceImpl struct {
 	categoryRepo  models.CategoryRepository
 	developerRepo models.DeveloperRepository
 	restrictRepo  models.RestrictRepository
+	currencies    CurrencyRules
 }
 
 // NewGameService creates a new game service
-func NewGameService(gameRepo models.GameRepository, categoryRepo models.CategoryRepository, developerRepo models.DeveloperRepository, restrictRepo models.RestrictRepository) GameService {
+func NewGameService(gameRepo models.GameRepository, categoryRepo models.CategoryRepository, developerRepo models.DeveloperRepository, restrictRepo models.RestrictRepository, currencies CurrencyRules) GameService {
 	return &GameServiceImpl{
 		gameRepo:      gameRepo,
 		categoryRepo:  categoryRepo,
 		developerRepo: developerRepo,
 		restrictRepo:  restrictRepo,
+		currencies:    currencies,
 	}
 }
 
 // CreateGame creates a new game
-func (s *GameServiceImpl) CreateGame(gameDTO *dto.GameCreateDTO) (*dto.GameDTO, error) {
+func (s *GameServiceImpl) CreateGame(ctx context.Context, gameDTO *dto.GameCreateDTO) (*dto.GameDTO, error) {
+	ctx, span := tracer.Start(ctx, "GameService.CreateGame")
+	defer span.End()
+
 	// Convert DTO to model
 	game := gameDTO.ToModel()
 
+	// Resolve the price list
+	currency, err := s.currencies.resolve(game.Currency)
+	if err != nil {
+		return nil, err
+	}
+	game.Currency = currency
+	game.Price = models.MinorUnits(gameDTO.Price, currency)
+	if game.Prices, err = gamePrices(currency, gameDTO.Prices); err != nil {
+		return nil, err
+	}
+
 	// Set timestamps
 	game.CreatedAt = time.Now()
 	game.UpdatedAt = time.Now()
 
 	// Load related models for validation if needed
 	if game.DeveloperID != 0 {
-		developer, err := s.developerRepo.FindByID(game.DeveloperID)
+		developer, err := s.developerRepo.FindByID(ctx, game.DeveloperID)
 		if err != nil {
 			return nil, err
 		}
@@ -46,7 +63,7 @@ func (s *GameServiceImpl) CreateGame(gameDTO *dto.GameCreateDTO) (*dto.GameDTO,
 	}
 
 	if game.CategoryID != 0 {
-		category, err := s.categoryRepo.FindByID(game.CategoryID)
+		category, err := s.categoryRepo.FindByID(ctx, game.CategoryID)
 		if err != nil {
 			return nil, err
 		}
@@ -54,7 +71,7 @@ func (s *GameServiceImpl) CreateGame(gameDTO *dto.GameCreateDTO) (*dto.GameDTO,
 	}
 
 	// Create game in repository
-	if err := s.gameRepo.Create(game); err != nil {
+	if err := s.gameRepo.Create(ctx, game); err != nil {
 		return nil, err
 	}
 
@@ -63,16 +80,19 @@ func (s *GameServiceImpl) CreateGame(gameDTO *dto.GameCreateDTO) (*dto.GameDTO,
 }
 
 // GetGameByID retrieves a game by ID
-func (s *GameServiceImpl) GetGameByID(id int) (*dto.GameDTO, error) {
+func (s *GameServiceImpl) GetGameByID(ctx context.Context, id int) (*dto.GameDTO, error) {
+	ctx, span := tracer.Start(ctx, "GameService.GetGameByID")
+	defer span.End()
+
 	// Get game from repository
-	game, err := s.gameRepo.FindByID(id)
+	game, err := s.gameRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	// Load developer if not loaded
 	if game.Developer == nil && game.DeveloperID != 0 {
-		developer, err := s.developerRepo.FindByID(game.DeveloperID)
+		developer, err := s.developerRepo.FindByID(ctx, game.DeveloperID)
 		if err != nil {
 			return nil, err
 		}
@@ -81,7 +101,7 @@ func (s *GameServiceImpl) GetGameByID(id int) (*dto.GameDTO, error) {
 
 	// Load category if not loaded
 	if game.Category == nil && game.CategoryID != 0 {
-		category, err := s.categoryRepo.FindByID(game.CategoryID)
+		category, err := s.categoryRepo.FindByID(ctx, game.CategoryID)
 		if err != nil {
 			return nil, err
 		}
@@ -89,7 +109,7 @@ func (s *GameServiceImpl) GetGameByID(id int) (*dto.GameDTO, error) {
 	}
 
 	// Load restrictions
-	restrictions, err := s.restrictRepo.FindByGameID(game.ID)
+	restrictions, err := s.restrictRepo.FindByGameID(ctx, game.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -100,9 +120,12 @@ func (s *GameServiceImpl) GetGameByID(id int) (*dto.GameDTO, error) {
 }
 
 // GetAllGames retrieves all games with pagination
-func (s *GameServiceImpl) GetAllGames(limit, offset int) ([]*dto.GameDTO, error) {
+func (s *GameServiceImpl) GetAllGames(ctx context.Context, limit, offset int) ([]*dto.GameDTO, error) {
+	ctx, span := tracer.Start(ctx, "GameService.GetAllGames")
+	defer span.End()
+
 	// Get games from repository
-	games, err := s.gameRepo.FindAll(limit, offset)
+	games, err := s.gameRepo.FindAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +134,7 @@ func (s *GameServiceImpl) GetAllGames(limit, offset int) ([]*dto.GameDTO, error)
 	for _, game := range games {
 		// Load developer if not loaded
 		if game.Developer == nil && game.DeveloperID != 0 {
-			developer, err := s.developerRepo.FindByID(game.DeveloperID)
+			developer, err := s.developerRepo.FindByID(ctx, game.DeveloperID)
 			if err != nil {
 				return nil, err
 			}
@@ -120,7 +143,7 @@ func (s *GameServiceImpl) GetAllGames(limit, offset int) ([]*dto.GameDTO, error)
 
 		// Load category if not loaded
 		if game.Category == nil && game.CategoryID != 0 {
-			category, err := s.categoryRepo.FindByID(game.CategoryID)
+			category, err := s.categoryRepo.FindByID(ctx, game.CategoryID)
 			if err != nil {
 				return nil, err
 			}
@@ -128,7 +151,7 @@ func (s *GameServiceImpl) GetAllGames(limit, offset int) ([]*dto.GameDTO, error)
 		}
 
 		// Load restrictions
-		restrictions, err := s.restrictRepo.FindByGameID(game.ID)
+		restrictions, err := s.restrictRepo.FindByGameID(ctx, game.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -140,9 +163,12 @@ func (s *GameServiceImpl) GetAllGames(limit, offset int) ([]*dto.GameDTO, error)
 }
 
 // UpdateGame updates a game
-func (s *GameServiceImpl) UpdateGame(id int, gameDTO *dto.GameUpdateDTO) (*dto.GameDTO, error) {
+func (s *GameServiceImpl) UpdateGame(ctx context.Context, id int, gameDTO *dto.GameUpdateDTO) (*dto.GameDTO, error) {
+	ctx, span := tracer.Start(ctx, "GameService.UpdateGame")
+	defer span.End()
+
 	// Get existing game
-	existingGame, err := s.gameRepo.FindByID(id)
+	existingGame, err := s.gameRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -157,8 +183,30 @@ func (s *GameServiceImpl) UpdateGame(id int, gameDTO *dto.GameUpdateDTO) (*dto.G
 	if updateData.Description != "" {
 		existingGame.Description = updateData.Description
 	}
-	if updateData.Price > 0 {
-		existingGame.Price = updateData.Price
+	if updateData.Currency != "" {
+		if existingGame.Currency, err = s.currencies.resolve(updateData.Currency); err != nil {
+			return nil, err
+		}
+	}
+	if gameDTO.Price > 0 {
+		existingGame.Price = models.MinorUnits(gameDTO.Price, existingGame.Currency)
+	}
+	if gameDTO.Prices != nil {
+		if existingGame.Prices, err = gamePrices(existingGame.Currency, gameDTO.Prices); err != nil {
+			return nil, err
+		}
+	} else {
+		// A regional price in the new base currency would never be used
+		prices := existingGame.Prices[:0]
+		for _, price := range existingGame.Prices {
+			if price.Currency != existingGame.Currency {
+				prices = append(prices, price)
+			}
+		}
+		existingGame.Prices = prices
+	}
+	if gameDTO.Discount != nil {
+		existingGame.Discount = *gameDTO.Discount
 	}
 	if !updateData.ReleaseDate.IsZero() {
 		existingGame.ReleaseDate = updateData.ReleaseDate
@@ -180,14 +228,14 @@ func (s *GameServiceImpl) UpdateGame(id int, gameDTO *dto.GameUpdateDTO) (*dto.G
 	existingGame.UpdatedAt = time.Now()
 
 	// Update game in repository
-	if err := s.gameRepo.Update(existingGame); err != nil {
+	if err := s.gameRepo.Update(ctx, existingGame); err != nil {
 		return nil, err
 	}
 
 	// Load related data for response
 	// Load developer if not loaded
 	if existingGame.Developer == nil && existingGame.DeveloperID != 0 {
-		developer, err := s.developerRepo.FindByID(existingGame.DeveloperID)
+		developer, err := s.developerRepo.FindByID(ctx, existingGame.DeveloperID)
 		if err != nil {
 			return nil, err
 		}
@@ -196,7 +244,7 @@ func (s *GameServiceImpl) UpdateGame(id int, gameDTO *dto.GameUpdateDTO) (*dto.G
 
 	// Load category if not loaded
 	if existingGame.Category == nil && existingGame.CategoryID != 0 {
-		category, err := s.categoryRepo.FindByID(existingGame.CategoryID)
+		category, err := s.categoryRepo.FindByID(ctx, existingGame.CategoryID)
 		if err != nil {
 			return nil, err
 		}
@@ -204,7 +252,7 @@ func (s *GameServiceImpl) UpdateGame(id int, gameDTO *dto.GameUpdateDTO) (*dto.G
 	}
 
 	// Load restrictions
-	restrictions, err := s.restrictRepo.FindByGameID(existingGame.ID)
+	restrictions, err := s.restrictRepo.FindByGameID(ctx, existingGame.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -214,19 +262,48 @@ func (s *GameServiceImpl) UpdateGame(id int, gameDTO *dto.GameUpdateDTO) (*dto.G
 	return dto.GameDTOFromModel(existingGame), nil
 }
 
+// gamePrices converts a game's regional prices, rejecting unsupported or repeated currencies
+// and prices in the base currency
+func gamePrices(baseCurrency string, priceDTOs []dto.GamePriceSetDTO) ([]*models.GamePrice, error) {
+	prices := make([]*models.GamePrice, 0, len(priceDTOs))
+	seen := make(map[string]bool, len(priceDTOs))
+	for _, priceDTO := range priceDTOs {
+		currency := models.NormalizeCurrency(priceDTO.Currency)
+		if !models.IsCurrency(currency) {
+			return nil, errors.New("unsupported currency")
+		}
+		if currency == baseCurrency {
+			return nil, errors.New("regional price cannot be in the base currency")
+		}
+		if seen[currency] {
+			return nil, errors.New("a game cannot have two prices in the same currency")
+		}
+		seen[currency] = true
+
+		prices = append(prices, &models.GamePrice{Currency: currency, Price: models.MinorUnits(priceDTO.Price, currency)})
+	}
+	return prices, nil
+}
+
 // DeleteGame deletes a game
-func (s *GameServiceImpl) DeleteGame(id int) error {
-	return s.gameRepo.Delete(id)
+func (s *GameServiceImpl) DeleteGame(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "GameService.DeleteGame")
+	defer span.End()
+
+	return s.gameRepo.Delete(ctx, id)
 }
 
 // SearchGamesByTitle searches for games by title
-func (s *GameServiceImpl) SearchGamesByTitle(title string, limit, offset int) ([]*dto.GameDTO, error) {
+func (s *GameServiceImpl) SearchGamesByTitle(ctx context.Context, title string, limit, offset int) ([]*dto.GameDTO, error) {
+	ctx, span := tracer.Start(ctx, "GameService.SearchGamesByTitle")
+	defer span.End()
+
 	if title == "" {
 		return nil, errors.New("search title cannot be empty")
 	}
 
 	// Get all games from repository
-	games, err := s.gameRepo.FindAll(limit, offset)
+	games, err := s.gameRepo.FindAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -245,7 +322,7 @@ func (s *GameServiceImpl) SearchGamesByTitle(title string, limit, offset int) ([
 	for _, game := range result {
 		// Load developer if not loaded
 		if game.Developer == nil && game.DeveloperID != 0 {
-			developer, err := s.developerRepo.FindByID(game.DeveloperID)
+			developer, err := s.developerRepo.FindByID(ctx, game.DeveloperID)
 			if err != nil {
 				return nil, err
 			}
@@ -254,7 +331,7 @@ func (s *GameServiceImpl) SearchGamesByTitle(title string, limit, offset int) ([
 
 		// Load category if not loaded
 		if game.Category == nil && game.CategoryID != 0 {
-			category, err := s.categoryRepo.FindByID(game.CategoryID)
+			category, err := s.categoryRepo.FindByID(ctx, game.CategoryID)
 			if err != nil {
 				return nil, err
 			}
@@ -262,7 +339,7 @@ func (s *GameServiceImpl) SearchGamesByTitle(title string, limit, offset int) ([
 		}
 
 		// Load restrictions
-		restrictions, err := s.restrictRepo.FindByGameID(game.ID)
+		restrictions, err := s.restrictRepo.FindByGameID(ctx, game.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -274,9 +351,12 @@ func (s *GameServiceImpl) SearchGamesByTitle(title string, limit, offset int) ([
 }
 
 // GetGamesByCategory retrieves games by category
-func (s *GameServiceImpl) GetGamesByCategory(categoryID int) ([]*dto.GameDTO, error) {
+func (s *GameServiceImpl) GetGamesByCategory(ctx context.Context, categoryID int) ([]*dto.GameDTO, error) {
+	ctx, span := tracer.Start(ctx, "GameService.GetGamesByCategory")
+	defer span.End()
+
 	// Get games from repository
-	games, err := s.gameRepo.FindByCategory(categoryID)
+	games, err := s.gameRepo.FindByCategory(ctx, categoryID)
 	if err != nil {
 		return nil, err
 	}
@@ -285,7 +365,7 @@ func (s *GameServiceImpl) GetGamesByCategory(categoryID int) ([]*dto.GameDTO, er
 	for _, game := range games {
 		// Load developer if not loaded
 		if game.Developer == nil && game.DeveloperID != 0 {
-			developer, err := s.developerRepo.FindByID(game.DeveloperID)
+			developer, err := s.developerRepo.FindByID(ctx, game.DeveloperID)
 			if err != nil {
 				return nil, err
 			}
@@ -294,7 +374,7 @@ func (s *GameServiceImpl) GetGamesByCategory(categoryID int) ([]*dto.GameDTO, er
 
 		// Load category if not loaded
 		if game.Category == nil && game.CategoryID != 0 {
-			category, err := s.categoryRepo.FindByID(game.CategoryID)
+			category, err := s.categoryRepo.FindByID(ctx, game.CategoryID)
 			if err != nil {
 				return nil, err
 			}
@@ -302,7 +382,7 @@ func (s *GameServiceImpl) GetGamesByCategory(categoryID int) ([]*dto.GameDTO, er
 		}
 
 		// Load restrictions
-		restrictions, err := s.restrictRepo.FindByGameID(game.ID)
+		restrictions, err := s.restrictRepo.FindByGameID(ctx, game.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -314,9 +394,12 @@ func (s *GameServiceImpl) GetGamesByCategory(categoryID int) ([]*dto.GameDTO, er
 }
 
 // GetGamesByDeveloper retrieves games by developer
-func (s *GameServiceImpl) GetGamesByDeveloper(developerID int) ([]*dto.GameDTO, error) {
+func (s *GameServiceImpl) GetGamesByDeveloper(ctx context.Context, developerID int) ([]*dto.GameDTO, error) {
+	ctx, span := tracer.Start(ctx, "GameService.GetGamesByDeveloper")
+	defer span.End()
+
 	// Get games from repository
-	games, err := s.gameRepo.FindByDeveloper(developerID)
+	games, err := s.gameRepo.FindByDeveloper(ctx, developerID)
 	if err != nil {
 		return nil, err
 	}
@@ -325,7 +408,7 @@ func (s *GameServiceImpl) GetGamesByDeveloper(developerID int) ([]*dto.GameDTO,
 	for _, game := range games {
 		// Load developer if not loaded
 		if game.Developer == nil && game.DeveloperID != 0 {
-			developer, err := s.developerRepo.FindByID(game.DeveloperID)
+			developer, err := s.developerRepo.FindByID(ctx, game.DeveloperID)
 			if err != nil {
 				return nil, err
 			}
@@ -334,7 +417,7 @@ func (s *GameServiceImpl) GetGamesByDeveloper(developerID int) ([]*dto.GameDTO,
 
 		// Load category if not loaded
 		if game.Category == nil && game.CategoryID != 0 {
-			category, err := s.categoryRepo.FindByID(game.CategoryID)
+			category, err := s.categoryRepo.FindByID(ctx, game.CategoryID)
 			if err != nil {
 				return nil, err
 			}
@@ -342,7 +425,7 @@ func (s *GameServiceImpl) GetGamesByDeveloper(developerID int) ([]*dto.GameDTO,
 		}
 
 		// Load restrictions
-		restrictions, err := s.restrictRepo.FindByGameID(game.ID)
+		restrictions, err := s.restrictRepo.FindByGameID(ctx, game.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -354,14 +437,17 @@ func (s *GameServiceImpl) GetGamesByDeveloper(developerID int) ([]*dto.GameDTO,
 }
 
 // GetTopSellingGames retrieves top selling games
-func (s *GameServiceImpl) GetTopSellingGames(limit int) ([]*dto.GameDTO, error) {
+func (s *GameServiceImpl) GetTopSellingGames(ctx context.Context, limit int) ([]*dto.GameDTO, error) {
+	ctx, span := tracer.Start(ctx, "GameService.GetTopSellingGames")
+	defer span.End()
+
 	// Ограничиваем количество возвращаемых игр
 	if limit <= 0 {
 		limit = 10 // дефолтное ограничение
 	}
 
 	// Получаем все игры
-	games, err := s.gameRepo.FindAll(100, 0) // Получаем достаточное количество игр для выборки
+	games, err := s.gameRepo.FindAll(ctx, 100, 0) // Получаем достаточное количество игр для выборки
 	if err != nil {
 		return nil, err
 	}
@@ -376,7 +462,7 @@ func (s *GameServiceImpl) GetTopSellingGames(limit int) ([]*dto.GameDTO, error)
 	for _, game := range games {
 		// Загружаем разработчика, если не загружен
 		if game.Developer == nil && game.DeveloperID != 0 {
-			developer, err := s.developerRepo.FindByID(game.DeveloperID)
+			developer, err := s.developerRepo.FindByID(ctx, game.DeveloperID)
 			if err != nil {
 				return nil, err
 			}
@@ -385,7 +471,7 @@ func (s *GameServiceImpl) GetTopSellingGames(limit int) ([]*dto.GameDTO, error)
 
 		// Загружаем категорию, если не загружена
 		if game.Category == nil && game.CategoryID != 0 {
-			category, err := s.categoryRepo.FindByID(game.CategoryID)
+			category, err := s.categoryRepo.FindByID(ctx, game.CategoryID)
 			if err != nil {
 				return nil, err
 			}
@@ -393,7 +479,7 @@ func (s *GameServiceImpl) GetTopSellingGames(limit int) ([]*dto.GameDTO, error)
 		}
 
 		// Загружаем ограничения
-		restrictions, err := s.restrictRepo.FindByGameID(game.ID)
+		restrictions, err := s.restrictRepo.FindByGameID(ctx, game.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -405,29 +491,26 @@ func (s *GameServiceImpl) GetTopSellingGames(limit int) ([]*dto.GameDTO, error)
 }
 
 // GetDiscountedGames retrieves games with discounts
-func (s *GameServiceImpl) GetDiscountedGames(limit int) ([]*dto.GameDTO, error) {
+func (s *GameServiceImpl) GetDiscountedGames(ctx context.Context, limit int) ([]*dto.GameDTO, error) {
+	ctx, span := tracer.Start(ctx, "GameService.GetDiscountedGames")
+	defer span.End()
+
 	// Ограничиваем количество возвращаемых игр
 	if limit <= 0 {
 		limit = 10 // дефолтное ограничение
 	}
 
-	// Получаем все игры
-	games, err := s.gameRepo.FindAll(100, 0) // Получаем достаточное количество игр для выборки
+	// Get the games on sale, biggest discount first
+	games, err := s.gameRepo.FindDiscounted(ctx, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	// Имитируем фильтрацию по скидкам (в реальном приложении здесь был бы специальный запрос к репозиторию)
-	// Для демонстрации просто берем первые N игр и считаем их "со скидками"
-	if len(games) > limit {
-		games = games[:limit]
-	}
-
 	// Загрузим связанные данные для всех игр
 	for _, game := range games {
 		// Загружаем разработчика, если не загружен
 		if game.Developer == nil && game.DeveloperID != 0 {
-			developer, err := s.developerRepo.FindByID(game.DeveloperID)
+			developer, err := s.developerRepo.FindByID(ctx, game.DeveloperID)
 			if err != nil {
 				return nil, err
 			}
@@ -436,7 +519,7 @@ func (s *GameServiceImpl) GetDiscountedGames(limit int) ([]*dto.GameDTO, error)
 
 		// Загружаем категорию, если не загружена
 		if game.Category == nil && game.CategoryID != 0 {
-			category, err := s.categoryRepo.FindByID(game.CategoryID)
+			category, err := s.categoryRepo.FindByID(ctx, game.CategoryID)
 			if err != nil {
 				return nil, err
 			}
@@ -444,7 +527,7 @@ func (s *GameServiceImpl) GetDiscountedGames(limit int) ([]*dto.GameDTO, error)
 		}
 
 		// Загружаем ограничения
-		restrictions, err := s.restrictRepo.FindByGameID(game.ID)
+		restrictions, err := s.restrictRepo.FindByGameID(ctx, game.ID)
 		if err != nil {
 			return nil, err
 		}
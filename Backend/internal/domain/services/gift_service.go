@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/interfaces/dto"
+)
+
+// giftRules checks who may receive a game as a gift
+type giftRules struct {
+	userRepo    models.UserRepository
+	libraryRepo models.LibraryRepository
+	giftRepo    models.GiftRepository
+}
+
+// findRecipient looks a gift recipient up by nickname or email
+func (r giftRules) findRecipient(ctx context.Context, nicknameOrEmail string) (*models.User, error) {
+	nicknameOrEmail = strings.TrimSpace(nicknameOrEmail)
+
+	var user *models.User
+	var err error
+	if strings.Contains(nicknameOrEmail, "@") {
+		user, err = r.userRepo.FindByEmail(ctx, nicknameOrEmail)
+	} else {
+		user, err = r.userRepo.FindByNickname(ctx, nicknameOrEmail)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("recipient not found")
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// check reports whether the sender may gift the game to the recipient
+func (r giftRules) check(ctx context.Context, senderID, recipientID, gameID int) error {
+	if senderID == recipientID {
+		return errors.New("you cannot gift a game to yourself")
+	}
+
+	owned, err := r.libraryRepo.HasGame(ctx, recipientID, gameID)
+	if err != nil {
+		return err
+	}
+	if owned {
+		return errors.New("recipient already owns this game")
+	}
+
+	pending, err := r.giftRepo.HasPending(ctx, recipientID, gameID)
+	if err != nil {
+		return err
+	}
+	if pending {
+		return errors.New("recipient already has this game as a pending gift")
+	}
+
+	return nil
+}
+
+// GiftServiceImpl implements GiftService interface
+type GiftServiceImpl struct {
+	giftRepo models.GiftRepository
+}
+
+// NewGiftService creates a new gift service
+func NewGiftService(giftRepo models.GiftRepository) GiftService {
+	return &GiftServiceImpl{
+		giftRepo: giftRepo,
+	}
+}
+
+// GetReceivedGifts gets the gifts sent to a user, newest first
+func (s *GiftServiceImpl) GetReceivedGifts(ctx context.Context, userID int) ([]*dto.GiftDTO, error) {
+	ctx, span := tracer.Start(ctx, "GiftService.GetReceivedGifts")
+	defer span.End()
+
+	gifts, err := s.giftRepo.FindByRecipientID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.GiftDTOsFromModels(gifts), nil
+}
+
+// AcceptGift adds a pending gift to the recipient's library
+func (s *GiftServiceImpl) AcceptGift(ctx context.Context, giftID, userID int) (*dto.GiftDTO, error) {
+	ctx, span := tracer.Start(ctx, "GiftService.AcceptGift")
+	defer span.End()
+
+	gift, err := s.findPendingGift(ctx, giftID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.giftRepo.Accept(ctx, gift); err != nil {
+		return nil, err
+	}
+
+	return dto.GiftDTOFromModel(gift), nil
+}
+
+// DeclineGift turns a pending gift down and refunds its share of the order to the sender
+func (s *GiftServiceImpl) DeclineGift(ctx context.Context, giftID, userID int) (*dto.GiftDTO, error) {
+	ctx, span := tracer.Start(ctx, "GiftService.DeclineGift")
+	defer span.End()
+
+	gift, err := s.findPendingGift(ctx, giftID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only paid orders have anything to refund
+	var refund int64
+	var entries []*models.PointsLedger
+	var credit *models.StoreCreditLedger
+	order := gift.OrderItem.Order
+	if order.Status == models.OrderStatusPaid {
+		var creditShare int64
+		var redeemed, earned int
+		refund, creditShare, redeemed, earned = refundShare(order, []*models.OrderItem{gift.OrderItem})
+		entries = refundEntries(order, redeemed, earned)
+		credit = creditRefund(order, creditShare)
+	}
+
+	if err := s.giftRepo.Decline(ctx, gift, refund, entries, credit); err != nil {
+		return nil, err
+	}
+
+	return dto.GiftDTOFromModel(gift), nil
+}
+
+// findPendingGift gets an unanswered gift addressed to the user
+func (s *GiftServiceImpl) findPendingGift(ctx context.Context, giftID, userID int) (*models.Gift, error) {
+	gift, err := s.giftRepo.FindByID(ctx, giftID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("gift not found")
+		}
+		return nil, err
+	}
+
+	// Other users' gifts are reported as missing
+	if gift.RecipientID != userID {
+		return nil, errors.New("gift not found")
+	}
+	if gift.Status != models.GiftStatusPending {
+		return nil, errors.New("gift has already been answered")
+	}
+
+	return gift, nil
+}
+
+// refundShare returns the part of the amount paid, of the store credit spent and of the points
+// redeemed and earned on an order attributable to the given items, by their discounted and
+// taxed amounts
+func refundShare(order *models.Order, items []*models.OrderItem) (int64, int64, int, int) {
+	var subtotal, part int64
+	for _, item := range order.OrderItems {
+		subtotal += item.GrossAmount
+	}
+	for _, item := range items {
+		part += item.GrossAmount
+	}
+	if subtotal <= 0 {
+		return 0, 0, 0, 0
+	}
+
+	amount := models.MulDiv(order.TotalCost, part, subtotal)
+	credit := models.MulDiv(order.CreditPaid, part, subtotal)
+	redeemed := int(models.MulDiv(int64(order.PointsRedeemed), part, subtotal))
+	earned := int(models.MulDiv(int64(order.PointsEarned), part, subtotal))
+	return amount, credit, redeemed, earned
+}
+
+// refundEntries returns the ledger entries giving back redeemed points and taking back earned points of an order
+func refundEntries(order *models.Order, redeemed, earned int) []*models.PointsLedger {
+	var entries []*models.PointsLedger
+	if earned > 0 {
+		entries = append(entries, &models.PointsLedger{
+			UserID:  order.UserID,
+			Amount:  -earned,
+			Reason:  models.PointsReasonOrderReversed,
+			OrderID: &order.ID,
+		})
+	}
+	if redeemed > 0 {
+		entries = append(entries, &models.PointsLedger{
+			UserID:  order.UserID,
+			Amount:  redeemed,
+			Reason:  models.PointsReasonRedemptionRefunded,
+			OrderID: &order.ID,
+		})
+	}
+	return entries
+}
+
+// creditRefund returns the ledger entry giving back store credit spent on an order, or nil when there is none
+func creditRefund(order *models.Order, amount int64) *models.StoreCreditLedger {
+	if amount <= 0 {
+		return nil
+	}
+	return &models.StoreCreditLedger{
+		UserID:   order.UserID,
+		Currency: order.Currency,
+		Amount:   amount,
+		Reason:   models.CreditReasonOrderRefunded,
+		OrderID:  &order.ID,
+	}
+}
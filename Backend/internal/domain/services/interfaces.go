@@ -1,119 +1,218 @@
 package services
 
 import (
+	"context"
+
+	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/interfaces/dto"
 )
 
 // UserService defines business logic for user operations
 type UserService interface {
-	Register(userDTO *dto.UserSignupDTO) (*dto.UserResponseDTO, error)
-	Login(loginDTO *dto.UserLoginDTO) (*dto.AuthResponseDTO, error)
-	GetUserByID(id int) (*dto.UserResponseDTO, error)
-	GetAllUsers(limit, offset int) ([]*dto.UserResponseDTO, error)
-	UpdateUser(id int, userDTO *dto.UserUpdateDTO) (*dto.UserResponseDTO, error)
-	VerifyPassword(password, hashedPassword string) bool
-	HashPassword(password string) (string, error)
-	GenerateTokens(email, nickname, role string, id int) (string, string, error)
-	RefreshToken(refreshToken string) (*dto.AuthResponseDTO, error)
-	AddPoints(userID int, points int) (*dto.UserResponseDTO, error)
+	Register(ctx context.Context, userDTO *dto.UserSignupDTO) (*dto.UserResponseDTO, error)
+	CreateAdmin(ctx context.Context, userDTO *dto.UserSignupDTO) (*dto.UserResponseDTO, error)
+	Login(ctx context.Context, loginDTO *dto.UserLoginDTO) (*dto.AuthResponseDTO, error)
+	GetUserByID(ctx context.Context, id int) (*dto.UserResponseDTO, error)
+	GetAllUsers(ctx context.Context, limit, offset int) ([]*dto.UserResponseDTO, error)
+	UpdateUser(ctx context.Context, id int, isAdmin bool, userDTO *dto.UserUpdateDTO) (*dto.UserResponseDTO, error)
+	VerifyPassword(ctx context.Context, password, hashedPassword string) bool
+	HashPassword(ctx context.Context, password string) (string, error)
+	GenerateTokens(ctx context.Context, email, nickname, role string, id int) (string, string, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*dto.AuthResponseDTO, error)
+	AddPoints(ctx context.Context, userID int, points int) (*dto.UserResponseDTO, error)
+	GetPointsHistory(ctx context.Context, userID, limit, offset int) ([]*dto.PointsLedgerDTO, error)
+	GetStoreCredit(ctx context.Context, userID, limit, offset int) (*dto.StoreCreditDTO, error)
 }
 
 // RoleService defines business logic for role operations
 type RoleService interface {
-	CreateRole(roleDTO *dto.RoleCreateDTO) (*dto.RoleDTO, error)
-	GetRoleByID(id int) (*dto.RoleDTO, error)
-	GetAllRoles() ([]*dto.RoleDTO, error)
-	UpdateRole(id int, roleDTO *dto.RoleUpdateDTO) (*dto.RoleDTO, error)
-	DeleteRole(id int) error
+	CreateRole(ctx context.Context, roleDTO *dto.RoleCreateDTO) (*dto.RoleDTO, error)
+	GetRoleByID(ctx context.Context, id int) (*dto.RoleDTO, error)
+	GetAllRoles(ctx context.Context) ([]*dto.RoleDTO, error)
+	UpdateRole(ctx context.Context, id int, roleDTO *dto.RoleUpdateDTO) (*dto.RoleDTO, error)
+	DeleteRole(ctx context.Context, id int) error
 }
 
 // GameService defines business logic for game operations
 type GameService interface {
-	CreateGame(gameDTO *dto.GameCreateDTO) (*dto.GameDTO, error)
-	GetGameByID(id int) (*dto.GameDTO, error)
-	GetAllGames(limit, offset int) ([]*dto.GameDTO, error)
-	UpdateGame(id int, gameDTO *dto.GameUpdateDTO) (*dto.GameDTO, error)
-	DeleteGame(id int) error
-	SearchGamesByTitle(title string, limit, offset int) ([]*dto.GameDTO, error)
-	GetGamesByCategory(categoryID int) ([]*dto.GameDTO, error)
-	GetGamesByDeveloper(developerID int) ([]*dto.GameDTO, error)
-	GetTopSellingGames(limit int) ([]*dto.GameDTO, error)
-	GetDiscountedGames(limit int) ([]*dto.GameDTO, error)
+	CreateGame(ctx context.Context, gameDTO *dto.GameCreateDTO) (*dto.GameDTO, error)
+	GetGameByID(ctx context.Context, id int) (*dto.GameDTO, error)
+	GetAllGames(ctx context.Context, limit, offset int) ([]*dto.GameDTO, error)
+	UpdateGame(ctx context.Context, id int, gameDTO *dto.GameUpdateDTO) (*dto.GameDTO, error)
+	DeleteGame(ctx context.Context, id int) error
+	SearchGamesByTitle(ctx context.Context, title string, limit, offset int) ([]*dto.GameDTO, error)
+	GetGamesByCategory(ctx context.Context, categoryID int) ([]*dto.GameDTO, error)
+	GetGamesByDeveloper(ctx context.Context, developerID int) ([]*dto.GameDTO, error)
+	GetTopSellingGames(ctx context.Context, limit int) ([]*dto.GameDTO, error)
+	GetDiscountedGames(ctx context.Context, limit int) ([]*dto.GameDTO, error)
 }
 
 // CategoryService defines business logic for category operations
 type CategoryService interface {
-	CreateCategory(categoryDTO *dto.CategoryCreateDTO) (*dto.CategoryDTO, error)
-	GetCategoryByID(id int) (*dto.CategoryDTO, error)
-	GetAllCategories(limit, offset int) ([]*dto.CategoryDTO, error)
-	UpdateCategory(id int, categoryDTO *dto.CategoryUpdateDTO) (*dto.CategoryDTO, error)
-	DeleteCategory(id int) error
+	CreateCategory(ctx context.Context, categoryDTO *dto.CategoryCreateDTO) (*dto.CategoryDTO, error)
+	GetCategoryByID(ctx context.Context, id int) (*dto.CategoryDTO, error)
+	GetAllCategories(ctx context.Context, limit, offset int) ([]*dto.CategoryDTO, error)
+	UpdateCategory(ctx context.Context, id int, categoryDTO *dto.CategoryUpdateDTO) (*dto.CategoryDTO, error)
+	DeleteCategory(ctx context.Context, id int) error
 }
 
 // DeveloperService defines business logic for developer operations
 type DeveloperService interface {
-	CreateDeveloper(developerDTO *dto.DeveloperCreateDTO) (*dto.DeveloperDTO, error)
-	GetDeveloperByID(id int) (*dto.DeveloperDTO, error)
-	GetAllDevelopers(limit, offset int) ([]*dto.DeveloperDTO, error)
-	UpdateDeveloper(id int, developerDTO *dto.DeveloperUpdateDTO) (*dto.DeveloperDTO, error)
-	DeleteDeveloper(id int) error
+	CreateDeveloper(ctx context.Context, developerDTO *dto.DeveloperCreateDTO) (*dto.DeveloperDTO, error)
+	GetDeveloperByID(ctx context.Context, id int) (*dto.DeveloperDTO, error)
+	GetAllDevelopers(ctx context.Context, limit, offset int) ([]*dto.DeveloperDTO, error)
+	UpdateDeveloper(ctx context.Context, id int, developerDTO *dto.DeveloperUpdateDTO) (*dto.DeveloperDTO, error)
+	DeleteDeveloper(ctx context.Context, id int) error
+	AddDeveloperMember(ctx context.Context, developerID, userID int) error
+	RemoveDeveloperMember(ctx context.Context, developerID, userID int) error
+	GetDeveloperMembers(ctx context.Context, developerID int) ([]*dto.DeveloperMemberDTO, error)
 }
 
 // RestrictService defines business logic for restrict operations
 type RestrictService interface {
-	CreateRestrict(restrictDTO *dto.RestrictCreateDTO) (*dto.RestrictDTO, error)
-	GetRestrictByID(id int) (*dto.RestrictDTO, error)
-	GetAllRestricts(limit, offset int) ([]*dto.RestrictDTO, error)
-	UpdateRestrict(id int, restrictDTO *dto.RestrictUpdateDTO) (*dto.RestrictDTO, error)
-	DeleteRestrict(id int) error
-	GetRestrictsByGameID(gameID int) ([]*dto.RestrictDTO, error)
+	CreateRestrict(ctx context.Context, restrictDTO *dto.RestrictCreateDTO) (*dto.RestrictDTO, error)
+	GetRestrictByID(ctx context.Context, id int) (*dto.RestrictDTO, error)
+	GetAllRestricts(ctx context.Context, limit, offset int) ([]*dto.RestrictDTO, error)
+	UpdateRestrict(ctx context.Context, id int, restrictDTO *dto.RestrictUpdateDTO) (*dto.RestrictDTO, error)
+	DeleteRestrict(ctx context.Context, id int) error
+	GetRestrictsByGameID(ctx context.Context, gameID int) ([]*dto.RestrictDTO, error)
 }
 
 // CartService defines business logic for cart operations
 type CartService interface {
-	GetCart(userID int) (*dto.CartResponseDTO, error)
-	AddGameToCart(userID int, cartItemDTO *dto.CartItemCreateDTO) error
-	RemoveGameFromCart(userID, gameID int) error
-	ClearCart(userID int) error
-	UpdateCartItemQuantity(userID, gameID int, quantityDTO *dto.CartItemUpdateDTO) error
-	CalculateCartTotal(userID int) (float64, error)
+	GetCart(ctx context.Context, userID int) (*dto.CartResponseDTO, error)
+	AddGameToCart(ctx context.Context, userID int, cartItemDTO *dto.CartItemCreateDTO) error
+	RemoveGameFromCart(ctx context.Context, userID, gameID int) error
+	ClearCart(ctx context.Context, userID int) error
+	UpdateCartItemQuantity(ctx context.Context, userID, gameID int, quantityDTO *dto.CartItemUpdateDTO) error
+	CalculateCartTotal(ctx context.Context, userID int) (int64, error)
+	SetGift(ctx context.Context, userID, gameID int, giftDTO *dto.CartGiftCreateDTO) error
+	AddGiftCopy(ctx context.Context, userID, gameID int, giftDTO *dto.CartGiftCreateDTO) error
+	RemoveGift(ctx context.Context, userID, gameID int, recipientID *int) error
+	ApplyCoupon(ctx context.Context, userID int, couponDTO *dto.CartCouponApplyDTO) (*dto.CartResponseDTO, error)
+	RemoveCoupon(ctx context.Context, userID int) error
+	AddBundleToCart(ctx context.Context, userID, bundleID int) error
+	RemoveBundleFromCart(ctx context.Context, userID, bundleID int) error
+	GetGuestCart(ctx context.Context, token string) (*dto.GuestCartDTO, error)
+	AddGameToGuestCart(ctx context.Context, token string, gameID int) (*dto.GuestCartDTO, error)
+	RemoveGameFromGuestCart(ctx context.Context, token string, gameID int) (*dto.GuestCartDTO, error)
+	ClearGuestCart(ctx context.Context, token string) error
+	MergeGuestCart(ctx context.Context, token string, userID int) error
+	DeleteStaleGuestCarts(ctx context.Context) error
 }
 
 // FavoriteService defines business logic for favorite operations
 type FavoriteService interface {
-	GetFavorite(userID int) (*dto.FavoriteResponseDTO, error)
-	AddGameToFavorite(userID, gameID int) error
-	RemoveGameFromFavorite(userID, gameID int) error
-	ClearFavorite(userID int) error
+	GetFavorite(ctx context.Context, userID int) (*dto.FavoriteResponseDTO, error)
+	AddGameToFavorite(ctx context.Context, userID, gameID int) error
+	RemoveGameFromFavorite(ctx context.Context, userID, gameID int) error
+	ClearFavorite(ctx context.Context, userID int) error
 }
 
 // LibraryService defines business logic for library operations
 type LibraryService interface {
-	GetLibrary(userID int) (*dto.LibraryResponseDTO, error)
+	GetLibrary(ctx context.Context, userID int) (*dto.LibraryResponseDTO, error)
+	UnlockReleasedGames(ctx context.Context) error
 }
 
 // OrderService defines business logic for order operations
 type OrderService interface {
-	CreateOrderFromCart(userID int) (*dto.OrderResponseDTO, error)
-	CreateOrder(orderDTO *dto.OrderCreateDTO) (*dto.OrderResponseDTO, error)
-	GetOrderByID(id int) (*dto.OrderResponseDTO, error)
-	GetUserOrders(userID int) ([]*dto.OrderResponseDTO, error)
-	GetAllOrders(limit, offset int) ([]*dto.OrderResponseDTO, error)
-	UpdateOrderStatus(id int, statusDTO *dto.OrderUpdateDTO) (*dto.OrderResponseDTO, error)
+	CreateOrderFromCart(ctx context.Context, userID int, checkoutDTO *dto.OrderCheckoutDTO) (*dto.OrderResponseDTO, error)
+	CreateOrder(ctx context.Context, orderDTO *dto.OrderCreateDTO) (*dto.OrderResponseDTO, error)
+	GetOrderByID(ctx context.Context, id int) (*dto.OrderResponseDTO, error)
+	GetUserOrders(ctx context.Context, userID int) ([]*dto.OrderResponseDTO, error)
+	GetAllOrders(ctx context.Context, limit, offset int) ([]*dto.OrderResponseDTO, error)
+	UpdateOrderStatus(ctx context.Context, id int, statusDTO *dto.OrderUpdateDTO) (*dto.OrderResponseDTO, error)
+	CancelPreOrder(ctx context.Context, userID, itemID int) (*dto.OrderResponseDTO, error)
+	GetInvoice(ctx context.Context, orderID int, format string) (*dto.InvoiceDocumentDTO, error)
+}
+
+// GiftService defines business logic for gift operations
+type GiftService interface {
+	GetReceivedGifts(ctx context.Context, userID int) ([]*dto.GiftDTO, error)
+	AcceptGift(ctx context.Context, giftID, userID int) (*dto.GiftDTO, error)
+	DeclineGift(ctx context.Context, giftID, userID int) (*dto.GiftDTO, error)
+}
+
+// BundleService defines business logic for bundle operations
+type BundleService interface {
+	CreateBundle(ctx context.Context, bundleDTO *dto.BundleCreateDTO) (*dto.BundleDTO, error)
+	GetBundleByID(ctx context.Context, id int) (*dto.BundleDTO, error)
+	GetBundles(ctx context.Context, limit, offset int) ([]*dto.BundleDTO, error)
+	UpdateBundle(ctx context.Context, id int, bundleDTO *dto.BundleUpdateDTO) (*dto.BundleDTO, error)
+	DeleteBundle(ctx context.Context, id int) error
+}
+
+// CouponService defines business logic for coupon management
+type CouponService interface {
+	CreateCoupon(ctx context.Context, couponDTO *dto.CouponCreateDTO) (*dto.CouponDTO, error)
+	GetCoupons(ctx context.Context, limit, offset int) ([]*dto.CouponDTO, error)
+	DeactivateCoupon(ctx context.Context, id int) error
+}
+
+// TaxService defines business logic for tax rule operations
+type TaxService interface {
+	GetTaxRules(ctx context.Context) ([]*dto.TaxRuleDTO, error)
+	CreateTaxRule(ctx context.Context, ruleDTO *dto.TaxRuleCreateDTO) (*dto.TaxRuleDTO, error)
+	UpdateTaxRule(ctx context.Context, id int, ruleDTO *dto.TaxRuleUpdateDTO) (*dto.TaxRuleDTO, error)
+	DeleteTaxRule(ctx context.Context, id int) error
+}
+
+// RedeemService defines business logic for redeem code operations
+type RedeemService interface {
+	GenerateCodes(ctx context.Context, generateDTO *dto.RedeemCodeGenerateDTO) ([]*dto.RedeemCodeDTO, error)
+	GetCodes(ctx context.Context, limit, offset int) ([]*dto.RedeemCodeDTO, error)
+	Redeem(ctx context.Context, userID int, redeemDTO *dto.RedeemDTO) (*dto.RedeemResultDTO, error)
 }
 
 // ReviewService defines business logic for review operations
 type ReviewService interface {
-	CreateReview(reviewDTO *dto.ReviewCreateDTO) (*dto.ReviewResponseDTO, error)
-	GetReviewByID(id int) (*dto.ReviewResponseDTO, error)
-	GetReviewsByGameID(gameID int) ([]*dto.ReviewResponseDTO, error)
-	UpdateReview(id, userID int, reviewDTO *dto.ReviewUpdateDTO) (*dto.ReviewResponseDTO, error)
-	DeleteReview(id, userID int) error
+	CreateReview(ctx context.Context, userID int, reviewDTO *dto.ReviewCreateDTO) (*dto.ReviewResponseDTO, error)
+	GetReviewByID(ctx context.Context, id int) (*dto.ReviewResponseDTO, error)
+	GetReviewsByGameID(ctx context.Context, gameID int, queryDTO *dto.ReviewListQueryDTO) (*dto.ReviewPageDTO, error)
+	UpdateReview(ctx context.Context, id, userID int, reviewDTO *dto.ReviewUpdateDTO) (*dto.ReviewResponseDTO, error)
+	DeleteReview(ctx context.Context, id, userID int) error
+	ReportReview(ctx context.Context, reviewID, userID int, reportDTO *dto.ReviewReportCreateDTO) (*dto.ReviewReportDTO, error)
+	GetModerationQueue(ctx context.Context, limit, offset int) ([]*dto.ReviewModerationDTO, error)
+	ApproveReview(ctx context.Context, id int) (*dto.ReviewResponseDTO, error)
+	HideReview(ctx context.Context, id int) (*dto.ReviewResponseDTO, error)
+	VoteReview(ctx context.Context, reviewID, userID int, voteDTO *dto.ReviewVoteDTO) (*dto.ReviewResponseDTO, error)
+	ReplyToReview(ctx context.Context, reviewID, userID int, isAdmin bool, replyDTO *dto.ReviewReplyCreateDTO) (*dto.ReviewReplyDTO, error)
+	DeleteReply(ctx context.Context, reviewID, userID int, isAdmin bool) error
 }
 
 // AuthService defines business logic for authentication operations
 type AuthService interface {
-	VerifyToken(token string) (int, string, error)
-	MatchUserTypeToID(userID int, roleType string) error
-	RefreshUserToken(token string) (string, string, error)
+	VerifyToken(ctx context.Context, token string) (int, string, error)
+	MatchUserTypeToID(ctx context.Context, userID int, roleType string) error
+	RefreshUserToken(ctx context.Context, token string) (string, string, error)
+}
+
+// NotificationService defines business logic for notification operations
+type NotificationService interface {
+	GetNotifications(ctx context.Context, userID int, queryDTO *dto.NotificationListQueryDTO) (*dto.NotificationPageDTO, error)
+	MarkRead(ctx context.Context, userID, notificationID int) error
+	MarkAllRead(ctx context.Context, userID int) error
+	GetPreferences(ctx context.Context, userID int) (*dto.NotificationPreferenceDTO, error)
+	UpdatePreferences(ctx context.Context, userID int, preferenceDTO *dto.NotificationPreferenceUpdateDTO) (*dto.NotificationPreferenceDTO, error)
+	CheckWishlists(ctx context.Context) error
+}
+
+// Mailer sends plain text emails
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// BusinessMetrics counts business events for monitoring
+type BusinessMetrics interface {
+	UserSignedUp()
+	LoginAttempted(succeeded bool)
+	OrderCreated(currency string)
+	OrderPaid(total int64, currency string)
+}
+
+// InvoiceRenderer renders issued invoices as documents
+type InvoiceRenderer interface {
+	PDF(invoice *models.Invoice) ([]byte, error)
+	HTML(invoice *models.Invoice) ([]byte, error)
 }
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// newInvoice copies a paid order and its buyer into an invoice, to be numbered when issued
+func newInvoice(order *models.Order, buyer *models.User) *models.Invoice {
+	invoice := &models.Invoice{
+		OrderID:        order.ID,
+		UserID:         order.UserID,
+		BuyerName:      buyer.Nickname,
+		BuyerEmail:     buyer.Email,
+		BuyerRegion:    buyer.Region,
+		Currency:       order.Currency,
+		Subtotal:       order.Subtotal,
+		CouponDiscount: order.CouponDiscount,
+		TaxName:        order.TaxName,
+		TaxRate:        order.TaxRate,
+		TaxInclusive:   order.TaxInclusive,
+		NetTotal:       order.NetTotal,
+		TaxTotal:       order.TaxTotal,
+		GrossTotal:     order.GrossTotal,
+		PointsDiscount: order.PointsDiscount,
+		CreditPaid:     order.CreditPaid,
+		TotalPaid:      order.TotalCost,
+		OrderedAt:      order.CreatedAt,
+		IssuedAt:       time.Now(),
+	}
+
+	for _, item := range order.OrderItems {
+		invoice.Lines = append(invoice.Lines, &models.InvoiceLine{
+			Description: invoiceLineDescription(item),
+			Quantity:    item.Quantity,
+			UnitPrice:   item.Price,
+			NetAmount:   item.NetAmount,
+			TaxAmount:   item.TaxAmount,
+			GrossAmount: item.GrossAmount,
+		})
+	}
+
+	return invoice
+}
+
+// invoiceLineDescription describes what an order item bought
+func invoiceLineDescription(item *models.OrderItem) string {
+	var description string
+	switch {
+	case item.Bundle != nil:
+		description = "Bundle: " + item.Bundle.Name
+	case item.BundleID != nil:
+		description = fmt.Sprintf("Bundle #%d", *item.BundleID)
+	case item.Game != nil:
+		description = item.Game.Title
+	case item.GameID != nil:
+		description = fmt.Sprintf("Game #%d", *item.GameID)
+	}
+
+	if item.PreOrder {
+		description += " (pre-order)"
+	}
+	if item.GiftRecipientID != nil {
+		description += " (gift)"
+	}
+	return description
+}
@@ -1,6 +1,10 @@
 package services
 
 import (
+	"context"
+	"log/slog"
+	"time"
+
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/interfaces/dto"
 )
@@ -9,26 +13,31 @@ import (
 type LibraryServiceImpl struct {
 	libraryRepo models.LibraryRepository
 	gameRepo    models.GameRepository
+	logger      *slog.Logger
 }
 
 // NewLibraryService creates a new library service
-func NewLibraryService(libraryRepo models.LibraryRepository, gameRepo models.GameRepository) LibraryService {
+func NewLibraryService(libraryRepo models.LibraryRepository, gameRepo models.GameRepository, logger *slog.Logger) LibraryService {
 	return &LibraryServiceImpl{
 		libraryRepo: libraryRepo,
 		gameRepo:    gameRepo,
+		logger:      logger,
 	}
 }
 
 // GetLibrary gets a user's game library
-func (s *LibraryServiceImpl) GetLibrary(userID int) (*dto.LibraryResponseDTO, error) {
+func (s *LibraryServiceImpl) GetLibrary(ctx context.Context, userID int) (*dto.LibraryResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "LibraryService.GetLibrary")
+	defer span.End()
+
 	// Get library from repository
-	library, err := s.libraryRepo.FindByUserID(userID)
+	library, err := s.libraryRepo.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get library items
-	libraryItems, err := s.libraryRepo.GetLibraryItems(userID)
+	libraryItems, err := s.libraryRepo.GetLibraryItems(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -45,3 +54,18 @@ func (s *LibraryServiceImpl) GetLibrary(userID int) (*dto.LibraryResponseDTO, er
 	// Create library response DTO
 	return dto.LibraryResponseDTOFromModel(library, libraryItems, libraryItemDTOs), nil
 }
+
+// UnlockReleasedGames makes pre-ordered games playable once their release date has passed
+func (s *LibraryServiceImpl) UnlockReleasedGames(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "LibraryService.UnlockReleasedGames")
+	defer span.End()
+
+	unlocked, err := s.libraryRepo.UnlockReleased(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if unlocked > 0 {
+		s.logger.InfoContext(ctx, "Unlocked pre-ordered games", "count", unlocked)
+	}
+	return nil
+}
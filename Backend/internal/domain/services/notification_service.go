@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/interfaces/dto"
+)
+
+// wishlistBatchSize is how many wishlist items are checked per query
+const wishlistBatchSize = 200
+
+// NotificationServiceImpl implements NotificationService interface
+type NotificationServiceImpl struct {
+	notificationRepo models.NotificationRepository
+	favoriteRepo     models.FavoriteRepository
+	mailer           Mailer
+	currencies       CurrencyRules
+	logger           *slog.Logger
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(notificationRepo models.NotificationRepository, favoriteRepo models.FavoriteRepository, mailer Mailer, currencies CurrencyRules, logger *slog.Logger) NotificationService {
+	return &NotificationServiceImpl{
+		notificationRepo: notificationRepo,
+		favoriteRepo:     favoriteRepo,
+		mailer:           mailer,
+		currencies:       currencies,
+		logger:           logger,
+	}
+}
+
+// GetNotifications retrieves a user's notifications, newest first
+func (s *NotificationServiceImpl) GetNotifications(ctx context.Context, userID int, queryDTO *dto.NotificationListQueryDTO) (*dto.NotificationPageDTO, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.GetNotifications")
+	defer span.End()
+
+	notifications, err := s.notificationRepo.FindByUserID(ctx, userID, queryDTO.Unread, queryDTO.Limit, queryDTO.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.NotificationPageDTO{
+		Items:  dto.NotificationDTOsFromModels(notifications),
+		Unread: unread,
+		Limit:  queryDTO.Limit,
+		Offset: queryDTO.Offset,
+	}, nil
+}
+
+// MarkRead marks one of the user's notifications as read
+func (s *NotificationServiceImpl) MarkRead(ctx context.Context, userID, notificationID int) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.MarkRead")
+	defer span.End()
+
+	if err := s.notificationRepo.MarkRead(ctx, userID, notificationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("notification not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// MarkAllRead marks all of the user's notifications as read
+func (s *NotificationServiceImpl) MarkAllRead(ctx context.Context, userID int) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.MarkAllRead")
+	defer span.End()
+
+	return s.notificationRepo.MarkAllRead(ctx, userID)
+}
+
+// GetPreferences retrieves the user's notification preferences
+func (s *NotificationServiceImpl) GetPreferences(ctx context.Context, userID int) (*dto.NotificationPreferenceDTO, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.GetPreferences")
+	defer span.End()
+
+	preference, err := s.notificationRepo.FindPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NotificationPreferenceDTOFromModel(preference), nil
+}
+
+// UpdatePreferences changes the user's notification preferences; omitted fields are left unchanged
+func (s *NotificationServiceImpl) UpdatePreferences(ctx context.Context, userID int, preferenceDTO *dto.NotificationPreferenceUpdateDTO) (*dto.NotificationPreferenceDTO, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.UpdatePreferences")
+	defer span.End()
+
+	preference, err := s.notificationRepo.FindPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if preferenceDTO.PriceDrops != nil {
+		preference.PriceDrops = *preferenceDTO.PriceDrops
+	}
+	if preferenceDTO.Releases != nil {
+		preference.Releases = *preferenceDTO.Releases
+	}
+	if preferenceDTO.Email != nil {
+		preference.Email = *preferenceDTO.Email
+	}
+	preference.UpdatedAt = time.Now()
+
+	if err := s.notificationRepo.SavePreference(ctx, preference); err != nil {
+		return nil, err
+	}
+
+	return dto.NotificationPreferenceDTOFromModel(preference), nil
+}
+
+// CheckWishlists notifies users whose wishlisted games got cheaper, went on sale or came out
+// since the last check. Each change is claimed on the wishlist item before notifying, so running
+// the check on several instances at once notifies a user only once.
+func (s *NotificationServiceImpl) CheckWishlists(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.CheckWishlists")
+	defer span.End()
+
+	now := time.Now()
+	preferences := make(map[int]*models.NotificationPreference)
+
+	for afterID := 0; ; {
+		items, err := s.favoriteRepo.FindWatchedItems(ctx, afterID, wishlistBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		for _, item := range items {
+			afterID = item.ID
+			if item.Game == nil || item.Favorite == nil || item.Favorite.User == nil {
+				continue
+			}
+			if err := s.checkWishlistItem(ctx, item, now, preferences); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// checkWishlistItem notifies the owner of a wishlist item about a price drop or release of its game
+func (s *NotificationServiceImpl) checkWishlistItem(ctx context.Context, item *models.FavoriteItem, now time.Time, preferences map[int]*models.NotificationPreference) error {
+	game, user := item.Game, item.Favorite.User
+
+	// Price drops and sales in the user's currency; price rises and currency changes only move the baseline
+	currency := s.currencies.forUser(user)
+	if amount, ok := game.SalePriceIn(currency); ok {
+		price := models.Money{Amount: amount, Currency: currency}
+		var watched *models.Money
+		if item.WatchedPrice != nil {
+			watched = &models.Money{Amount: *item.WatchedPrice, Currency: item.WatchedCurrency}
+		}
+
+		if watched == nil || *watched != price {
+			claimed, err := s.favoriteRepo.UpdateWatchedPrice(ctx, item.ID, watched, price)
+			if err != nil {
+				return err
+			}
+			if claimed && watched != nil && watched.Currency == price.Currency && price.Amount < watched.Amount {
+				preference, err := s.preference(ctx, user.ID, preferences)
+				if err != nil {
+					return err
+				}
+				if preference.PriceDrops {
+					if err := s.notify(ctx, user, preference, priceDropNotification(game, *watched, price)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	// Release
+	if !item.ReleaseNotified && released(game, now) {
+		claimed, err := s.favoriteRepo.MarkReleaseNotified(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+		if claimed {
+			preference, err := s.preference(ctx, user.ID, preferences)
+			if err != nil {
+				return err
+			}
+			if preference.Releases {
+				if err := s.notify(ctx, user, preference, releaseNotification(game)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// notify stores an in-app notification for the user and emails it if they asked for email.
+// A failed email is logged; the in-app notification stays.
+func (s *NotificationServiceImpl) notify(ctx context.Context, user *models.User, preference *models.NotificationPreference, notification *models.Notification) error {
+	notification.UserID = user.ID
+	if err := s.notificationRepo.Create(ctx, notification); err != nil {
+		return err
+	}
+
+	if !preference.Email || user.Email == "" {
+		return nil
+	}
+	if err := s.mailer.Send(user.Email, notification.Title, notification.Message); err != nil {
+		s.logger.WarnContext(ctx, "Failed to email notification", "notification_id", notification.ID, "user_id", user.ID, "error", err)
+		return nil
+	}
+	return s.notificationRepo.MarkEmailed(ctx, notification.ID)
+}
+
+// preference returns the user's notification preferences, loading each user's once per check
+func (s *NotificationServiceImpl) preference(ctx context.Context, userID int, preferences map[int]*models.NotificationPreference) (*models.NotificationPreference, error) {
+	if preference, ok := preferences[userID]; ok {
+		return preference, nil
+	}
+
+	preference, err := s.notificationRepo.FindPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	preferences[userID] = preference
+	return preference, nil
+}
+
+// priceDropNotification describes a wishlisted game getting cheaper
+func priceDropNotification(game *models.Game, previous, price models.Money) *models.Notification {
+	notification := &models.Notification{
+		GameID:  &game.ID,
+		Type:    models.NotificationTypePriceDrop,
+		Title:   fmt.Sprintf("%s is now cheaper", game.Title),
+		Message: fmt.Sprintf("%s on your wishlist dropped from %s to %s.", game.Title, previous, price),
+	}
+	if game.Discount > 0 {
+		full, _ := game.PriceIn(price.Currency)
+		notification.Type = models.NotificationTypeDiscount
+		notification.Title = fmt.Sprintf("%s is on sale", game.Title)
+		notification.Message = fmt.Sprintf("%s on your wishlist is %d%% off: %s instead of %s.", game.Title, game.Discount, price, models.FormatMoney(full, price.Currency))
+	}
+	return notification
+}
+
+// releaseNotification describes a wishlisted game coming out
+func releaseNotification(game *models.Game) *models.Notification {
+	return &models.Notification{
+		GameID:  &game.ID,
+		Type:    models.NotificationTypeRelease,
+		Title:   fmt.Sprintf("%s is out now", game.Title),
+		Message: fmt.Sprintf("%s on your wishlist has been released.", game.Title),
+	}
+}
+
+// released reports whether the game's release date has passed; games without one are not released yet
+func released(game *models.Game, now time.Time) bool {
+	return !game.ReleaseDate.IsZero() && !game.ReleaseDate.After(now)
+}
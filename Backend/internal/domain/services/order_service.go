@@ -1,143 +1,373 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"time"
 
+	"gorm.io/gorm"
+
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/interfaces/dto"
 )
 
 // OrderServiceImpl implements OrderService interface
 type OrderServiceImpl struct {
-	orderRepo models.OrderRepository
-	cartRepo  models.CartRepository
-	gameRepo  models.GameRepository
+	orderRepo   models.OrderRepository
+	cartRepo    models.CartRepository
+	gameRepo    models.GameRepository
+	couponRepo  models.CouponRepository
+	userRepo    models.UserRepository
+	creditRepo  models.StoreCreditRepository
+	invoiceRepo models.InvoiceRepository
+	invoices    InvoiceRenderer
+	pointsRules PointsRules
+	giftRules   giftRules
+	ownership   ownershipRules
+	pricing     pricing
+	reviewer    cartReviewer
+	metrics     BusinessMetrics
 }
 
 // NewOrderService creates a new order service
-func NewOrderService(orderRepo models.OrderRepository, cartRepo models.CartRepository, gameRepo models.GameRepository) OrderService {
+func NewOrderService(
+	orderRepo models.OrderRepository,
+	cartRepo models.CartRepository,
+	gameRepo models.GameRepository,
+	userRepo models.UserRepository,
+	libraryRepo models.LibraryRepository,
+	giftRepo models.GiftRepository,
+	couponRepo models.CouponRepository,
+	creditRepo models.StoreCreditRepository,
+	invoiceRepo models.InvoiceRepository,
+	invoices InvoiceRenderer,
+	pointsRules PointsRules,
+	currencies CurrencyRules,
+	taxes TaxCalculator,
+	metrics BusinessMetrics,
+) OrderService {
+	pricing := pricing{couponRepo: couponRepo, libraryRepo: libraryRepo, userRepo: userRepo, currencies: currencies, taxes: taxes}
 	return &OrderServiceImpl{
-		orderRepo: orderRepo,
-		cartRepo:  cartRepo,
-		gameRepo:  gameRepo,
+		orderRepo:   orderRepo,
+		cartRepo:    cartRepo,
+		gameRepo:    gameRepo,
+		couponRepo:  couponRepo,
+		userRepo:    userRepo,
+		creditRepo:  creditRepo,
+		invoiceRepo: invoiceRepo,
+		invoices:    invoices,
+		pointsRules: pointsRules,
+		pricing:     pricing,
+		ownership:   ownershipRules{libraryRepo: libraryRepo, orderRepo: orderRepo},
+		giftRules: giftRules{
+			userRepo:    userRepo,
+			libraryRepo: libraryRepo,
+			giftRepo:    giftRepo,
+		},
+		reviewer: cartReviewer{
+			cartRepo:   cartRepo,
+			couponRepo: couponRepo,
+			userRepo:   userRepo,
+			pricing:    pricing,
+		},
+		metrics: metrics,
 	}
 }
 
-// CreateOrderFromCart creates an order from a user's cart
-func (s *OrderServiceImpl) CreateOrderFromCart(userID int) (*dto.OrderResponseDTO, error) {
-	// Get cart items
-	cartItems, err := s.cartRepo.GetCartItems(userID)
+// CreateOrderFromCart creates an order from a user's cart, optionally paying part of it with loyalty points
+// and store credit.
+// The checkout must carry the version of the cart the user reviewed, so the amount charged is the one they saw.
+func (s *OrderServiceImpl) CreateOrderFromCart(ctx context.Context, userID int, checkoutDTO *dto.OrderCheckoutDTO) (*dto.OrderResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.CreateOrderFromCart")
+	defer span.End()
+
+	if checkoutDTO.CartVersion == "" {
+		return nil, errors.New("cart version is required")
+	}
+
+	// Price the cart for the user
+	review, err := s.reviewer.review(ctx, userID)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("cart not found")
+		}
 		return nil, err
 	}
 
 	// Check that the cart is not empty
-	if len(cartItems) == 0 {
+	if len(review.Items) == 0 {
 		return nil, errors.New("cart is empty")
 	}
 
-	// Calculate the total cost
-	var total float64
-	orderItems := make([]*models.OrderItem, 0, len(cartItems))
+	// Refuse to charge anything but what the user reviewed
+	if checkoutDTO.CartVersion != review.Version {
+		return nil, errors.New("cart has changed since it was reviewed")
+	}
+	if review.Blocked {
+		return nil, errors.New("cart contains items that can no longer be bought")
+	}
+	if review.NoRegion {
+		return nil, errNoRegion
+	}
+
+	// Lock in the current prices
+	lines := review.Lines
+	orderItems := make([]*models.OrderItem, 0, len(lines))
+	cartItemIDs := make([]int, 0, len(lines))
+	for i, line := range lines {
+		cartItemIDs = append(cartItemIDs, line.CartItem.ID)
+		amounts := review.Breakdown.Lines[i]
+		orderItem := &models.OrderItem{
+			Quantity:    line.Quantity,
+			Price:       line.Price,
+			NetAmount:   amounts.Net,
+			TaxAmount:   amounts.Tax,
+			GrossAmount: amounts.Gross,
+		}
 
-	for _, item := range cartItems {
-		// Get the current price of the game
-		game, err := s.gameRepo.FindByID(item.GameID)
+		if line.Bundle != nil {
+			if line.Owned == len(line.Bundle.Games) {
+				return nil, errors.New("you already own every game in this bundle")
+			}
+			orderItem.BundleID = &line.Bundle.ID
+			orderItem.Bundle = line.Bundle
+			for _, game := range line.Bundle.Games {
+				orderItem.BundleGames = append(orderItem.BundleGames, &models.OrderItemGame{GameID: game.ID})
+			}
+			orderItems = append(orderItems, orderItem)
+			continue
+		}
+
+		// Load the full game for the response
+		game, err := s.gameRepo.FindByID(ctx, line.Game.ID)
 		if err != nil {
 			return nil, err
 		}
-
-		// Create an order item
-		orderItem := &models.OrderItem{
-			GameID:   item.GameID,
-			Game:     game,
-			Quantity: item.Quantity,
-			Price:    game.Price, // Lock in the current price
+		orderItem.GameID = &game.ID
+		orderItem.Game = game
+		orderItem.PreOrder = preOrdered(game, time.Now())
+
+		// A gift copy goes to its recipient; otherwise the game must not be owned already
+		item := line.CartItem
+		if item.GiftRecipientID != nil {
+			if err := s.giftRules.check(ctx, userID, *item.GiftRecipientID, game.ID); err != nil {
+				return nil, err
+			}
+			orderItem.GiftRecipientID = item.GiftRecipientID
+			orderItem.GiftMessage = item.GiftMessage
+		} else if err := s.ownership.check(ctx, userID, game.ID); err != nil {
+			return nil, err
 		}
 
 		orderItems = append(orderItems, orderItem)
-		total += game.Price * float64(item.Quantity)
 	}
 
-	// Create the order
-	order := &models.Order{
-		UserID:     userID,
-		OrderItems: orderItems,
-		TotalCost:  total,
-		Status:     "new",
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+	// Apply the cart's coupon; unlike the cart view, checkout refuses a coupon that no longer applies
+	if review.Cart.CouponID != nil && review.Coupon == nil {
+		return nil, errors.New("coupon not found")
 	}
+	if review.CouponErr != nil {
+		return nil, review.CouponErr
+	}
+	coupon, breakdown := review.Coupon, review.Breakdown
 
-	// Save the order
-	if err := s.orderRepo.Create(order); err != nil {
-		return nil, err
+	// Redeemed points are capped to a share of the discounted total
+	if checkoutDTO.Points > s.pointsRules.maxRedeemable(breakdown.Total, breakdown.Currency) {
+		return nil, errors.New("too many points redeemed for this order")
 	}
+	discount := s.pointsRules.discountFor(checkoutDTO.Points, breakdown.Currency)
 
-	// Clear the cart
-	if err := s.cartRepo.ClearCart(userID); err != nil {
+	// Store credit in the order currency pays as much of the rest as it covers
+	var credit int64
+	if checkoutDTO.UseCredit {
+		balances, err := s.creditRepo.FindBalances(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, balance := range balances {
+			if balance.Currency == breakdown.Currency {
+				credit = min(balance.Balance, max(0, breakdown.Total-discount))
+			}
+		}
+	}
+
+	// Create the order; points and credit pay part of the taxed total and do not lower the tax
+	order := &models.Order{
+		UserID:         userID,
+		OrderItems:     orderItems,
+		Currency:       breakdown.Currency,
+		Subtotal:       breakdown.Subtotal,
+		CouponDiscount: breakdown.CouponDiscount,
+		PointsRedeemed: checkoutDTO.Points,
+		PointsDiscount: discount,
+		CreditPaid:     credit,
+		TotalCost:      max(0, breakdown.Total-discount-credit),
+		Status:         models.OrderStatusPending,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	setOrderTax(order, breakdown)
+	if coupon != nil {
+		order.CouponID = &coupon.ID
+		order.Coupon = coupon
+	}
+
+	// Save the order, redeem the coupon, spend the points and credit and take the ordered items out of the cart
+	if err := s.orderRepo.PlaceOrder(ctx, order, cartItemIDs); err != nil {
 		return nil, err
 	}
+	s.metrics.OrderCreated(order.Currency)
 
 	// Convert to DTO for response
 	return dto.OrderResponseDTOFromModel(order, orderItems), nil
 }
 
 // CreateOrder creates a new order
-func (s *OrderServiceImpl) CreateOrder(orderDTO *dto.OrderCreateDTO) (*dto.OrderResponseDTO, error) {
+func (s *OrderServiceImpl) CreateOrder(ctx context.Context, orderDTO *dto.OrderCreateDTO) (*dto.OrderResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.CreateOrder")
+	defer span.End()
+
 	// Validate order items
 	if len(orderDTO.Items) == 0 {
 		return nil, errors.New("order must have at least one item")
 	}
 
-	// Calculate total and create order items
-	var total float64
+	// Orders are priced in the currency the user pays in
+	currency, err := s.pricing.currencyFor(ctx, orderDTO.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	taxRule, err := s.pricing.taxRuleFor(ctx, orderDTO.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create order items
+	lines := make([]priceLine, 0, len(orderDTO.Items))
 	orderItems := make([]*models.OrderItem, 0, len(orderDTO.Items))
 
+	seen := make(map[int]bool, len(orderDTO.Items))
 	for _, item := range orderDTO.Items {
+		// Each game is a single copy the user must not own already
+		if seen[item.GameID] {
+			return nil, errors.New("order cannot contain the same game twice")
+		}
+		seen[item.GameID] = true
+		if err := s.ownership.check(ctx, orderDTO.UserID, item.GameID); err != nil {
+			return nil, err
+		}
+
 		// Get the current price of the game
-		game, err := s.gameRepo.FindByID(item.GameID)
+		game, err := s.gameRepo.FindByID(ctx, item.GameID)
 		if err != nil {
 			return nil, err
 		}
 
+		// Lock in the current price
+		price, ok := game.SalePriceIn(currency)
+		if !ok {
+			return nil, errors.New("game is not sold in the user's currency")
+		}
+
 		// Create an order item
 		orderItem := &models.OrderItem{
-			GameID:   item.GameID,
+			GameID:   &game.ID,
 			Game:     game,
-			Quantity: item.Quantity,
-			Price:    game.Price, // Lock in the current price
+			Quantity: 1,
+			Price:    price,
+			PreOrder: preOrdered(game, time.Now()),
 		}
 
 		orderItems = append(orderItems, orderItem)
-		total += game.Price * float64(item.Quantity)
+		lines = append(lines, priceLine{Game: game, Price: price, Quantity: 1})
+	}
+
+	// Calculate the totals and the tax of each item
+	breakdown := s.pricing.price(currency, lines, nil, taxRule)
+	for i, orderItem := range orderItems {
+		orderItem.NetAmount = breakdown.Lines[i].Net
+		orderItem.TaxAmount = breakdown.Lines[i].Tax
+		orderItem.GrossAmount = breakdown.Lines[i].Gross
 	}
 
 	// Create the order
 	order := &models.Order{
 		UserID:     orderDTO.UserID,
 		OrderItems: orderItems,
-		TotalCost:  total,
-		Status:     "new",
+		Currency:   currency,
+		Subtotal:   breakdown.Subtotal,
+		TotalCost:  breakdown.Total,
+		Status:     models.OrderStatusPending,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
+	setOrderTax(order, breakdown)
 
 	// Save the order
-	if err := s.orderRepo.Create(order); err != nil {
+	if err := s.orderRepo.Create(ctx, order); err != nil {
 		return nil, err
 	}
+	s.metrics.OrderCreated(order.Currency)
 
 	// Convert to DTO for response
 	return dto.OrderResponseDTOFromModel(order, orderItems), nil
 }
 
+// CancelPreOrder cancels one of the user's pre-ordered games before its release and refunds
+// its share of the paid order
+func (s *OrderServiceImpl) CancelPreOrder(ctx context.Context, userID, itemID int) (*dto.OrderResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.CancelPreOrder")
+	defer span.End()
+
+	item, err := s.orderRepo.FindItemByID(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order item not found")
+		}
+		return nil, err
+	}
+
+	// Other users' orders are reported as missing
+	order := item.Order
+	if order == nil || order.UserID != userID {
+		return nil, errors.New("order item not found")
+	}
+	if !item.PreOrder {
+		return nil, errors.New("item is not a pre-order")
+	}
+	if item.RefundedAt != nil {
+		return nil, errors.New("pre-order is already cancelled")
+	}
+	if order.Status != models.OrderStatusPaid {
+		return nil, errors.New("only paid pre-orders can be cancelled")
+	}
+	if item.Game != nil && !preOrdered(item.Game, time.Now()) {
+		return nil, errors.New("game has already been released")
+	}
+
+	amount, credit, redeemed, earned := refundShare(order, []*models.OrderItem{item})
+	if err := s.orderRepo.CancelPreOrderItem(ctx, order, item, amount, refundEntries(order, redeemed, earned), creditRefund(order, credit)); err != nil {
+		return nil, err
+	}
+
+	return s.GetOrderByID(ctx, order.ID)
+}
+
 // GetOrderByID gets an order by ID
-func (s *OrderServiceImpl) GetOrderByID(id int) (*dto.OrderResponseDTO, error) {
+func (s *OrderServiceImpl) GetOrderByID(ctx context.Context, id int) (*dto.OrderResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.GetOrderByID")
+	defer span.End()
+
 	// Get order from repository
-	order, err := s.orderRepo.FindByID(id)
+	order, err := s.orderRepo.FindByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
 		return nil, err
 	}
 
@@ -146,9 +376,12 @@ func (s *OrderServiceImpl) GetOrderByID(id int) (*dto.OrderResponseDTO, error) {
 }
 
 // GetUserOrders gets all orders for a user
-func (s *OrderServiceImpl) GetUserOrders(userID int) ([]*dto.OrderResponseDTO, error) {
+func (s *OrderServiceImpl) GetUserOrders(ctx context.Context, userID int) ([]*dto.OrderResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.GetUserOrders")
+	defer span.End()
+
 	// Get orders from repository
-	orders, err := s.orderRepo.FindByUserID(userID)
+	orders, err := s.orderRepo.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -158,9 +391,12 @@ func (s *OrderServiceImpl) GetUserOrders(userID int) ([]*dto.OrderResponseDTO, e
 }
 
 // GetAllOrders gets all orders
-func (s *OrderServiceImpl) GetAllOrders(limit, offset int) ([]*dto.OrderResponseDTO, error) {
+func (s *OrderServiceImpl) GetAllOrders(ctx context.Context, limit, offset int) ([]*dto.OrderResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.GetAllOrders")
+	defer span.End()
+
 	// Get orders from repository
-	orders, err := s.orderRepo.FindAll(limit, offset)
+	orders, err := s.orderRepo.FindAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -169,23 +405,160 @@ func (s *OrderServiceImpl) GetAllOrders(limit, offset int) ([]*dto.OrderResponse
 	return dto.OrderResponseDTOsFromModels(orders), nil
 }
 
-// UpdateOrderStatus updates an order's status
-func (s *OrderServiceImpl) UpdateOrderStatus(id int, statusDTO *dto.OrderUpdateDTO) (*dto.OrderResponseDTO, error) {
+// UpdateOrderStatus moves an order to a new status and settles its loyalty points and store
+// credit. Paying an order credits the earned points; cancelling or refunding it returns the
+// redeemed points and spent credit and takes back the earned points.
+func (s *OrderServiceImpl) UpdateOrderStatus(ctx context.Context, id int, statusDTO *dto.OrderUpdateDTO) (*dto.OrderResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.UpdateOrderStatus")
+	defer span.End()
+
 	// Get existing order
-	order, err := s.orderRepo.FindByID(id)
+	order, err := s.orderRepo.FindByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
 		return nil, err
 	}
 
+	fromStatus := order.Status
+	if fromStatus == statusDTO.Status {
+		return dto.OrderResponseDTOFromModel(order, order.OrderItems), nil
+	}
+	if fromStatus == models.OrderStatusCancelled || fromStatus == models.OrderStatusRefunded {
+		return nil, errors.New("order is already closed")
+	}
+
+	var entries []*models.PointsLedger
+	var credit *models.StoreCreditLedger
+	var invoice *models.Invoice
+	switch statusDTO.Status {
+	case models.OrderStatusPaid:
+		buyer, err := s.userRepo.FindByID(ctx, order.UserID)
+		if err != nil {
+			return nil, err
+		}
+		invoice = newInvoice(order, buyer)
+		order.PointsEarned = s.pointsRules.earnedForOrder(order.TotalCost, order.Currency)
+		if order.PointsEarned > 0 {
+			entries = append(entries, &models.PointsLedger{
+				UserID:  order.UserID,
+				Amount:  order.PointsEarned,
+				Reason:  models.PointsReasonOrderEarned,
+				OrderID: &order.ID,
+			})
+		}
+	case models.OrderStatusCancelled:
+		if fromStatus == models.OrderStatusPaid {
+			return nil, errors.New("paid orders must be refunded")
+		}
+		// Nothing was paid, so all spent points and credit are given back
+		entries = refundEntries(order, order.PointsRedeemed, 0)
+		credit = creditRefund(order, order.CreditPaid)
+	case models.OrderStatusRefunded:
+		if fromStatus != models.OrderStatusPaid {
+			return nil, errors.New("only paid orders can be refunded")
+		}
+		// Items refunded earlier, e.g. declined gifts, are already settled
+		var remaining []*models.OrderItem
+		for _, item := range order.OrderItems {
+			if item.RefundedAt == nil {
+				remaining = append(remaining, item)
+			}
+		}
+		amount, creditShare, redeemed, earned := refundShare(order, remaining)
+		order.RefundedAmount += amount
+		entries = refundEntries(order, redeemed, earned)
+		credit = creditRefund(order, creditShare)
+	}
+
 	// Update status
 	order.Status = statusDTO.Status
 	order.UpdatedAt = time.Now()
 
-	// Save changes
-	if err := s.orderRepo.Update(order); err != nil {
+	// Save changes together with the points and credit entries
+	if err := s.orderRepo.TransitionStatus(ctx, order, fromStatus, entries, credit, invoice); err != nil {
 		return nil, err
 	}
+	if order.Status == models.OrderStatusPaid {
+		s.metrics.OrderPaid(order.TotalCost, order.Currency)
+	}
 
 	// Convert to DTO for response
 	return dto.OrderResponseDTOFromModel(order, order.OrderItems), nil
 }
+
+// GetInvoice renders the invoice of a paid order in the format. Orders paid before invoices
+// were issued get theirs on first request; once issued, an invoice never changes.
+func (s *OrderServiceImpl) GetInvoice(ctx context.Context, orderID int, format string) (*dto.InvoiceDocumentDTO, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.GetInvoice")
+	defer span.End()
+
+	// Check the format before anything is issued
+	document := &dto.InvoiceDocumentDTO{}
+	var render func(*models.Invoice) ([]byte, error)
+	switch format {
+	case dto.InvoiceFormatHTML:
+		document.ContentType = "text/html; charset=utf-8"
+		render = s.invoices.HTML
+	case dto.InvoiceFormatPDF:
+		document.ContentType = "application/pdf"
+		render = s.invoices.PDF
+	default:
+		return nil, errors.New("unsupported invoice format")
+	}
+
+	invoice, err := s.invoiceRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if invoice, err = s.issueLateInvoice(ctx, orderID); err != nil {
+			return nil, err
+		}
+	}
+
+	document.Number = invoice.Number
+	document.UserID = invoice.UserID
+	if document.Content, err = render(invoice); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// issueLateInvoice issues the invoice of an order paid before invoices were issued
+func (s *OrderServiceImpl) issueLateInvoice(ctx context.Context, orderID int) (*models.Invoice, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("order not found")
+		}
+		return nil, err
+	}
+
+	// Refunded orders were paid first
+	if order.Status != models.OrderStatusPaid && order.Status != models.OrderStatusRefunded {
+		return nil, errors.New("order has not been paid")
+	}
+
+	buyer, err := s.userRepo.FindByID(ctx, order.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.invoiceRepo.Issue(ctx, newInvoice(order, buyer))
+}
+
+// setOrderTax records the tax of the breakdown on the order
+func setOrderTax(order *models.Order, breakdown priceBreakdown) {
+	order.NetTotal = breakdown.Net
+	order.TaxTotal = breakdown.Tax
+	order.GrossTotal = breakdown.Total
+	if rule := breakdown.TaxRule; rule != nil {
+		order.TaxRegion = rule.Region
+		order.TaxName = rule.Name
+		order.TaxRate = rule.Rate
+		order.TaxInclusive = rule.Inclusive
+	}
+}
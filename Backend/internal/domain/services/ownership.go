@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// ownershipRules keeps users from buying a game for themselves more than once
+type ownershipRules struct {
+	libraryRepo models.LibraryRepository
+	orderRepo   models.OrderRepository
+}
+
+// check reports whether the user may buy the game for themselves
+func (r ownershipRules) check(ctx context.Context, userID, gameID int) error {
+	owned, err := r.libraryRepo.HasGame(ctx, userID, gameID)
+	if err != nil {
+		return err
+	}
+	if owned {
+		return errors.New("you already own this game")
+	}
+
+	pending, err := r.orderRepo.HasPendingGame(ctx, userID, gameID)
+	if err != nil {
+		return err
+	}
+	if pending {
+		return errors.New("game is already in a pending order")
+	}
+
+	return nil
+}
+
+// preOrdered reports whether buying the game now is a pre-order: its release date is still to come
+func preOrdered(game *models.Game, now time.Time) bool {
+	return game.ReleaseDate.After(now)
+}
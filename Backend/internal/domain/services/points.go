@@ -0,0 +1,54 @@
+package services
+
+import (
+	"math"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// PointsRules configures how loyalty points are earned and redeemed
+type PointsRules struct {
+	// OrderPercent is the share of a paid order's total credited back as points
+	OrderPercent float64
+	// FirstReviewBonus is credited the first time a user reviews a game they own
+	FirstReviewBonus int
+	// PointValues is the value of a single point in major units, by currency. The values
+	// must be worth about the same, or users could earn points in one currency and spend
+	// them in another. Orders in a currency missing here neither earn nor redeem points.
+	PointValues map[string]float64
+	// MaxRedeemPercent caps the share of an order's subtotal payable with points
+	MaxRedeemPercent float64
+}
+
+// pointValue returns the value of a single point in minor units of the currency, or 0
+// when points have no value in it
+func (r PointsRules) pointValue(currency string) float64 {
+	value, ok := r.PointValues[currency]
+	if !ok || value <= 0 {
+		return 0
+	}
+	return value * math.Pow10(models.CurrencyExponent(currency))
+}
+
+// earnedForOrder returns the points earned by paying the given total, in minor units of the currency
+func (r PointsRules) earnedForOrder(total int64, currency string) int {
+	value := r.pointValue(currency)
+	if r.OrderPercent <= 0 || value <= 0 {
+		return 0
+	}
+	return int(math.Floor(float64(total) * r.OrderPercent / 100 / value))
+}
+
+// maxRedeemable returns the most points that may be redeemed on the given subtotal, in minor units of the currency
+func (r PointsRules) maxRedeemable(subtotal int64, currency string) int {
+	value := r.pointValue(currency)
+	if r.MaxRedeemPercent <= 0 || value <= 0 {
+		return 0
+	}
+	return int(math.Floor(float64(subtotal) * r.MaxRedeemPercent / 100 / value))
+}
+
+// discountFor returns the value of the given points in minor units of the currency, rounded half up
+func (r PointsRules) discountFor(points int, currency string) int64 {
+	return int64(math.Floor(float64(points)*r.pointValue(currency) + 0.5))
+}
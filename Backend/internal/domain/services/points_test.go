@@ -0,0 +1,114 @@
+package services
+
+import "testing"
+
+// testPointsRules credits 5% and caps redemption at 50%, with points worth about the same in every currency
+var testPointsRules = PointsRules{
+	OrderPercent:     5,
+	MaxRedeemPercent: 50,
+	PointValues:      map[string]float64{"USD": 0.01, "RUB": 1, "JPY": 1},
+}
+
+func TestPointValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		want     float64
+	}{
+		{"two-digit currency", "USD", 1},
+		{"rouble", "RUB", 100},
+		{"zero-digit currency", "JPY", 1},
+		{"currency without a value", "EUR", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testPointsRules.pointValue(tt.currency); got != tt.want {
+				t.Errorf("pointValue(%q) = %v, want %v", tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEarnedForOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    PointsRules
+		total    int64
+		currency string
+		want     int
+	}{
+		{"USD order", testPointsRules, 10000, "USD", 500},
+		{"rounds down", testPointsRules, 1999, "USD", 99},
+		{"RUB order earns the same as its USD worth", testPointsRules, 750000, "RUB", 375},
+		{"JPY order", testPointsRules, 1000, "JPY", 50},
+		{"currency without a value earns nothing", testPointsRules, 10000, "EUR", 0},
+		{"no earning percentage", PointsRules{PointValues: testPointsRules.PointValues}, 10000, "USD", 0},
+		{"zero total", testPointsRules, 0, "USD", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.earnedForOrder(tt.total, tt.currency); got != tt.want {
+				t.Errorf("earnedForOrder(%d, %q) = %d, want %d", tt.total, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxRedeemable(t *testing.T) {
+	tests := []struct {
+		name     string
+		subtotal int64
+		currency string
+		want     int
+	}{
+		{"half of a USD order", 10000, "USD", 5000},
+		{"rounds down", 999, "USD", 499},
+		{"half of a RUB order", 750000, "RUB", 3750},
+		{"half of a JPY order", 1000, "JPY", 500},
+		{"currency without a value redeems nothing", 10000, "EUR", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testPointsRules.maxRedeemable(tt.subtotal, tt.currency); got != tt.want {
+				t.Errorf("maxRedeemable(%d, %q) = %d, want %d", tt.subtotal, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscountFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    PointsRules
+		points   int
+		currency string
+		want     int64
+	}{
+		{"USD cents", testPointsRules, 500, "USD", 500},
+		{"RUB kopecks", testPointsRules, 500, "RUB", 50000},
+		{"JPY yen", testPointsRules, 500, "JPY", 500},
+		{"currency without a value is worth nothing", testPointsRules, 500, "EUR", 0},
+		{"rounds half up", PointsRules{PointValues: map[string]float64{"USD": 0.005}}, 3, "USD", 2},
+		{"no points", testPointsRules, 0, "USD", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.discountFor(tt.points, tt.currency); got != tt.want {
+				t.Errorf("discountFor(%d, %q) = %d, want %d", tt.points, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+// Points earned in one currency must not buy more when spent in another
+func TestPointsKeepTheirWorthAcrossCurrencies(t *testing.T) {
+	// 7500 RUB is worth about 75 USD under the test values
+	earned := testPointsRules.earnedForOrder(750000, "RUB")
+	spentInUSD := testPointsRules.discountFor(earned, "USD")
+	if earnedInUSD := testPointsRules.earnedForOrder(7500, "USD"); earned != earnedInUSD {
+		t.Errorf("7500 RUB earned %d points, 75 USD earned %d", earned, earnedInUSD)
+	}
+	if spentInUSD > 375 {
+		t.Errorf("points earned on 7500 RUB are worth %d cents, want at most 375", spentInUSD)
+	}
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// priceLine is a game or bundle, its unit price for the buyer and the number of copies being bought
+type priceLine struct {
+	CartItem *models.CartItem // Set for lines priced from a cart
+	Game     *models.Game
+	Bundle   *models.Bundle
+	Owned    int   // Games of the bundle the buyer already owns
+	Price    int64 // In minor units of the buyer's currency
+	Quantity int
+}
+
+// priceBreakdown holds the price of a cart or order before loyalty points, in minor units of Currency
+type priceBreakdown struct {
+	Currency       string
+	Subtotal       int64
+	CouponDiscount int64
+	TaxRule        *models.TaxRule // Nil when no tax is charged
+	Net            int64
+	Tax            int64
+	Total          int64        // With tax
+	Lines          []taxAmounts // Amounts of each line after its share of the coupon, in the order of the lines
+}
+
+// pricing computes cart and order prices so both always agree
+type pricing struct {
+	couponRepo  models.CouponRepository
+	libraryRepo models.LibraryRepository
+	userRepo    models.UserRepository
+	currencies  CurrencyRules
+	taxes       TaxCalculator
+}
+
+// currencyFor returns the currency the user pays in
+func (p pricing) currencyFor(ctx context.Context, userID int) (string, error) {
+	user, err := p.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return p.currencies.forUser(user), nil
+}
+
+// taxRuleFor returns the tax rule of the user's region, or nil when they pay no tax
+func (p pricing) taxRuleFor(ctx context.Context, userID int) (*models.TaxRule, error) {
+	user, err := p.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return p.taxes.ruleFor(ctx, user.Region)
+}
+
+// cartLines returns the price lines of the user's cart items in the currency. Every item is a
+// single copy; extra copies of a game are separate gift items. Items no longer for sale, or
+// without a price in the currency, get no line.
+func (p pricing) cartLines(ctx context.Context, userID int, currency string, items []*models.CartItem) ([]priceLine, error) {
+	lines := make([]priceLine, 0, len(items))
+	for _, item := range items {
+		switch {
+		case item.Bundle != nil:
+			if !item.Bundle.Active || item.Bundle.DeletedAt.Valid {
+				continue
+			}
+			price, owned, ok, err := p.bundlePrice(ctx, userID, item.Bundle, currency)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				lines = append(lines, priceLine{CartItem: item, Bundle: item.Bundle, Owned: owned, Price: price, Quantity: 1})
+			}
+		case item.Game != nil && !item.Game.DeletedAt.Valid:
+			if price, ok := item.Game.SalePriceIn(currency); ok {
+				lines = append(lines, priceLine{CartItem: item, Game: item.Game, Price: price, Quantity: 1})
+			}
+		}
+	}
+	return lines, nil
+}
+
+// bundleListPrice returns the price of the whole bundle in the currency. In a currency other
+// than the bundle's own, the bundle keeps the same share of its games' combined regional
+// prices. Reports false when a game of the bundle has no price in either currency.
+func bundleListPrice(bundle *models.Bundle, currency string) (int64, bool) {
+	if bundle.Currency == currency {
+		return bundle.Price, true
+	}
+
+	var full, base int64
+	for _, game := range bundle.Games {
+		price, ok := game.PriceIn(currency)
+		if !ok {
+			return 0, false
+		}
+		basePrice, ok := game.PriceIn(bundle.Currency)
+		if !ok {
+			return 0, false
+		}
+		full += price
+		base += basePrice
+	}
+	if base <= 0 {
+		return 0, bundle.Price == 0
+	}
+	return models.MulDiv(bundle.Price, full, base), true
+}
+
+// bundlePrice returns the "complete the bundle" price of a bundle for the user in the currency:
+// the bundle price reduced by the share of its games' value the user already owns. The number
+// of the bundle's games the user owns is returned with it. Reports false when the bundle has
+// no price in the currency.
+func (p pricing) bundlePrice(ctx context.Context, userID int, bundle *models.Bundle, currency string) (int64, int, bool, error) {
+	listPrice, ok := bundleListPrice(bundle, currency)
+	if !ok {
+		return 0, 0, false, nil
+	}
+
+	var full, missing int64
+	owned := 0
+	for _, game := range bundle.Games {
+		price, _ := game.PriceIn(currency)
+		full += price
+		has, err := p.libraryRepo.HasGame(ctx, userID, game.ID)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		if has {
+			owned++
+			continue
+		}
+		missing += price
+	}
+
+	if owned == 0 || full <= 0 {
+		return listPrice, owned, true, nil
+	}
+	return models.MulDiv(listPrice, missing, full), owned, true, nil
+}
+
+// price returns the subtotal of the lines in the currency, the discount given by the coupon, if any,
+// and the tax charged under the rule, if any. Tax is worked out per line on what is left after the
+// line's share of the coupon, so the lines always add up to the totals.
+func (p pricing) price(currency string, lines []priceLine, coupon *models.Coupon, taxRule *models.TaxRule) priceBreakdown {
+	var subtotal, eligible int64
+	covered := make([]bool, len(lines))
+	last := -1
+	for i, line := range lines {
+		amount := line.Price * int64(line.Quantity)
+		subtotal += amount
+		if coupon != nil && lineCovered(coupon, line) {
+			eligible += amount
+			covered[i] = true
+			last = i
+		}
+	}
+
+	var discount int64
+	if coupon != nil {
+		switch coupon.Type {
+		case models.CouponTypePercent:
+			discount = models.PercentOf(eligible, min(coupon.Value, 100))
+		case models.CouponTypeFixed:
+			if coupon.Currency == currency {
+				discount = min(coupon.Value, eligible)
+			}
+		}
+	}
+
+	breakdown := priceBreakdown{
+		Currency:       currency,
+		Subtotal:       subtotal,
+		CouponDiscount: discount,
+		TaxRule:        taxRule,
+		Lines:          make([]taxAmounts, len(lines)),
+	}
+
+	// Covered lines bear the discount in proportion to their amount; the last takes the rounding remainder
+	remaining := discount
+	for i, line := range lines {
+		amount := line.Price * int64(line.Quantity)
+		if discount > 0 && covered[i] {
+			share := remaining
+			if i != last {
+				share = models.MulDiv(discount, amount, eligible)
+			}
+			remaining -= share
+			amount = max(0, amount-share)
+		}
+
+		taxed := p.taxes.calculate(taxRule, amount)
+		breakdown.Lines[i] = taxed
+		breakdown.Net += taxed.Net
+		breakdown.Tax += taxed.Tax
+		breakdown.Total += taxed.Gross
+	}
+
+	return breakdown
+}
+
+// checkCoupon reports why the user cannot use the coupon on the lines priced in the currency, or nil if they can
+func (p pricing) checkCoupon(ctx context.Context, coupon *models.Coupon, userID int, currency string, lines []priceLine) error {
+	now := time.Now()
+	if !coupon.Active {
+		return errors.New("coupon is not active")
+	}
+	if coupon.StartsAt != nil && now.Before(*coupon.StartsAt) {
+		return errors.New("coupon is not valid yet")
+	}
+	if coupon.EndsAt != nil && now.After(*coupon.EndsAt) {
+		return errors.New("coupon has expired")
+	}
+	if coupon.MaxUses > 0 && coupon.Uses >= coupon.MaxUses {
+		return errors.New("coupon usage limit reached")
+	}
+	if coupon.MaxUsesPerUser > 0 {
+		used, err := p.couponRepo.CountUserRedemptions(ctx, coupon.ID, userID)
+		if err != nil {
+			return err
+		}
+		if used >= int64(coupon.MaxUsesPerUser) {
+			return errors.New("you have already used this coupon")
+		}
+	}
+
+	// Fixed amounts and minimums are only meaningful in the coupon's own currency
+	if coupon.Currency != currency && (coupon.Type == models.CouponTypeFixed || coupon.MinTotal > 0) {
+		return errors.New("coupon is not valid in your currency")
+	}
+
+	breakdown := p.price(currency, lines, coupon, nil)
+	if breakdown.Subtotal < coupon.MinTotal {
+		return errors.New("cart total is below the coupon minimum")
+	}
+	if breakdown.CouponDiscount <= 0 {
+		return errors.New("coupon does not apply to any game in the cart")
+	}
+
+	return nil
+}
+
+// lineCovered reports whether the coupon applies to a line; a bundle is covered when all of its games are
+func lineCovered(coupon *models.Coupon, line priceLine) bool {
+	if line.Bundle == nil {
+		return couponCovers(coupon, line.Game)
+	}
+	for _, game := range line.Bundle.Games {
+		if !couponCovers(coupon, game) {
+			return false
+		}
+	}
+	return true
+}
+
+// couponCovers reports whether the coupon's category and developer restrictions include the game
+func couponCovers(coupon *models.Coupon, game *models.Game) bool {
+	if len(coupon.Categories) == 0 && len(coupon.Developers) == 0 {
+		return true
+	}
+	for _, category := range coupon.Categories {
+		if category.ID == game.CategoryID {
+			return true
+		}
+	}
+	for _, developer := range coupon.Developers {
+		if developer.ID == game.DeveloperID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"testing"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// testLine is a single copy of a game in the category at the price
+func testLine(categoryID int, price int64) priceLine {
+	return priceLine{Game: &models.Game{CategoryID: categoryID}, Price: price, Quantity: 1}
+}
+
+func TestPriceSplitsCouponDiscount(t *testing.T) {
+	tests := []struct {
+		name      string
+		lines     []priceLine
+		coupon    *models.Coupon
+		taxRule   *models.TaxRule
+		wantNet   []int64 // Of each line
+		wantTotal int64
+	}{
+		{
+			name:      "no coupon",
+			lines:     []priceLine{testLine(1, 1000), testLine(1, 500)},
+			wantNet:   []int64{1000, 500},
+			wantTotal: 1500,
+		},
+		{
+			name:      "last line takes the rounding remainder",
+			lines:     []priceLine{testLine(1, 333), testLine(1, 333), testLine(1, 333)},
+			coupon:    &models.Coupon{Type: models.CouponTypeFixed, Value: 100, Currency: "USD"},
+			wantNet:   []int64{300, 300, 299},
+			wantTotal: 899,
+		},
+		{
+			name:      "percent discount rounded on the subtotal",
+			lines:     []priceLine{testLine(1, 1000), testLine(1, 1000), testLine(1, 1)},
+			coupon:    &models.Coupon{Type: models.CouponTypePercent, Value: 10},
+			wantNet:   []int64{900, 900, 1},
+			wantTotal: 1801,
+		},
+		{
+			name:      "only covered lines share the discount",
+			lines:     []priceLine{testLine(1, 333), testLine(2, 500), testLine(1, 333)},
+			coupon:    &models.Coupon{Type: models.CouponTypeFixed, Value: 101, Currency: "USD", Categories: []*models.Category{{ID: 1}}},
+			wantNet:   []int64{282, 500, 283},
+			wantTotal: 1065,
+		},
+		{
+			name:      "fixed discount capped at the covered amount",
+			lines:     []priceLine{testLine(1, 300), testLine(2, 500)},
+			coupon:    &models.Coupon{Type: models.CouponTypeFixed, Value: 1000, Currency: "USD", Categories: []*models.Category{{ID: 1}}},
+			wantNet:   []int64{0, 500},
+			wantTotal: 500,
+		},
+		{
+			name:      "fixed coupon in another currency gives nothing",
+			lines:     []priceLine{testLine(1, 1000)},
+			coupon:    &models.Coupon{Type: models.CouponTypeFixed, Value: 100, Currency: "EUR"},
+			wantNet:   []int64{1000},
+			wantTotal: 1000,
+		},
+		{
+			name:      "tax on each discounted line adds up to the total",
+			lines:     []priceLine{testLine(1, 333), testLine(1, 333), testLine(1, 333)},
+			coupon:    &models.Coupon{Type: models.CouponTypeFixed, Value: 100, Currency: "USD"},
+			taxRule:   &models.TaxRule{Rate: 1000},
+			wantNet:   []int64{300, 300, 299},
+			wantTotal: 989,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (pricing{}).price("USD", tt.lines, tt.coupon, tt.taxRule)
+
+			var subtotal, net, tax, total int64
+			for i, line := range tt.lines {
+				subtotal += line.Price * int64(line.Quantity)
+				if got.Lines[i].Net != tt.wantNet[i] {
+					t.Errorf("line %d net = %d, want %d", i, got.Lines[i].Net, tt.wantNet[i])
+				}
+				net += got.Lines[i].Net
+				tax += got.Lines[i].Tax
+				total += got.Lines[i].Gross
+			}
+
+			if got.Subtotal != subtotal {
+				t.Errorf("subtotal = %d, want %d", got.Subtotal, subtotal)
+			}
+			if got.Subtotal-got.CouponDiscount != net {
+				t.Errorf("subtotal %d less discount %d does not match the lines' net %d", got.Subtotal, got.CouponDiscount, net)
+			}
+			if got.Net != net || got.Tax != tax || got.Total != total {
+				t.Errorf("totals %d/%d/%d do not add up the lines' %d/%d/%d", got.Net, got.Tax, got.Total, net, tax, total)
+			}
+			if got.Total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", got.Total, tt.wantTotal)
+			}
+		})
+	}
+}
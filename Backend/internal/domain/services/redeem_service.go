@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/interfaces/dto"
+	"uniStore/Backend/internal/utils"
+)
+
+// RedeemServiceImpl implements RedeemService interface
+type RedeemServiceImpl struct {
+	redeemCodeRepo models.RedeemCodeRepository
+	gameRepo       models.GameRepository
+	currencies     CurrencyRules
+}
+
+// NewRedeemService creates a new redeem service
+func NewRedeemService(redeemCodeRepo models.RedeemCodeRepository, gameRepo models.GameRepository, currencies CurrencyRules) RedeemService {
+	return &RedeemServiceImpl{
+		redeemCodeRepo: redeemCodeRepo,
+		gameRepo:       gameRepo,
+		currencies:     currencies,
+	}
+}
+
+// GenerateCodes creates a batch of unique codes granting a game or store credit
+func (s *RedeemServiceImpl) GenerateCodes(ctx context.Context, generateDTO *dto.RedeemCodeGenerateDTO) ([]*dto.RedeemCodeDTO, error) {
+	ctx, span := tracer.Start(ctx, "RedeemService.GenerateCodes")
+	defer span.End()
+
+	if generateDTO.ExpiresAt != nil && generateDTO.ExpiresAt.Before(time.Now()) {
+		return nil, errors.New("expiry must be in the future")
+	}
+
+	// Store credit is kept in minor units of its currency
+	var creditAmount int64
+	var creditCurrency string
+	if generateDTO.CreditAmount > 0 {
+		var err error
+		if creditCurrency, err = s.currencies.resolve(generateDTO.CreditCurrency); err != nil {
+			return nil, err
+		}
+		creditAmount = models.MinorUnits(generateDTO.CreditAmount, creditCurrency)
+	}
+	if (generateDTO.GameID == nil) == (creditAmount == 0) {
+		return nil, errors.New("a code must grant either a game or store credit")
+	}
+
+	var game *models.Game
+	if generateDTO.GameID != nil {
+		var err error
+		if game, err = s.gameRepo.FindByID(ctx, *generateDTO.GameID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("game not found")
+			}
+			return nil, err
+		}
+	}
+
+	maxUses := generateDTO.MaxUses
+	if maxUses == 0 {
+		maxUses = 1
+	}
+
+	codes := make([]*models.RedeemCode, 0, generateDTO.Count)
+	seen := make(map[string]bool, generateDTO.Count)
+	for len(codes) < generateDTO.Count {
+		code, err := utils.GenerateCode(4, 4)
+		if err != nil {
+			return nil, err
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		codes = append(codes, &models.RedeemCode{
+			Code:           code,
+			GameID:         generateDTO.GameID,
+			CreditAmount:   creditAmount,
+			CreditCurrency: creditCurrency,
+			MaxUses:        maxUses,
+			ExpiresAt:      generateDTO.ExpiresAt,
+			Note:           generateDTO.Note,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		})
+	}
+
+	if err := s.redeemCodeRepo.CreateBatch(ctx, codes); err != nil {
+		return nil, err
+	}
+
+	for _, code := range codes {
+		code.Game = game
+	}
+	return dto.RedeemCodeDTOsFromModels(codes), nil
+}
+
+// GetCodes gets generated codes with their redemptions, newest first
+func (s *RedeemServiceImpl) GetCodes(ctx context.Context, limit, offset int) ([]*dto.RedeemCodeDTO, error) {
+	ctx, span := tracer.Start(ctx, "RedeemService.GetCodes")
+	defer span.End()
+
+	codes, err := s.redeemCodeRepo.FindAll(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.RedeemCodeDTOsFromModels(codes), nil
+}
+
+// Redeem grants the user what a code is worth
+func (s *RedeemServiceImpl) Redeem(ctx context.Context, userID int, redeemDTO *dto.RedeemDTO) (*dto.RedeemResultDTO, error) {
+	ctx, span := tracer.Start(ctx, "RedeemService.Redeem")
+	defer span.End()
+
+	code, err := s.redeemCodeRepo.Redeem(ctx, utils.NormalizeCode(redeemDTO.Code), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("code not found")
+		}
+		return nil, err
+	}
+
+	result := &dto.RedeemResultDTO{
+		CreditAmount:   code.CreditAmount,
+		CreditCurrency: code.CreditCurrency,
+		CreditPoints:   code.CreditPoints,
+	}
+	if code.GameID != nil {
+		game, err := s.gameRepo.FindByID(ctx, *code.GameID)
+		if err != nil {
+			return nil, err
+		}
+		result.Game = dto.GameDTOFromModel(game)
+	}
+
+	return result, nil
+}
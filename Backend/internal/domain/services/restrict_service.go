@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"time"
 
 	"uniStore/Backend/internal/domain/models"
@@ -20,7 +21,10 @@ func NewRestrictService(restrictRepo models.RestrictRepository) RestrictService
 }
 
 // CreateRestrict creates a new restrict
-func (s *RestrictServiceImpl) CreateRestrict(restrictDTO *dto.RestrictCreateDTO) (*dto.RestrictDTO, error) {
+func (s *RestrictServiceImpl) CreateRestrict(ctx context.Context, restrictDTO *dto.RestrictCreateDTO) (*dto.RestrictDTO, error) {
+	ctx, span := tracer.Start(ctx, "RestrictService.CreateRestrict")
+	defer span.End()
+
 	// Convert DTO to model
 	restrict := restrictDTO.ToModel()
 
@@ -29,7 +33,7 @@ func (s *RestrictServiceImpl) CreateRestrict(restrictDTO *dto.RestrictCreateDTO)
 	restrict.UpdatedAt = time.Now()
 
 	// Create restrict in repository
-	if err := s.restrictRepo.Create(restrict); err != nil {
+	if err := s.restrictRepo.Create(ctx, restrict); err != nil {
 		return nil, err
 	}
 
@@ -38,9 +42,12 @@ func (s *RestrictServiceImpl) CreateRestrict(restrictDTO *dto.RestrictCreateDTO)
 }
 
 // GetRestrictByID gets a restrict by ID
-func (s *RestrictServiceImpl) GetRestrictByID(id int) (*dto.RestrictDTO, error) {
+func (s *RestrictServiceImpl) GetRestrictByID(ctx context.Context, id int) (*dto.RestrictDTO, error) {
+	ctx, span := tracer.Start(ctx, "RestrictService.GetRestrictByID")
+	defer span.End()
+
 	// Get restrict from repository
-	restrict, err := s.restrictRepo.FindByID(id)
+	restrict, err := s.restrictRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -50,9 +57,12 @@ func (s *RestrictServiceImpl) GetRestrictByID(id int) (*dto.RestrictDTO, error)
 }
 
 // GetAllRestricts gets all restricts
-func (s *RestrictServiceImpl) GetAllRestricts(limit, offset int) ([]*dto.RestrictDTO, error) {
+func (s *RestrictServiceImpl) GetAllRestricts(ctx context.Context, limit, offset int) ([]*dto.RestrictDTO, error) {
+	ctx, span := tracer.Start(ctx, "RestrictService.GetAllRestricts")
+	defer span.End()
+
 	// Get restricts from repository
-	restricts, err := s.restrictRepo.FindAll(limit, offset)
+	restricts, err := s.restrictRepo.FindAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -62,9 +72,12 @@ func (s *RestrictServiceImpl) GetAllRestricts(limit, offset int) ([]*dto.Restric
 }
 
 // GetRestrictsByGameID gets all restricts for a game
-func (s *RestrictServiceImpl) GetRestrictsByGameID(gameID int) ([]*dto.RestrictDTO, error) {
+func (s *RestrictServiceImpl) GetRestrictsByGameID(ctx context.Context, gameID int) ([]*dto.RestrictDTO, error) {
+	ctx, span := tracer.Start(ctx, "RestrictService.GetRestrictsByGameID")
+	defer span.End()
+
 	// Get restricts from repository
-	restricts, err := s.restrictRepo.FindByGameID(gameID)
+	restricts, err := s.restrictRepo.FindByGameID(ctx, gameID)
 	if err != nil {
 		return nil, err
 	}
@@ -74,9 +87,12 @@ func (s *RestrictServiceImpl) GetRestrictsByGameID(gameID int) ([]*dto.RestrictD
 }
 
 // UpdateRestrict updates a restrict
-func (s *RestrictServiceImpl) UpdateRestrict(id int, restrictDTO *dto.RestrictUpdateDTO) (*dto.RestrictDTO, error) {
+func (s *RestrictServiceImpl) UpdateRestrict(ctx context.Context, id int, restrictDTO *dto.RestrictUpdateDTO) (*dto.RestrictDTO, error) {
+	ctx, span := tracer.Start(ctx, "RestrictService.UpdateRestrict")
+	defer span.End()
+
 	// Get existing restriction
-	existingRestrict, err := s.restrictRepo.FindByID(id)
+	existingRestrict, err := s.restrictRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +109,7 @@ func (s *RestrictServiceImpl) UpdateRestrict(id int, restrictDTO *dto.RestrictUp
 	existingRestrict.UpdatedAt = time.Now()
 
 	// Update restrict in repository
-	if err := s.restrictRepo.Update(existingRestrict); err != nil {
+	if err := s.restrictRepo.Update(ctx, existingRestrict); err != nil {
 		return nil, err
 	}
 
@@ -102,6 +118,9 @@ func (s *RestrictServiceImpl) UpdateRestrict(id int, restrictDTO *dto.RestrictUp
 }
 
 // DeleteRestrict deletes a restrict
-func (s *RestrictServiceImpl) DeleteRestrict(id int) error {
-	return s.restrictRepo.Delete(id)
+func (s *RestrictServiceImpl) DeleteRestrict(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "RestrictService.DeleteRestrict")
+	defer span.End()
+
+	return s.restrictRepo.Delete(ctx, id)
 }
@@ -1,64 +1,158 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"time"
+	"unicode"
+
+	"gorm.io/gorm"
 
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/interfaces/dto"
 )
 
+// ReviewOptions configures review policies
+type ReviewOptions struct {
+	// RequirePurchase allows only users owning the game to review it
+	RequirePurchase bool
+	// BannedWords puts reviews containing any of these words or phrases on hold for moderation
+	BannedWords []string
+}
+
+// containsBannedWord reports whether text contains one of the banned words or phrases.
+// Matching is case-insensitive and only considers whole words.
+func (o ReviewOptions) containsBannedWord(text string) bool {
+	if len(o.BannedWords) == 0 {
+		return false
+	}
+
+	normalize := func(s string) string {
+		words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		return strings.Join(words, " ")
+	}
+
+	normalizedText := " " + normalize(text) + " "
+	for _, banned := range o.BannedWords {
+		term := normalize(banned)
+		if term != "" && strings.Contains(normalizedText, " "+term+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// initialStatus returns the moderation status for new or edited review text
+func (o ReviewOptions) initialStatus(review *models.Review) string {
+	if o.containsBannedWord(review.Title + " " + review.Description) {
+		return models.ReviewStatusPending
+	}
+	return models.ReviewStatusVisible
+}
+
 // ReviewServiceImpl implements ReviewService interface
 type ReviewServiceImpl struct {
-	reviewRepo models.ReviewRepository
-	gameRepo   models.GameRepository
-	userRepo   models.UserRepository
+	reviewRepo    models.ReviewRepository
+	gameRepo      models.GameRepository
+	userRepo      models.UserRepository
+	libraryRepo   models.LibraryRepository
+	developerRepo models.DeveloperRepository
+	options       ReviewOptions
+	pointsRules   PointsRules
 }
 
 // NewReviewService creates a new review service
-func NewReviewService(reviewRepo models.ReviewRepository, gameRepo models.GameRepository, userRepo models.UserRepository) ReviewService {
+func NewReviewService(
+	reviewRepo models.ReviewRepository,
+	gameRepo models.GameRepository,
+	userRepo models.UserRepository,
+	libraryRepo models.LibraryRepository,
+	developerRepo models.DeveloperRepository,
+	options ReviewOptions,
+	pointsRules PointsRules,
+) ReviewService {
 	return &ReviewServiceImpl{
-		reviewRepo: reviewRepo,
-		gameRepo:   gameRepo,
-		userRepo:   userRepo,
+		reviewRepo:    reviewRepo,
+		gameRepo:      gameRepo,
+		userRepo:      userRepo,
+		libraryRepo:   libraryRepo,
+		developerRepo: developerRepo,
+		options:       options,
+		pointsRules:   pointsRules,
 	}
 }
 
-// CreateReview creates a new review
-func (s *ReviewServiceImpl) CreateReview(reviewDTO *dto.ReviewCreateDTO) (*dto.ReviewResponseDTO, error) {
+// CreateReview creates a new review authored by the given user
+func (s *ReviewServiceImpl) CreateReview(ctx context.Context, userID int, reviewDTO *dto.ReviewCreateDTO) (*dto.ReviewResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.CreateReview")
+	defer span.End()
+
 	// Check if the game exists
-	game, err := s.gameRepo.FindByID(reviewDTO.GameID)
+	game, err := s.gameRepo.FindByID(ctx, reviewDTO.GameID)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("game not found")
+		}
 		return nil, err
 	}
-	if game == nil {
-		return nil, errors.New("game not found")
-	}
 
 	// Check if the user exists
-	user, err := s.userRepo.FindByID(reviewDTO.UserID)
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	// Only one review per user per game
+	_, err = s.reviewRepo.FindByGameAndUser(ctx, game.ID, user.ID)
+	if err == nil {
+		return nil, errors.New("you have already reviewed this game")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Reviews from owners carry the verified purchase flag
+	owned, err := s.libraryRepo.HasGame(ctx, user.ID, game.ID)
 	if err != nil {
 		return nil, err
 	}
-	if user == nil {
-		return nil, errors.New("user not found")
+	if !owned && s.options.RequirePurchase {
+		return nil, errors.New("only owners of the game can review it")
 	}
 
 	// Create model from DTO
-	review := &models.Review{
-		GameID:      reviewDTO.GameID,
-		UserID:      reviewDTO.UserID,
-		Title:       "Review", // Default title
-		Description: reviewDTO.Comment,
-		Rating:      reviewDTO.Rating,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-		Game:        game,
-		User:        user,
-	}
+	review := reviewDTO.ToModel(user.ID)
+	review.VerifiedPurchase = owned
+	review.Status = s.options.initialStatus(review)
+	review.CreatedAt = time.Now()
+	review.UpdatedAt = time.Now()
+	review.Game = game
+	review.User = user
 
-	// Create review in repository
-	if err := s.reviewRepo.Create(review); err != nil {
+	// Owners earn a one-time bonus for reviewing a game
+	if owned && s.pointsRules.FirstReviewBonus > 0 {
+		bonus := &models.PointsLedger{
+			UserID: user.ID,
+			Amount: s.pointsRules.FirstReviewBonus,
+			Reason: models.PointsReasonReviewBonus,
+			GameID: &game.ID,
+		}
+		err = s.reviewRepo.CreateWithBonus(ctx, review, bonus)
+	} else {
+		err = s.reviewRepo.Create(ctx, review)
+	}
+	if err != nil {
+		// Another request of the user reviewed the game since the check above
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errors.New("you have already reviewed this game")
+		}
 		return nil, err
 	}
 
@@ -67,39 +161,74 @@ func (s *ReviewServiceImpl) CreateReview(reviewDTO *dto.ReviewCreateDTO) (*dto.R
 }
 
 // GetReviewByID gets a review by ID
-func (s *ReviewServiceImpl) GetReviewByID(id int) (*dto.ReviewResponseDTO, error) {
+func (s *ReviewServiceImpl) GetReviewByID(ctx context.Context, id int) (*dto.ReviewResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.GetReviewByID")
+	defer span.End()
+
 	// Get review from repository
-	review, err := s.reviewRepo.FindByID(id)
+	review, err := s.reviewRepo.FindByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
 		return nil, err
 	}
 
+	// Held and hidden reviews are not public
+	if review.Status != models.ReviewStatusVisible {
+		return nil, errors.New("review not found")
+	}
+
 	// Convert model to DTO for response
 	return dto.ReviewResponseDTOFromModel(review), nil
 }
 
-// GetReviewsByGameID gets all reviews for a game
-func (s *ReviewServiceImpl) GetReviewsByGameID(gameID int) ([]*dto.ReviewResponseDTO, error) {
+// GetReviewsByGameID gets a sorted and filtered page of visible reviews for a game
+func (s *ReviewServiceImpl) GetReviewsByGameID(ctx context.Context, gameID int, queryDTO *dto.ReviewListQueryDTO) (*dto.ReviewPageDTO, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.GetReviewsByGameID")
+	defer span.End()
+
+	query := queryDTO.ToQuery()
+	switch query.Sort {
+	case models.ReviewSortHelpful, models.ReviewSortNewest, models.ReviewSortHighest, models.ReviewSortLowest:
+	default:
+		return nil, errors.New("invalid sort mode")
+	}
+
+	// Check if the game exists
+	if _, err := s.gameRepo.FindByID(ctx, gameID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("game not found")
+		}
+		return nil, err
+	}
+
 	// Get reviews from repository
-	reviews, err := s.reviewRepo.FindByGameID(gameID)
+	reviews, total, err := s.reviewRepo.FindByGameID(ctx, gameID, query)
 	if err != nil {
 		return nil, err
 	}
 
 	// Convert models to DTOs for response
-	reviewDTOs := make([]*dto.ReviewResponseDTO, len(reviews))
-	for i, review := range reviews {
-		reviewDTOs[i] = dto.ReviewResponseDTOFromModel(review)
-	}
-
-	return reviewDTOs, nil
+	return &dto.ReviewPageDTO{
+		Items:  dto.ReviewResponseDTOsFromModels(reviews),
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}, nil
 }
 
 // UpdateReview updates a review
-func (s *ReviewServiceImpl) UpdateReview(id, userID int, reviewDTO *dto.ReviewUpdateDTO) (*dto.ReviewResponseDTO, error) {
+func (s *ReviewServiceImpl) UpdateReview(ctx context.Context, id, userID int, reviewDTO *dto.ReviewUpdateDTO) (*dto.ReviewResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.UpdateReview")
+	defer span.End()
+
 	// Get existing review
-	existingReview, err := s.reviewRepo.FindByID(id)
+	existingReview, err := s.reviewRepo.FindByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
 		return nil, err
 	}
 
@@ -112,11 +241,16 @@ func (s *ReviewServiceImpl) UpdateReview(id, userID int, reviewDTO *dto.ReviewUp
 	existingReview.Rating = reviewDTO.Rating
 	existingReview.Description = reviewDTO.Comment
 
+	// Re-check edited text, but never lift a moderator's takedown
+	if existingReview.Status != models.ReviewStatusHidden {
+		existingReview.Status = s.options.initialStatus(existingReview)
+	}
+
 	// Update timestamp
 	existingReview.UpdatedAt = time.Now()
 
 	// Update review in repository
-	if err := s.reviewRepo.Update(existingReview); err != nil {
+	if err := s.reviewRepo.Update(ctx, existingReview); err != nil {
 		return nil, err
 	}
 
@@ -125,10 +259,16 @@ func (s *ReviewServiceImpl) UpdateReview(id, userID int, reviewDTO *dto.ReviewUp
 }
 
 // DeleteReview deletes a review
-func (s *ReviewServiceImpl) DeleteReview(id, userID int) error {
+func (s *ReviewServiceImpl) DeleteReview(ctx context.Context, id, userID int) error {
+	ctx, span := tracer.Start(ctx, "ReviewService.DeleteReview")
+	defer span.End()
+
 	// Get existing review
-	existingReview, err := s.reviewRepo.FindByID(id)
+	existingReview, err := s.reviewRepo.FindByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("review not found")
+		}
 		return err
 	}
 
@@ -137,5 +277,238 @@ func (s *ReviewServiceImpl) DeleteReview(id, userID int) error {
 		return errors.New("review belongs to another user")
 	}
 
-	return s.reviewRepo.Delete(id)
+	return s.reviewRepo.Delete(ctx, id)
+}
+
+// ReportReview files a user's report against a review
+func (s *ReviewServiceImpl) ReportReview(ctx context.Context, reviewID, userID int, reportDTO *dto.ReviewReportCreateDTO) (*dto.ReviewReportDTO, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.ReportReview")
+	defer span.End()
+
+	review, err := s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
+		return nil, err
+	}
+	if review.Status == models.ReviewStatusHidden {
+		return nil, errors.New("review not found")
+	}
+	if review.UserID == userID {
+		return nil, errors.New("you cannot report your own review")
+	}
+
+	// A user can report a review only once
+	_, err = s.reviewRepo.FindReport(ctx, reviewID, userID)
+	if err == nil {
+		return nil, errors.New("you have already reported this review")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	report := &models.ReviewReport{
+		ReviewID:  reviewID,
+		UserID:    userID,
+		Reason:    strings.TrimSpace(reportDTO.Reason),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := s.reviewRepo.CreateReport(ctx, report); err != nil {
+		// Another request of the user reported the review since the check above
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errors.New("you have already reported this review")
+		}
+		return nil, err
+	}
+
+	return dto.ReviewReportDTOFromModel(report), nil
+}
+
+// GetModerationQueue gets reviews awaiting moderation together with their open reports
+func (s *ReviewServiceImpl) GetModerationQueue(ctx context.Context, limit, offset int) ([]*dto.ReviewModerationDTO, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.GetModerationQueue")
+	defer span.End()
+
+	reviews, err := s.reviewRepo.FindModerationQueue(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := make([]*dto.ReviewModerationDTO, len(reviews))
+	for i, review := range reviews {
+		queue[i] = dto.ReviewModerationDTOFromModel(review)
+	}
+
+	return queue, nil
+}
+
+// ApproveReview makes a review visible and resolves its reports
+func (s *ReviewServiceImpl) ApproveReview(ctx context.Context, id int) (*dto.ReviewResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.ApproveReview")
+	defer span.End()
+
+	return s.moderate(ctx, id, models.ReviewStatusVisible)
+}
+
+// HideReview takes a review down and resolves its reports
+func (s *ReviewServiceImpl) HideReview(ctx context.Context, id int) (*dto.ReviewResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.HideReview")
+	defer span.End()
+
+	return s.moderate(ctx, id, models.ReviewStatusHidden)
+}
+
+// moderate sets the moderation status of a review
+func (s *ReviewServiceImpl) moderate(ctx context.Context, id int, status string) (*dto.ReviewResponseDTO, error) {
+	if err := s.reviewRepo.Moderate(ctx, id, status); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
+		return nil, err
+	}
+
+	review, err := s.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ReviewResponseDTOFromModel(review), nil
+}
+
+// VoteReview records the user's helpfulness vote on a review
+func (s *ReviewServiceImpl) VoteReview(ctx context.Context, reviewID, userID int, voteDTO *dto.ReviewVoteDTO) (*dto.ReviewResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.VoteReview")
+	defer span.End()
+
+	review, err := s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
+		return nil, err
+	}
+	if review.Status != models.ReviewStatusVisible {
+		return nil, errors.New("review not found")
+	}
+	if review.UserID == userID {
+		return nil, errors.New("you cannot vote on your own review")
+	}
+
+	if err := s.reviewRepo.Vote(ctx, reviewID, userID, *voteDTO.Helpful); err != nil {
+		return nil, err
+	}
+
+	// Reload to return the updated counters
+	review, err = s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ReviewResponseDTOFromModel(review), nil
+}
+
+// ReplyToReview posts or edits the developer reply to a review.
+// Only accounts linked to the game's developer and admins may reply.
+func (s *ReviewServiceImpl) ReplyToReview(ctx context.Context, reviewID, userID int, isAdmin bool, replyDTO *dto.ReviewReplyCreateDTO) (*dto.ReviewReplyDTO, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.ReplyToReview")
+	defer span.End()
+
+	review, err := s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
+		return nil, err
+	}
+	if review.Status == models.ReviewStatusHidden {
+		return nil, errors.New("review not found")
+	}
+
+	developerID, err := s.authorizeReply(ctx, review, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	// A review has a single reply which is edited in place
+	reply, err := s.reviewRepo.FindReply(ctx, reviewID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		reply = &models.ReviewReply{
+			ReviewID:    reviewID,
+			DeveloperID: developerID,
+			CreatedAt:   time.Now(),
+		}
+	}
+	reply.UserID = userID
+	reply.Body = strings.TrimSpace(replyDTO.Body)
+	reply.UpdatedAt = time.Now()
+
+	if err := s.reviewRepo.SaveReply(ctx, reply); err != nil {
+		return nil, err
+	}
+
+	// Reload to include the developer
+	reply, err = s.reviewRepo.FindReply(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ReviewReplyDTOFromModel(reply), nil
+}
+
+// DeleteReply removes the developer reply from a review
+func (s *ReviewServiceImpl) DeleteReply(ctx context.Context, reviewID, userID int, isAdmin bool) error {
+	ctx, span := tracer.Start(ctx, "ReviewService.DeleteReply")
+	defer span.End()
+
+	review, err := s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("review not found")
+		}
+		return err
+	}
+
+	if _, err := s.authorizeReply(ctx, review, userID, isAdmin); err != nil {
+		return err
+	}
+
+	if err := s.reviewRepo.DeleteReply(ctx, reviewID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("reply not found")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// authorizeReply checks that the user may reply on behalf of the reviewed game's developer
+// and returns that developer's ID
+func (s *ReviewServiceImpl) authorizeReply(ctx context.Context, review *models.Review, userID int, isAdmin bool) (int, error) {
+	game := review.Game
+	if game == nil {
+		var err error
+		if game, err = s.gameRepo.FindByID(ctx, review.GameID); err != nil {
+			return 0, err
+		}
+	}
+
+	if isAdmin {
+		return game.DeveloperID, nil
+	}
+
+	member, err := s.developerRepo.IsMember(ctx, game.DeveloperID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if !member {
+		return 0, errors.New("only the game's developer can reply to its reviews")
+	}
+
+	return game.DeveloperID, nil
 }
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"time"
 
 	"uniStore/Backend/internal/domain/models"
@@ -20,7 +21,10 @@ func NewRoleService(roleRepo models.RoleRepository) RoleService {
 }
 
 // CreateRole creates a new role
-func (s *RoleServiceImpl) CreateRole(roleDTO *dto.RoleCreateDTO) (*dto.RoleDTO, error) {
+func (s *RoleServiceImpl) CreateRole(ctx context.Context, roleDTO *dto.RoleCreateDTO) (*dto.RoleDTO, error) {
+	ctx, span := tracer.Start(ctx, "RoleService.CreateRole")
+	defer span.End()
+
 	// Convert DTO to model
 	role := roleDTO.ToModel()
 
@@ -29,7 +33,7 @@ func (s *RoleServiceImpl) CreateRole(roleDTO *dto.RoleCreateDTO) (*dto.RoleDTO,
 	role.UpdatedAt = time.Now()
 
 	// Create role in repository
-	if err := s.roleRepo.Create(role); err != nil {
+	if err := s.roleRepo.Create(ctx, role); err != nil {
 		return nil, err
 	}
 
@@ -38,9 +42,12 @@ func (s *RoleServiceImpl) CreateRole(roleDTO *dto.RoleCreateDTO) (*dto.RoleDTO,
 }
 
 // GetRoleByID gets a role by ID
-func (s *RoleServiceImpl) GetRoleByID(id int) (*dto.RoleDTO, error) {
+func (s *RoleServiceImpl) GetRoleByID(ctx context.Context, id int) (*dto.RoleDTO, error) {
+	ctx, span := tracer.Start(ctx, "RoleService.GetRoleByID")
+	defer span.End()
+
 	// Get role from repository
-	role, err := s.roleRepo.FindByID(id)
+	role, err := s.roleRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -50,9 +57,12 @@ func (s *RoleServiceImpl) GetRoleByID(id int) (*dto.RoleDTO, error) {
 }
 
 // GetAllRoles gets all roles
-func (s *RoleServiceImpl) GetAllRoles() ([]*dto.RoleDTO, error) {
+func (s *RoleServiceImpl) GetAllRoles(ctx context.Context) ([]*dto.RoleDTO, error) {
+	ctx, span := tracer.Start(ctx, "RoleService.GetAllRoles")
+	defer span.End()
+
 	// Get roles from repository
-	roles, err := s.roleRepo.FindAll()
+	roles, err := s.roleRepo.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -62,9 +72,12 @@ func (s *RoleServiceImpl) GetAllRoles() ([]*dto.RoleDTO, error) {
 }
 
 // UpdateRole updates a role
-func (s *RoleServiceImpl) UpdateRole(id int, roleDTO *dto.RoleUpdateDTO) (*dto.RoleDTO, error) {
+func (s *RoleServiceImpl) UpdateRole(ctx context.Context, id int, roleDTO *dto.RoleUpdateDTO) (*dto.RoleDTO, error) {
+	ctx, span := tracer.Start(ctx, "RoleService.UpdateRole")
+	defer span.End()
+
 	// Get existing role
-	existingRole, err := s.roleRepo.FindByID(id)
+	existingRole, err := s.roleRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +97,7 @@ func (s *RoleServiceImpl) UpdateRole(id int, roleDTO *dto.RoleUpdateDTO) (*dto.R
 	existingRole.UpdatedAt = time.Now()
 
 	// Update role in repository
-	if err := s.roleRepo.Update(existingRole); err != nil {
+	if err := s.roleRepo.Update(ctx, existingRole); err != nil {
 		return nil, err
 	}
 
@@ -93,6 +106,9 @@ func (s *RoleServiceImpl) UpdateRole(id int, roleDTO *dto.RoleUpdateDTO) (*dto.R
 }
 
 // DeleteRole deletes a role
-func (s *RoleServiceImpl) DeleteRole(id int) error {
-	return s.roleRepo.Delete(id)
+func (s *RoleServiceImpl) DeleteRole(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "RoleService.DeleteRole")
+	defer span.End()
+
+	return s.roleRepo.Delete(ctx, id)
 }
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// TaxCalculator finds the tax charged to buyers from a region and splits amounts into net and tax
+type TaxCalculator struct {
+	taxRepo models.TaxRuleRepository
+}
+
+// NewTaxCalculator creates a tax calculator using the tax rules table
+func NewTaxCalculator(taxRepo models.TaxRuleRepository) TaxCalculator {
+	return TaxCalculator{taxRepo: taxRepo}
+}
+
+// taxAmounts is an amount charged to a buyer split into its net part and the tax on it
+type taxAmounts struct {
+	Net   int64
+	Tax   int64
+	Gross int64
+}
+
+// errNoRegion is returned when the buyer has no region, so the tax they owe is unknown
+var errNoRegion = errors.New("region is required to calculate tax")
+
+// ruleFor returns the tax rule of the region, or nil when buyers from it pay no tax.
+// Without a region no tax decision can be made, so errNoRegion is returned.
+func (c TaxCalculator) ruleFor(ctx context.Context, region string) (*models.TaxRule, error) {
+	if c.taxRepo == nil {
+		return nil, nil
+	}
+	if region == "" {
+		return nil, errNoRegion
+	}
+
+	rule, err := c.taxRepo.FindByRegion(ctx, strings.ToUpper(region))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+// calculate splits an amount under the rule. Inclusive rules take the tax out of the amount,
+// the others add it on top. Without a rule the whole amount is net.
+func (c TaxCalculator) calculate(rule *models.TaxRule, amount int64) taxAmounts {
+	if rule == nil || rule.Rate <= 0 {
+		return taxAmounts{Net: amount, Gross: amount}
+	}
+
+	if rule.Inclusive {
+		tax := models.MulDiv(amount, int64(rule.Rate), int64(10000+rule.Rate))
+		return taxAmounts{Net: amount - tax, Tax: tax, Gross: amount}
+	}
+	tax := models.MulDiv(amount, int64(rule.Rate), 10000)
+	return taxAmounts{Net: amount, Tax: tax, Gross: amount + tax}
+}
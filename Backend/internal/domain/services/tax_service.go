@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/interfaces/dto"
+)
+
+// TaxServiceImpl implements TaxService interface
+type TaxServiceImpl struct {
+	taxRepo models.TaxRuleRepository
+}
+
+// NewTaxService creates a new tax service
+func NewTaxService(taxRepo models.TaxRuleRepository) TaxService {
+	return &TaxServiceImpl{
+		taxRepo: taxRepo,
+	}
+}
+
+// GetTaxRules gets every tax rule, by region
+func (s *TaxServiceImpl) GetTaxRules(ctx context.Context) ([]*dto.TaxRuleDTO, error) {
+	ctx, span := tracer.Start(ctx, "TaxService.GetTaxRules")
+	defer span.End()
+
+	rules, err := s.taxRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.TaxRuleDTOsFromModels(rules), nil
+}
+
+// CreateTaxRule creates the tax rule of a region; a region has at most one
+func (s *TaxServiceImpl) CreateTaxRule(ctx context.Context, ruleDTO *dto.TaxRuleCreateDTO) (*dto.TaxRuleDTO, error) {
+	ctx, span := tracer.Start(ctx, "TaxService.CreateTaxRule")
+	defer span.End()
+
+	region := strings.ToUpper(strings.TrimSpace(ruleDTO.Region))
+	if region == "" {
+		return nil, errors.New("region is required")
+	}
+	if _, err := s.taxRepo.FindByRegion(ctx, region); err == nil {
+		return nil, errors.New("a tax rule already exists for this region")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	rule := &models.TaxRule{
+		Region:    region,
+		Name:      ruleDTO.Name,
+		Rate:      ruleDTO.Rate,
+		Inclusive: true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if ruleDTO.Inclusive != nil {
+		rule.Inclusive = *ruleDTO.Inclusive
+	}
+
+	if err := s.taxRepo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return dto.TaxRuleDTOFromModel(rule), nil
+}
+
+// UpdateTaxRule updates a tax rule; orders already placed keep the tax they were charged
+func (s *TaxServiceImpl) UpdateTaxRule(ctx context.Context, id int, ruleDTO *dto.TaxRuleUpdateDTO) (*dto.TaxRuleDTO, error) {
+	ctx, span := tracer.Start(ctx, "TaxService.UpdateTaxRule")
+	defer span.End()
+
+	rule, err := s.taxRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tax rule not found")
+		}
+		return nil, err
+	}
+
+	if ruleDTO.Name != "" {
+		rule.Name = ruleDTO.Name
+	}
+	if ruleDTO.Rate != nil {
+		rule.Rate = *ruleDTO.Rate
+	}
+	if ruleDTO.Inclusive != nil {
+		rule.Inclusive = *ruleDTO.Inclusive
+	}
+	rule.UpdatedAt = time.Now()
+
+	if err := s.taxRepo.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return dto.TaxRuleDTOFromModel(rule), nil
+}
+
+// DeleteTaxRule deletes a tax rule; buyers from its region then pay no tax
+func (s *TaxServiceImpl) DeleteTaxRule(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "TaxService.DeleteTaxRule")
+	defer span.End()
+
+	if _, err := s.taxRepo.FindByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("tax rule not found")
+		}
+		return err
+	}
+
+	return s.taxRepo.Delete(ctx, id)
+}
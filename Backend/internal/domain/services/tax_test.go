@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// fakeTaxRules serves tax rules from a map by region
+type fakeTaxRules map[string]*models.TaxRule
+
+func (f fakeTaxRules) Create(ctx context.Context, rule *models.TaxRule) error { return nil }
+func (f fakeTaxRules) FindByID(ctx context.Context, id int) (*models.TaxRule, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+func (f fakeTaxRules) FindAll(ctx context.Context) ([]*models.TaxRule, error) { return nil, nil }
+func (f fakeTaxRules) Update(ctx context.Context, rule *models.TaxRule) error { return nil }
+func (f fakeTaxRules) Delete(ctx context.Context, id int) error               { return nil }
+
+func (f fakeTaxRules) FindByRegion(ctx context.Context, region string) (*models.TaxRule, error) {
+	if rule, ok := f[region]; ok {
+		return rule, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+var (
+	vatExclusive = &models.TaxRule{Region: "US", Name: "Sales tax", Rate: 2000}
+	vatInclusive = &models.TaxRule{Region: "DE", Name: "VAT", Rate: 2000, Inclusive: true}
+)
+
+func TestRuleFor(t *testing.T) {
+	calculator := NewTaxCalculator(fakeTaxRules{"DE": vatInclusive})
+	tests := []struct {
+		name    string
+		region  string
+		want    *models.TaxRule
+		wantErr error
+	}{
+		{"region with a rule", "DE", vatInclusive, nil},
+		{"lower case region", "de", vatInclusive, nil},
+		{"region without a rule pays no tax", "FR", nil, nil},
+		{"no region cannot be taxed", "", nil, errNoRegion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calculator.ruleFor(context.Background(), tt.region)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ruleFor(%q) error = %v, want %v", tt.region, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ruleFor(%q) = %v, want %v", tt.region, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   *models.TaxRule
+		amount int64
+		want   taxAmounts
+	}{
+		{"no rule", nil, 999, taxAmounts{Net: 999, Gross: 999}},
+		{"zero rate", &models.TaxRule{Rate: 0}, 999, taxAmounts{Net: 999, Gross: 999}},
+		{"exclusive adds the tax", vatExclusive, 1000, taxAmounts{Net: 1000, Tax: 200, Gross: 1200}},
+		{"exclusive rounds half up", vatExclusive, 999, taxAmounts{Net: 999, Tax: 200, Gross: 1199}},
+		{"inclusive takes the tax out", vatInclusive, 1200, taxAmounts{Net: 1000, Tax: 200, Gross: 1200}},
+		{"inclusive rounds half up", vatInclusive, 999, taxAmounts{Net: 832, Tax: 167, Gross: 999}},
+		{"inclusive odd rate", &models.TaxRule{Rate: 1900, Inclusive: true}, 1999, taxAmounts{Net: 1680, Tax: 319, Gross: 1999}},
+		{"zero amount", vatExclusive, 0, taxAmounts{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (TaxCalculator{}).calculate(tt.rule, tt.amount); got != tt.want {
+				t.Errorf("calculate(%d) = %+v, want %+v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
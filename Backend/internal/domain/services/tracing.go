@@ -0,0 +1,6 @@
+package services
+
+import "go.opentelemetry.io/otel"
+
+// tracer starts a span for every service call, so a trace shows which calls a request spent its time in
+var tracer = otel.Tracer("uniStore/Backend/internal/domain/services")
@@ -1,8 +1,10 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -16,64 +18,113 @@ import (
 // UserServiceImpl implements the UserService interface
 type UserServiceImpl struct {
 	userRepo     models.UserRepository
+	roleRepo     models.RoleRepository
 	cartRepo     models.CartRepository
 	favoriteRepo models.FavoriteRepository
 	libraryRepo  models.LibraryRepository
+	pointsRepo   models.PointsRepository
+	creditRepo   models.StoreCreditRepository
 	authUtils    *utils.AuthUtils
+	metrics      BusinessMetrics
 }
 
 // NewUserService creates a new instance of UserService
 func NewUserService(
 	userRepo models.UserRepository,
+	roleRepo models.RoleRepository,
 	cartRepo models.CartRepository,
 	favoriteRepo models.FavoriteRepository,
 	libraryRepo models.LibraryRepository,
+	pointsRepo models.PointsRepository,
+	creditRepo models.StoreCreditRepository,
 	authUtils *utils.AuthUtils,
+	metrics BusinessMetrics,
 ) UserService {
 	return &UserServiceImpl{
 		userRepo:     userRepo,
+		roleRepo:     roleRepo,
 		cartRepo:     cartRepo,
 		favoriteRepo: favoriteRepo,
 		libraryRepo:  libraryRepo,
+		pointsRepo:   pointsRepo,
+		creditRepo:   creditRepo,
 		authUtils:    authUtils,
+		metrics:      metrics,
 	}
 }
 
 // Register registers a new user
-func (s *UserServiceImpl) Register(userDTO *dto.UserSignupDTO) (*dto.UserResponseDTO, error) {
+func (s *UserServiceImpl) Register(ctx context.Context, userDTO *dto.UserSignupDTO) (*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Register")
+	defer span.End()
+
+	user, err := s.createUser(ctx, userDTO.ToModel())
+	if err != nil {
+		return nil, err
+	}
+	s.metrics.UserSignedUp()
+	return user, nil
+}
+
+// CreateAdmin creates the first admin account. Once an admin exists, further admins
+// are appointed by changing a user's role.
+func (s *UserServiceImpl) CreateAdmin(ctx context.Context, userDTO *dto.UserSignupDTO) (*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateAdmin")
+	defer span.End()
+
+	adminRole, err := s.roleRepo.FindByType(ctx, "admin")
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("admin role not found")
+		}
+		return nil, err
+	}
+
+	admins, err := s.userRepo.CountByRole(ctx, adminRole.ID)
+	if err != nil {
+		return nil, err
+	}
+	if admins > 0 {
+		return nil, errors.New("admin already exists")
+	}
+
+	user := userDTO.ToModel()
+	user.RoleID = adminRole.ID
+	return s.createUser(ctx, user)
+}
+
+// createUser saves a new user with a hashed password and empty cart, favorites and library
+func (s *UserServiceImpl) createUser(ctx context.Context, user *models.User) (*dto.UserResponseDTO, error) {
 	// Check if nickname already exists
-	existingUser, err := s.userRepo.FindByNickname(userDTO.Nickname)
+	existingUser, err := s.userRepo.FindByNickname(ctx, user.Nickname)
 	if err == nil && existingUser != nil {
 		return nil, errors.New("nickname already in use")
 	}
 
 	// Check if email already exists
-	existingUser, err = s.userRepo.FindByEmail(userDTO.Email)
+	existingUser, err = s.userRepo.FindByEmail(ctx, user.Email)
 	if err == nil && existingUser != nil {
 		return nil, errors.New("email already in use")
 	}
 
-	// Convert DTO to model
-	user := userDTO.ToModel()
-
 	// Set timestamps
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
 
 	// Hash the password
-	hashedPassword, err := s.HashPassword(user.Password)
+	hashedPassword, err := s.HashPassword(ctx, user.Password)
 	if err != nil {
 		return nil, err
 	}
 	user.Password = hashedPassword
 
 	// Create user
-	if err := s.userRepo.Create(user); err != nil {
+	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
 	// Initialize related entities
-	if err := s.initializeUserData(user.ID); err != nil {
+	if err := s.initializeUserData(ctx, user.ID); err != nil {
 		return nil, err
 	}
 
@@ -82,9 +133,9 @@ func (s *UserServiceImpl) Register(userDTO *dto.UserSignupDTO) (*dto.UserRespons
 }
 
 // initializeUserData creates empty cart, favorites and library for a new user
-func (s *UserServiceImpl) initializeUserData(userID int) error {
+func (s *UserServiceImpl) initializeUserData(ctx context.Context, userID int) error {
 	// Create empty shopping cart
-	if err := s.cartRepo.Create(&models.ShoppingCart{
+	if err := s.cartRepo.Create(ctx, &models.ShoppingCart{
 		UserID:    userID,
 		CartItems: []*models.CartItem{},
 	}); err != nil {
@@ -92,7 +143,7 @@ func (s *UserServiceImpl) initializeUserData(userID int) error {
 	}
 
 	// Create empty favorites
-	if err := s.favoriteRepo.Create(&models.Favorite{
+	if err := s.favoriteRepo.Create(ctx, &models.Favorite{
 		UserID:        userID,
 		FavoriteItems: []*models.FavoriteItem{},
 	}); err != nil {
@@ -100,7 +151,7 @@ func (s *UserServiceImpl) initializeUserData(userID int) error {
 	}
 
 	// Create empty library
-	if err := s.libraryRepo.Create(&models.Library{
+	if err := s.libraryRepo.Create(ctx, &models.Library{
 		UserID:       userID,
 		LibraryItems: []*models.LibraryItem{},
 	}); err != nil {
@@ -111,18 +162,23 @@ func (s *UserServiceImpl) initializeUserData(userID int) error {
 }
 
 // Login authenticates a user
-func (s *UserServiceImpl) Login(loginDTO *dto.UserLoginDTO) (*dto.AuthResponseDTO, error) {
+func (s *UserServiceImpl) Login(ctx context.Context, loginDTO *dto.UserLoginDTO) (*dto.AuthResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Login")
+	defer span.End()
+
 	// Find user by email
-	user, err := s.userRepo.FindByEmail(loginDTO.Email)
+	user, err := s.userRepo.FindByEmail(ctx, loginDTO.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.metrics.LoginAttempted(false)
 			return nil, errors.New("email or password is incorrect")
 		}
 		return nil, err
 	}
 
 	// Verify password
-	if !s.VerifyPassword(loginDTO.Password, user.Password) {
+	if !s.VerifyPassword(ctx, loginDTO.Password, user.Password) {
+		s.metrics.LoginAttempted(false)
 		return nil, errors.New("email or password is incorrect")
 	}
 
@@ -132,7 +188,7 @@ func (s *UserServiceImpl) Login(loginDTO *dto.UserLoginDTO) (*dto.AuthResponseDT
 		roleType = user.Role.Type
 	} else {
 		// Get role from database if it wasn't loaded
-		role, err := s.userRepo.FindByID(user.ID)
+		role, err := s.userRepo.FindByID(ctx, user.ID)
 		if err == nil && role != nil && role.Role != nil {
 			roleType = role.Role.Type
 		} else {
@@ -142,7 +198,7 @@ func (s *UserServiceImpl) Login(loginDTO *dto.UserLoginDTO) (*dto.AuthResponseDT
 	}
 
 	// Generate tokens
-	token, refreshToken, err := s.GenerateTokens(user.Email, user.Nickname, roleType, user.ID)
+	token, refreshToken, err := s.GenerateTokens(ctx, user.Email, user.Nickname, roleType, user.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -151,20 +207,25 @@ func (s *UserServiceImpl) Login(loginDTO *dto.UserLoginDTO) (*dto.AuthResponseDT
 	user.Token = token
 	user.RefreshToken = refreshToken
 	user.UpdatedAt = time.Now()
-	err = s.userRepo.Update(user)
+	err = s.userRepo.Update(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
+	s.metrics.LoginAttempted(true)
+
 	// Create response DTO
 	authResponseDTO := dto.AuthResponseDTOFromModel(user)
 	return authResponseDTO, nil
 }
 
 // GetUserByID gets a user by ID
-func (s *UserServiceImpl) GetUserByID(id int) (*dto.UserResponseDTO, error) {
+func (s *UserServiceImpl) GetUserByID(ctx context.Context, id int) (*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUserByID")
+	defer span.End()
+
 	// Get user from repository
-	user, err := s.userRepo.FindByID(id)
+	user, err := s.userRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -174,9 +235,12 @@ func (s *UserServiceImpl) GetUserByID(id int) (*dto.UserResponseDTO, error) {
 }
 
 // GetAllUsers gets all users with pagination
-func (s *UserServiceImpl) GetAllUsers(limit, offset int) ([]*dto.UserResponseDTO, error) {
+func (s *UserServiceImpl) GetAllUsers(ctx context.Context, limit, offset int) ([]*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetAllUsers")
+	defer span.End()
+
 	// Get users from repository
-	users, err := s.userRepo.FindAll(limit, offset)
+	users, err := s.userRepo.FindAll(ctx, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -190,10 +254,20 @@ func (s *UserServiceImpl) GetAllUsers(limit, offset int) ([]*dto.UserResponseDTO
 	return userDTOs, nil
 }
 
-// UpdateUser updates a user
-func (s *UserServiceImpl) UpdateUser(id int, userDTO *dto.UserUpdateDTO) (*dto.UserResponseDTO, error) {
+// errRegionLocked is returned when a user other than an admin changes a region already set
+var errRegionLocked = errors.New("only an admin can change your region")
+
+// errCurrencyLocked is returned when a user other than an admin changes a currency already set
+var errCurrencyLocked = errors.New("only an admin can change your currency")
+
+// UpdateUser updates a user. The region decides the tax charged and the currency the prices
+// paid, so only an admin can change either once it is set.
+func (s *UserServiceImpl) UpdateUser(ctx context.Context, id int, isAdmin bool, userDTO *dto.UserUpdateDTO) (*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateUser")
+	defer span.End()
+
 	// Get existing user
-	existingUser, err := s.userRepo.FindByID(id)
+	existingUser, err := s.userRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +283,7 @@ func (s *UserServiceImpl) UpdateUser(id int, userDTO *dto.UserUpdateDTO) (*dto.U
 		existingUser.Email = updateData.Email
 	}
 	if updateData.Password != "" {
-		hashedPassword, err := s.HashPassword(updateData.Password)
+		hashedPassword, err := s.HashPassword(ctx, updateData.Password)
 		if err != nil {
 			return nil, err
 		}
@@ -218,11 +292,28 @@ func (s *UserServiceImpl) UpdateUser(id int, userDTO *dto.UserUpdateDTO) (*dto.U
 	if updateData.RoleID != 0 {
 		existingUser.RoleID = updateData.RoleID
 	}
+	if updateData.Region != "" {
+		region := strings.ToUpper(updateData.Region)
+		if existingUser.Region != "" && existingUser.Region != region && !isAdmin {
+			return nil, errRegionLocked
+		}
+		existingUser.Region = region
+	}
+	if updateData.Currency != "" {
+		currency := models.NormalizeCurrency(updateData.Currency)
+		if !models.IsCurrency(currency) {
+			return nil, errors.New("unsupported currency")
+		}
+		if existingUser.Currency != "" && existingUser.Currency != currency && !isAdmin {
+			return nil, errCurrencyLocked
+		}
+		existingUser.Currency = currency
+	}
 
 	existingUser.UpdatedAt = time.Now()
 
 	// Update user in repository
-	if err := s.userRepo.Update(existingUser); err != nil {
+	if err := s.userRepo.Update(ctx, existingUser); err != nil {
 		return nil, err
 	}
 
@@ -230,20 +321,23 @@ func (s *UserServiceImpl) UpdateUser(id int, userDTO *dto.UserUpdateDTO) (*dto.U
 	return dto.UserResponseDTOFromModel(existingUser), nil
 }
 
-// AddPoints adds points to a user's account
-func (s *UserServiceImpl) AddPoints(userID int, points int) (*dto.UserResponseDTO, error) {
-	// Get user from repository
-	user, err := s.userRepo.FindByID(userID)
-	if err != nil {
+// AddPoints credits (or, when negative, debits) points to a user's account as a manual adjustment
+func (s *UserServiceImpl) AddPoints(ctx context.Context, userID int, points int) (*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.AddPoints")
+	defer span.End()
+
+	entry := &models.PointsLedger{
+		UserID: userID,
+		Amount: points,
+		Reason: models.PointsReasonAdjustment,
+	}
+	if err := s.pointsRepo.Record(ctx, entry); err != nil {
 		return nil, err
 	}
 
-	// Update points
-	user.Points += points
-	user.UpdatedAt = time.Now()
-
-	// Update user in repository
-	if err := s.userRepo.Update(user); err != nil {
+	// Get the user with the updated balance
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -251,23 +345,65 @@ func (s *UserServiceImpl) AddPoints(userID int, points int) (*dto.UserResponseDT
 	return dto.UserResponseDTOFromModel(user), nil
 }
 
+// GetPointsHistory gets a user's loyalty points ledger, newest first
+func (s *UserServiceImpl) GetPointsHistory(ctx context.Context, userID, limit, offset int) ([]*dto.PointsLedgerDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetPointsHistory")
+	defer span.End()
+
+	entries, err := s.pointsRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PointsLedgerDTOsFromModels(entries), nil
+}
+
+// GetStoreCredit gets a user's store credit balances and ledger, newest entries first
+func (s *UserServiceImpl) GetStoreCredit(ctx context.Context, userID, limit, offset int) (*dto.StoreCreditDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetStoreCredit")
+	defer span.End()
+
+	balances, err := s.creditRepo.FindBalances(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.creditRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.StoreCreditDTOFromModels(balances, entries), nil
+}
+
 // VerifyPassword verifies a password against a hash
-func (s *UserServiceImpl) VerifyPassword(password, hashedPassword string) bool {
+func (s *UserServiceImpl) VerifyPassword(ctx context.Context, password, hashedPassword string) bool {
+	ctx, span := tracer.Start(ctx, "UserService.VerifyPassword")
+	defer span.End()
+
 	return utils.CheckPassword(password, hashedPassword)
 }
 
 // HashPassword hashes a password
-func (s *UserServiceImpl) HashPassword(password string) (string, error) {
+func (s *UserServiceImpl) HashPassword(ctx context.Context, password string) (string, error) {
+	ctx, span := tracer.Start(ctx, "UserService.HashPassword")
+	defer span.End()
+
 	return utils.HashPassword(password)
 }
 
 // GenerateTokens generates JWT tokens
-func (s *UserServiceImpl) GenerateTokens(email, nickname, role string, id int) (string, string, error) {
+func (s *UserServiceImpl) GenerateTokens(ctx context.Context, email, nickname, role string, id int) (string, string, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GenerateTokens")
+	defer span.End()
+
 	return s.authUtils.GenerateToken(email, nickname, role, id)
 }
 
 // GetUserIDFromToken extracts user ID from token
-func (s *UserServiceImpl) GetUserIDFromToken(tokenString string) (int, error) {
+func (s *UserServiceImpl) GetUserIDFromToken(ctx context.Context, tokenString string) (int, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetUserIDFromToken")
+	defer span.End()
+
 	// Parse the token
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// Validate the algorithm is what we expect
@@ -296,7 +432,10 @@ func (s *UserServiceImpl) GetUserIDFromToken(tokenString string) (int, error) {
 }
 
 // RefreshToken refreshes a user's token
-func (s *UserServiceImpl) RefreshToken(refreshToken string) (*dto.AuthResponseDTO, error) {
+func (s *UserServiceImpl) RefreshToken(ctx context.Context, refreshToken string) (*dto.AuthResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserService.RefreshToken")
+	defer span.End()
+
 	// Refresh token
 	token, newRefreshToken, err := s.authUtils.RefreshToken(refreshToken)
 	if err != nil {
@@ -304,13 +443,13 @@ func (s *UserServiceImpl) RefreshToken(refreshToken string) (*dto.AuthResponseDT
 	}
 
 	// Get user ID from token
-	userID, err := s.GetUserIDFromToken(token)
+	userID, err := s.GetUserIDFromToken(ctx, token)
 	if err != nil {
 		return nil, err
 	}
 
 	// Update tokens in database
-	user, err := s.userRepo.FindByID(userID)
+	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -319,7 +458,7 @@ func (s *UserServiceImpl) RefreshToken(refreshToken string) (*dto.AuthResponseDT
 	user.RefreshToken = newRefreshToken
 	user.UpdatedAt = time.Now()
 
-	if err := s.userRepo.Update(user); err != nil {
+	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
 
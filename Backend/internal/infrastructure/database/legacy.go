@@ -0,0 +1,114 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/infrastructure/database/legacy"
+)
+
+// baselineVersion is the migration that creates the schema older versions built with AutoMigrate
+const baselineVersion = 1
+
+// legacyModelGroups lists the frozen models of the baseline schema in dependency order.
+// It must not change: later schema changes belong in new migrations, written so they also
+// apply on top of an upgraded legacy database.
+var legacyModelGroups = [][]interface{}{
+	// Base models
+	{&legacy.Role{}, &legacy.Developer{}, &legacy.Category{}, &legacy.Coupon{}, &legacy.TaxRule{}},
+	// Models with dependencies
+	{&legacy.User{}, &legacy.Game{}},
+	// Relationship models
+	{&legacy.ShoppingCart{}, &legacy.Favorite{}, &legacy.Library{}, &legacy.Order{}, &legacy.Restrict{}, &legacy.Review{}, &legacy.RedeemCode{}, &legacy.Bundle{}, &legacy.GuestCart{}, &legacy.Notification{}, &legacy.NotificationPreference{}},
+	// Join tables
+	{&legacy.CartItem{}, &legacy.FavoriteItem{}, &legacy.LibraryItem{}, &legacy.OrderItem{}, &legacy.ReviewReport{}, &legacy.ReviewVote{}, &legacy.ReviewReply{}, &legacy.DeveloperMember{}, &legacy.PointsLedger{}, &legacy.Gift{}, &legacy.RedeemCodeUse{}, &legacy.CouponRedemption{}, &legacy.GuestCartItem{}, &legacy.GamePrice{}, &legacy.Invoice{}, &legacy.InvoiceLine{}},
+}
+
+// upgradeLegacySchema brings a database created by AutoMigrate, before versioned
+// migrations, up to the baseline schema and converts the data older versions stored
+func (d *Database) upgradeLegacySchema() error {
+	// Set migration settings
+	migrator := d.DB.Session(&gorm.Session{
+		SkipDefaultTransaction: true,
+		AllowGlobalUpdate:      true,
+	})
+
+	// Convert amounts stored by older versions before the schema changes
+	if err := d.migrateMoney(); err != nil {
+		return err
+	}
+
+	// Older versions allowed several reviews of a game by the same user, which the unique
+	// index of the baseline does not
+	if err := d.dedupeReviews(); err != nil {
+		return err
+	}
+
+	// Columns added by the schema migration that need filling from existing data
+	fillVerified := d.DB.Migrator().HasTable(&legacy.Review{}) && !d.DB.Migrator().HasColumn(&legacy.Review{}, "VerifiedPurchase")
+	fillReleased := d.DB.Migrator().HasTable(&legacy.FavoriteItem{}) && !d.DB.Migrator().HasColumn(&legacy.FavoriteItem{}, "ReleaseNotified")
+
+	for i, group := range legacyModelGroups {
+		for _, model := range group {
+			if err := migrator.AutoMigrate(model); err != nil {
+				return fmt.Errorf("failed to migrate group %d: %w", i, err)
+			}
+		}
+	}
+
+	if fillVerified {
+		if err := d.fillVerifiedPurchases(); err != nil {
+			return err
+		}
+	}
+	if fillReleased {
+		if err := d.fillReleaseNotified(); err != nil {
+			return err
+		}
+	}
+	if err := d.fillPriceCurrencies(); err != nil {
+		return err
+	}
+	return d.fillOrderTotals()
+}
+
+// dedupeReviews soft-deletes all but the newest live review of each game by each user
+func (d *Database) dedupeReviews() error {
+	if !d.DB.Migrator().HasTable(&legacy.Review{}) {
+		return nil
+	}
+	if err := d.DB.Exec(`UPDATE review SET deleted_at = now()
+		WHERE deleted_at IS NULL AND id NOT IN (
+			SELECT DISTINCT ON (game_id, user_id) id
+			FROM review
+			WHERE deleted_at IS NULL
+			ORDER BY game_id, user_id, created_at DESC, id DESC
+		)`).Error; err != nil {
+		return fmt.Errorf("failed to remove duplicate reviews: %w", err)
+	}
+	return nil
+}
+
+// fillVerifiedPurchases flags the reviews written before the flag existed by users owning the game
+func (d *Database) fillVerifiedPurchases() error {
+	if err := d.DB.Exec(`UPDATE review SET verified_purchase = true
+		WHERE EXISTS (
+			SELECT 1 FROM library_item
+			JOIN library ON library.id = library_item.library_id
+			WHERE library.user_id = review.user_id AND library_item.game_id = review.game_id
+		)`).Error; err != nil {
+		return fmt.Errorf("failed to fill verified purchases: %w", err)
+	}
+	return nil
+}
+
+// fillReleaseNotified marks the wishlist entries of games already out when release
+// notifications were introduced, so their release is not announced to everyone at once
+func (d *Database) fillReleaseNotified() error {
+	if err := d.DB.Exec(`UPDATE favorite_item SET release_notified = true
+		WHERE game_id IN (SELECT id FROM game WHERE release_date <= now())`).Error; err != nil {
+		return fmt.Errorf("failed to fill wishlist release notifications: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,577 @@
+// Package legacy holds a frozen copy of the models as they were when versioned migrations
+// replaced AutoMigrate. Databases created before then are brought up to the baseline
+// migration from these copies, so later changes to the live models cannot change what
+// the upgrade builds. They must never change; schema changes belong in new migrations.
+package legacy
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Bundle represents several games sold together as one product
+type Bundle struct {
+	ID          int    `gorm:"primaryKey"`
+	Name        string `gorm:"not null" validate:"required"`
+	Description string `gorm:"type:text"`
+	Price       int64  `gorm:"not null" validate:"gte=0"`                    // Price of the whole bundle for a buyer owning none of its games, in minor units
+	Currency    string `gorm:"type:varchar(3);not null" validate:"required"` // Currency of the price; other currencies keep the same saving on the games' regional prices
+	Active      bool   `gorm:"not null;default:true"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	Games []*Game `gorm:"many2many:bundle_game"`
+}
+
+// ShoppingCart represents a user's shopping cart
+type ShoppingCart struct {
+	ID        int     `gorm:"primaryKey"`
+	UserID    int     `gorm:"not null;uniqueIndex" validate:"required"`
+	User      *User   `gorm:"foreignKey:UserID"`
+	CouponID  *int    // Coupon applied to the cart
+	Coupon    *Coupon `gorm:"foreignKey:CouponID"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	CartItems []*CartItem
+}
+
+// CartItem represents an item in a shopping cart
+type CartItem struct {
+	ID              int           `gorm:"primaryKey"`
+	ShoppingCartID  int           `gorm:"not null" validate:"required"`
+	ShoppingCart    *ShoppingCart `gorm:"foreignKey:ShoppingCartID"`
+	GameID          *int          // Set for a single game
+	Game            *Game         `gorm:"foreignKey:GameID"`
+	BundleID        *int          // Or for a bundle of games
+	Bundle          *Bundle       `gorm:"foreignKey:BundleID"`
+	Quantity        int           `gorm:"not null;default:1" validate:"required,min=1"`
+	AddedPrice      *int64        // Unit price in minor units when the item was added; nil for items added before prices were recorded
+	AddedCurrency   string        `gorm:"type:varchar(3)"` // Currency of AddedPrice
+	GiftRecipientID *int          // Set when the item is bought for another user
+	GiftRecipient   *User         `gorm:"foreignKey:GiftRecipientID"`
+	GiftMessage     string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+// Favorite represents a user's favorite games list
+type Favorite struct {
+	ID        int   `gorm:"primaryKey"`
+	UserID    int   `gorm:"not null;uniqueIndex" validate:"required"`
+	User      *User `gorm:"foreignKey:UserID"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	FavoriteItems []*FavoriteItem
+}
+
+// FavoriteItem represents a game in a user's favorite list
+type FavoriteItem struct {
+	ID         int       `gorm:"primaryKey"`
+	FavoriteID int       `gorm:"not null" validate:"required"`
+	Favorite   *Favorite `gorm:"foreignKey:FavoriteID"`
+	GameID     int       `gorm:"not null" validate:"required"`
+	Game       *Game     `gorm:"foreignKey:GameID"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+
+	// Wishlist watch state, so each price drop and release is notified once
+	WatchedPrice    *int64 // Sale price in minor units when last checked
+	WatchedCurrency string `gorm:"type:varchar(3)"` // Currency of WatchedPrice, the owner's currency at the time
+	ReleaseNotified bool   `gorm:"not null;default:false"`
+}
+
+// Library represents a user's game library
+type Library struct {
+	ID        int   `gorm:"primaryKey"`
+	UserID    int   `gorm:"not null;uniqueIndex" validate:"required"`
+	User      *User `gorm:"foreignKey:UserID"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	LibraryItems []*LibraryItem
+}
+
+// LibraryItem represents a game in a user's library
+type LibraryItem struct {
+	ID        int      `gorm:"primaryKey"`
+	LibraryID int      `gorm:"not null" validate:"required"`
+	Library   *Library `gorm:"foreignKey:LibraryID"`
+	GameID    int      `gorm:"not null" validate:"required"`
+	Game      *Game    `gorm:"foreignKey:GameID"`
+	Locked    bool     `gorm:"not null;default:false"` // Pre-ordered game awaiting its release
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// Coupon represents a promo code giving a discount at checkout
+type Coupon struct {
+	ID             int    `gorm:"primaryKey"`
+	Code           string `gorm:"not null;uniqueIndex" validate:"required"`
+	Type           string `gorm:"not null" validate:"required,oneof=percent fixed"`
+	Value          int64  `gorm:"not null" validate:"required,gt=0"`            // Percentage, or fixed amount off in minor units of Currency
+	MinTotal       int64  `gorm:"not null;default:0"`                           // Minimum cart subtotal in minor units of Currency
+	Currency       string `gorm:"type:varchar(3);not null" validate:"required"` // Currency of a fixed amount and the minimum subtotal
+	MaxUses        int    `gorm:"not null;default:0"`                           // Total uses allowed; 0 means unlimited
+	MaxUsesPerUser int    `gorm:"not null;default:1"`                           // Uses allowed per user; 0 means unlimited
+	Uses           int    `gorm:"not null;default:0"`
+	StartsAt       *time.Time
+	EndsAt         *time.Time
+	Active         bool `gorm:"not null;default:true"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+
+	// Restrictions; the coupon applies to every game when both are empty
+	Categories []*Category  `gorm:"many2many:coupon_category"`
+	Developers []*Developer `gorm:"many2many:coupon_developer"`
+}
+
+// CouponRedemption records a coupon used on an order
+type CouponRedemption struct {
+	ID        int     `gorm:"primaryKey"`
+	CouponID  int     `gorm:"not null;index:idx_coupon_redemption_coupon_user" validate:"required"`
+	Coupon    *Coupon `gorm:"foreignKey:CouponID"`
+	UserID    int     `gorm:"not null;index:idx_coupon_redemption_coupon_user" validate:"required"`
+	User      *User   `gorm:"foreignKey:UserID"`
+	OrderID   int     `gorm:"not null;uniqueIndex" validate:"required"`
+	Order     *Order  `gorm:"foreignKey:OrderID"`
+	Discount  int64   `gorm:"not null"` // In minor units of the order currency
+	CreatedAt time.Time
+}
+
+// Game represents a video game in the system
+type Game struct {
+	ID          int    `gorm:"primaryKey"`
+	Title       string `gorm:"type:varchar(255);not null" validate:"required"`
+	Description string
+	Price       int64  `gorm:"not null" validate:"gte=0"`                    // Base price in minor units of Currency
+	Currency    string `gorm:"type:varchar(3);not null" validate:"required"` // Currency of the base price
+	Discount    int    `gorm:"not null;default:0" validate:"gte=0,lte=100"`  // Percent off every price while the game is on sale
+	ReleaseDate time.Time
+	DeveloperID int        `gorm:"not null" validate:"required"`
+	Developer   *Developer `gorm:"foreignKey:DeveloperID"`
+	CategoryID  int        `gorm:"not null" validate:"required"`
+	Category    *Category  `gorm:"foreignKey:CategoryID"`
+	ImageData   []byte     `gorm:"type:bytea"`
+	ImageName   string     `gorm:"type:varchar(255)"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	CartItems     []*CartItem
+	FavoriteItems []*FavoriteItem
+	LibraryItems  []*LibraryItem
+	OrderItems    []*OrderItem
+	Reviews       []*Review
+	Restricts     []*Restrict
+	Prices        []*GamePrice // Regional prices in other currencies
+}
+
+// GamePrice is a game's price in a currency other than its base currency
+type GamePrice struct {
+	ID        int    `gorm:"primaryKey"`
+	GameID    int    `gorm:"not null;uniqueIndex:idx_game_price_currency" validate:"required"`
+	Game      *Game  `gorm:"foreignKey:GameID"`
+	Currency  string `gorm:"type:varchar(3);not null;uniqueIndex:idx_game_price_currency" validate:"required"`
+	Price     int64  `gorm:"not null" validate:"gte=0"` // In minor units of Currency
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Developer represents a game developer
+type Developer struct {
+	ID          int    `gorm:"primaryKey"`
+	Name        string `gorm:"not null;unique" validate:"required"`
+	Country     string
+	Description string
+	WebsiteURL  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	Games   []*Game
+	Members []*DeveloperMember
+}
+
+// DeveloperMember links a user account to a developer.
+// Members may act on behalf of the developer, e.g. reply to reviews of its games.
+type DeveloperMember struct {
+	ID          int        `gorm:"primaryKey"`
+	DeveloperID int        `gorm:"not null;uniqueIndex:idx_developer_member,where:deleted_at IS NULL" validate:"required"`
+	Developer   *Developer `gorm:"foreignKey:DeveloperID"`
+	UserID      int        `gorm:"not null;uniqueIndex:idx_developer_member,where:deleted_at IS NULL;index" validate:"required"`
+	User        *User      `gorm:"foreignKey:UserID"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+// Category represents a game category/genre
+type Category struct {
+	ID          int    `gorm:"primaryKey"`
+	Name        string `gorm:"not null;unique" validate:"required"`
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	Games []*Game
+}
+
+// Restrict represents regional restrictions for games
+type Restrict struct {
+	ID        int    `gorm:"primaryKey"`
+	GameID    int    `gorm:"not null" validate:"required"`
+	Game      *Game  `gorm:"foreignKey:GameID"`
+	Region    string `gorm:"not null" validate:"required"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// Review represents a user review for a game.
+// A user can have at most one live review per game.
+type Review struct {
+	ID               int    `gorm:"primaryKey"`
+	GameID           int    `gorm:"not null;uniqueIndex:idx_review_game_user,where:deleted_at IS NULL;index:idx_review_listing,priority:1" validate:"required"`
+	Game             *Game  `gorm:"foreignKey:GameID"`
+	UserID           int    `gorm:"not null;uniqueIndex:idx_review_game_user,where:deleted_at IS NULL" validate:"required"`
+	User             *User  `gorm:"foreignKey:UserID"`
+	Title            string `gorm:"not null" validate:"required"`
+	Description      string
+	Rating           int    `gorm:"not null" validate:"required,min=1,max=5"`
+	VerifiedPurchase bool   `gorm:"not null;default:false"` // Author owned the game when the review was written
+	Status           string `gorm:"not null;default:'visible';index;index:idx_review_listing,priority:2"`
+	HelpfulCount     int    `gorm:"not null;default:0"`
+	UnhelpfulCount   int    `gorm:"not null;default:0"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	Reports []*ReviewReport
+	Reply   *ReviewReply
+}
+
+// ReviewReply represents a developer's public response to a review.
+// A review has at most one reply.
+type ReviewReply struct {
+	ID          int        `gorm:"primaryKey"`
+	ReviewID    int        `gorm:"not null;uniqueIndex:idx_review_reply_review,where:deleted_at IS NULL" validate:"required"`
+	Review      *Review    `gorm:"foreignKey:ReviewID"`
+	DeveloperID int        `gorm:"not null" validate:"required"`
+	Developer   *Developer `gorm:"foreignKey:DeveloperID"`
+	UserID      int        `gorm:"not null" validate:"required"` // Account that wrote the reply
+	User        *User      `gorm:"foreignKey:UserID"`
+	Body        string     `gorm:"type:text;not null" validate:"required"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+// ReviewVote represents a user's helpfulness vote on a review.
+// A user can vote on a review only once, but may change the vote.
+type ReviewVote struct {
+	ID        int     `gorm:"primaryKey"`
+	ReviewID  int     `gorm:"not null;uniqueIndex:idx_review_vote_review_user,where:deleted_at IS NULL" validate:"required"`
+	Review    *Review `gorm:"foreignKey:ReviewID"`
+	UserID    int     `gorm:"not null;uniqueIndex:idx_review_vote_review_user,where:deleted_at IS NULL" validate:"required"`
+	User      *User   `gorm:"foreignKey:UserID"`
+	Helpful   bool    `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// ReviewReport represents a user's complaint about a review.
+// A user can report a review only once.
+type ReviewReport struct {
+	ID        int     `gorm:"primaryKey"`
+	ReviewID  int     `gorm:"not null;uniqueIndex:idx_review_report_review_user,where:deleted_at IS NULL" validate:"required"`
+	Review    *Review `gorm:"foreignKey:ReviewID"`
+	UserID    int     `gorm:"not null;uniqueIndex:idx_review_report_review_user,where:deleted_at IS NULL" validate:"required"`
+	User      *User   `gorm:"foreignKey:UserID"`
+	Reason    string  `gorm:"not null" validate:"required"`
+	Resolved  bool    `gorm:"not null;default:false;index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// GuestCart represents the shopping cart of a visitor who is not logged in.
+// It is stored in the database so that any backend instance can serve it.
+type GuestCart struct {
+	ID        int    `gorm:"primaryKey"`
+	Token     string `gorm:"not null;uniqueIndex" validate:"required"` // Random identifier handed to the visitor
+	CreatedAt time.Time
+	UpdatedAt time.Time `gorm:"index"`
+
+	// Relations
+	Items []*GuestCartItem
+}
+
+// GuestCartItem represents a game in a guest cart
+type GuestCartItem struct {
+	ID          int        `gorm:"primaryKey"`
+	GuestCartID int        `gorm:"not null;uniqueIndex:idx_guest_cart_item" validate:"required"`
+	GuestCart   *GuestCart `gorm:"foreignKey:GuestCartID"`
+	GameID      int        `gorm:"not null;uniqueIndex:idx_guest_cart_item" validate:"required"`
+	Game        *Game      `gorm:"foreignKey:GameID"`
+	CreatedAt   time.Time
+}
+
+// Invoice is the receipt of a paid order. It copies everything it shows from the order and
+// the buyer when issued, so later changes to either never alter it.
+type Invoice struct {
+	ID             int    `gorm:"primaryKey"`
+	Sequence       int    `gorm:"not null;uniqueIndex" validate:"required"` // Gapless, in issue order
+	Number         string `gorm:"not null;uniqueIndex" validate:"required"`
+	OrderID        int    `gorm:"not null;uniqueIndex" validate:"required"`
+	Order          *Order `gorm:"foreignKey:OrderID"`
+	UserID         int    `gorm:"not null;index" validate:"required"`
+	BuyerName      string `gorm:"not null"`
+	BuyerEmail     string `gorm:"not null"`
+	BuyerRegion    string
+	Currency       string `gorm:"type:varchar(3);not null" validate:"required"`
+	Subtotal       int64  `gorm:"not null"` // Sum of the lines before discounts
+	CouponDiscount int64  `gorm:"not null"`
+	TaxName        string // Empty when no tax was charged
+	TaxRate        int    `gorm:"not null"` // In basis points
+	TaxInclusive   bool   `gorm:"not null"`
+	NetTotal       int64  `gorm:"not null"`
+	TaxTotal       int64  `gorm:"not null"`
+	GrossTotal     int64  `gorm:"not null"`
+	PointsDiscount int64  `gorm:"not null"` // Paid with loyalty points
+	TotalPaid      int64  `gorm:"not null"`
+	OrderedAt      time.Time
+	IssuedAt       time.Time `gorm:"not null"`
+
+	// Relations
+	Lines []*InvoiceLine
+}
+
+// InvoiceLine is a line of an invoice
+type InvoiceLine struct {
+	ID          int    `gorm:"primaryKey"`
+	InvoiceID   int    `gorm:"not null;index" validate:"required"`
+	Description string `gorm:"not null"`
+	Quantity    int    `gorm:"not null"`
+	UnitPrice   int64  `gorm:"not null"`
+	NetAmount   int64  `gorm:"not null"` // After the line's share of the coupon
+	TaxAmount   int64  `gorm:"not null"`
+	GrossAmount int64  `gorm:"not null"`
+}
+
+// Notification represents an in-app notification for a user
+type Notification struct {
+	ID        int    `gorm:"primaryKey"`
+	UserID    int    `gorm:"not null;index" validate:"required"`
+	User      *User  `gorm:"foreignKey:UserID"`
+	GameID    *int   // Game the notification is about, if any
+	Game      *Game  `gorm:"foreignKey:GameID"`
+	Type      string `gorm:"not null" validate:"required"`
+	Title     string `gorm:"not null" validate:"required"`
+	Message   string `gorm:"type:text"`
+	ReadAt    *time.Time
+	EmailedAt *time.Time // Set once the notification was also sent by email
+	CreatedAt time.Time
+}
+
+// NotificationPreference holds which notifications a user wants and how.
+// Users without a stored preference get the defaults of the notification repository.
+type NotificationPreference struct {
+	ID         int   `gorm:"primaryKey"`
+	UserID     int   `gorm:"not null;uniqueIndex" validate:"required"`
+	User       *User `gorm:"foreignKey:UserID"`
+	PriceDrops bool  `gorm:"not null"` // Price drops and sales of wishlisted games
+	Releases   bool  `gorm:"not null"` // Releases of wishlisted games
+	Email      bool  `gorm:"not null"` // Also send notifications by email
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Order represents a user's order
+type Order struct {
+	ID             int     `gorm:"primaryKey"`
+	UserID         int     `gorm:"not null" validate:"required"`
+	User           *User   `gorm:"foreignKey:UserID"`
+	Currency       string  `gorm:"type:varchar(3);not null" validate:"required"` // Currency of every amount of the order
+	Subtotal       int64   `gorm:"not null;default:0" validate:"gte=0"`          // Sum of the items before discounts
+	CouponID       *int    // Coupon used on the order
+	Coupon         *Coupon `gorm:"foreignKey:CouponID"`
+	CouponDiscount int64   `gorm:"not null;default:0" validate:"gte=0"` // Amount taken off by the coupon
+	TaxRegion      string  // Region whose tax was charged; empty when none was
+	TaxName        string
+	TaxRate        int    `gorm:"not null;default:0"` // In basis points
+	TaxInclusive   bool   `gorm:"not null;default:false"`
+	NetTotal       int64  `gorm:"not null;default:0" validate:"gte=0"` // Discounted items before tax
+	TaxTotal       int64  `gorm:"not null;default:0" validate:"gte=0"`
+	GrossTotal     int64  `gorm:"not null;default:0" validate:"gte=0"` // Discounted items with tax, before points
+	PointsRedeemed int    `gorm:"not null;default:0" validate:"gte=0"` // Loyalty points spent on the order
+	PointsDiscount int64  `gorm:"not null;default:0" validate:"gte=0"` // Value of the redeemed points
+	PointsEarned   int    `gorm:"not null;default:0" validate:"gte=0"` // Loyalty points credited once paid
+	TotalCost      int64  `gorm:"not null" validate:"gte=0"`           // Amount to pay
+	RefundedAmount int64  `gorm:"not null;default:0" validate:"gte=0"` // Amount paid back to the user
+	Status         string `gorm:"not null;default:'pending'"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	OrderItems []*OrderItem
+}
+
+// OrderItem represents an item in an order
+type OrderItem struct {
+	ID              int     `gorm:"primaryKey"`
+	OrderID         int     `gorm:"not null" validate:"required"`
+	Order           *Order  `gorm:"foreignKey:OrderID"`
+	GameID          *int    // Set for a single game
+	Game            *Game   `gorm:"foreignKey:GameID"`
+	BundleID        *int    // Or for a bundle, granting each of its games
+	Bundle          *Bundle `gorm:"foreignKey:BundleID"`
+	Price           int64   `gorm:"not null" validate:"gte=0"` // Unit price in minor units of the order currency
+	Quantity        int     `gorm:"not null;default:1" validate:"required,min=1"`
+	NetAmount       int64   `gorm:"not null;default:0" validate:"gte=0"` // Line total after its share of the coupon, before tax
+	TaxAmount       int64   `gorm:"not null;default:0" validate:"gte=0"`
+	GrossAmount     int64   `gorm:"not null;default:0" validate:"gte=0"` // Line total after its share of the coupon, with tax
+	PreOrder        bool    `gorm:"not null;default:false"`              // Game was not released yet when ordered
+	GiftRecipientID *int    // Set when the item is bought for another user
+	GiftRecipient   *User   `gorm:"foreignKey:GiftRecipientID"`
+	GiftMessage     string
+	RefundedAt      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+// Gift represents a game bought by one user for another, awaiting the recipient's answer
+type Gift struct {
+	ID          int        `gorm:"primaryKey"`
+	OrderItemID int        `gorm:"not null;uniqueIndex" validate:"required"`
+	OrderItem   *OrderItem `gorm:"foreignKey:OrderItemID"`
+	SenderID    int        `gorm:"not null;index" validate:"required"`
+	Sender      *User      `gorm:"foreignKey:SenderID"`
+	RecipientID int        `gorm:"not null;index" validate:"required"`
+	Recipient   *User      `gorm:"foreignKey:RecipientID"`
+	GameID      int        `gorm:"not null" validate:"required"`
+	Game        *Game      `gorm:"foreignKey:GameID"`
+	Message     string
+	Status      string `gorm:"not null;default:'pending';index"`
+	RespondedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+// PointsLedger records a single credit (positive amount) or debit (negative amount)
+// of a user's loyalty points. User.Points always equals the sum of the user's entries.
+type PointsLedger struct {
+	ID        int    `gorm:"primaryKey"`
+	UserID    int    `gorm:"not null;index" validate:"required"`
+	User      *User  `gorm:"foreignKey:UserID"`
+	Amount    int    `gorm:"not null"`
+	Balance   int    `gorm:"not null"` // Balance after the entry was applied
+	Reason    string `gorm:"not null;index"`
+	OrderID   *int   `gorm:"index"`
+	Order     *Order `gorm:"foreignKey:OrderID"`
+	GameID    *int   `gorm:"index"`
+	Game      *Game  `gorm:"foreignKey:GameID"`
+	Note      string
+	CreatedAt time.Time
+}
+
+// RedeemCode represents a key that grants a game or store credit when redeemed
+type RedeemCode struct {
+	ID           int    `gorm:"primaryKey"`
+	Code         string `gorm:"not null;uniqueIndex" validate:"required"`
+	GameID       *int   // Game granted by the code; nil for store-credit codes
+	Game         *Game  `gorm:"foreignKey:GameID"`
+	CreditPoints int    `gorm:"not null;default:0"` // Points credited by store-credit codes
+	MaxUses      int    `gorm:"not null;default:1" validate:"min=1"`
+	Uses         int    `gorm:"not null;default:0"`
+	ExpiresAt    *time.Time
+	Note         string // e.g. the partner store or giveaway the code was made for
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	RedeemCodeUses []*RedeemCodeUse
+}
+
+// RedeemCodeUse records a user redeeming a code
+type RedeemCodeUse struct {
+	ID           int         `gorm:"primaryKey"`
+	RedeemCodeID int         `gorm:"not null;uniqueIndex:idx_redeem_code_use_code_user" validate:"required"`
+	RedeemCode   *RedeemCode `gorm:"foreignKey:RedeemCodeID"`
+	UserID       int         `gorm:"not null;uniqueIndex:idx_redeem_code_use_code_user;index" validate:"required"`
+	User         *User       `gorm:"foreignKey:UserID"`
+	CreatedAt    time.Time
+}
+
+// TaxRule is the sales tax, such as VAT, charged to buyers from a region
+type TaxRule struct {
+	ID        int    `gorm:"primaryKey"`
+	Region    string `gorm:"type:varchar(8);not null;uniqueIndex" validate:"required"` // Region code, as on users
+	Name      string `gorm:"not null" validate:"required"`                             // Shown to buyers, e.g. "VAT"
+	Rate      int    `gorm:"not null" validate:"gte=0,lte=10000"`                      // In basis points, 2000 is 20%
+	Inclusive bool   `gorm:"not null"`                                                 // Prices already include the tax
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// User represents a user in the system
+type User struct {
+	ID           int    `gorm:"primaryKey"`
+	Nickname     string `gorm:"not null;unique" validate:"required,min=2,max=100"`
+	Email        string `gorm:"unique;not null" validate:"required,email"`
+	Password     string `gorm:"not null" validate:"required,min=6"`
+	RoleID       int    `gorm:"not null;default:2"`
+	Role         *Role  `gorm:"foreignKey:RoleID"`
+	Points       int    `gorm:"default:0"`
+	Region       string `gorm:"type:varchar(8)"` // Region the user shops from, matched against game restrictions
+	Currency     string `gorm:"type:varchar(3)"` // Currency the user pays in; chosen from the region when empty
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	Token        string
+	RefreshToken string
+
+	// Relations
+	ShoppingCart *ShoppingCart
+	Favorites    *Favorite
+	Library      *Library
+	Orders       []*Order
+	Reviews      []*Review
+}
+
+// Role represents a user role in the system
+type Role struct {
+	ID          int    `gorm:"primaryKey"`
+	Type        string `gorm:"not null;unique"`
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	// Relations
+	Users []*User
+}
@@ -0,0 +1,303 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the key of the Postgres advisory lock held while migrating, so that
+// replicas starting at the same time apply migrations one after another
+const migrationLockID = 72_610_043
+
+// Migration is a versioned schema change read from migrations/<version>_<name>.up.sql
+// and its optional .down.sql counterpart
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+// schemaMigration records an applied migration
+type schemaMigration struct {
+	Version   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// TableName stores applied migrations in schema_migrations
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// loadMigrations reads the embedded migrations ordered by version
+func loadMigrations() ([]*Migration, error) {
+	files, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, file := range files {
+		name := file.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s must end in .up.sql or .down.sql", name)
+		}
+
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		prefix, title, ok := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s must be named <version>_<name>.%s.sql", name, direction)
+		}
+
+		content, err := migrationFiles.ReadFile(path.Join("migrations", name))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: title}
+			byVersion[version] = migration
+		} else if migration.Name != title {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, migration.Name, title)
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrate applies all pending migrations in order, each in its own transaction
+func (d *Database) Migrate() error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	return d.withMigrationLock(func(conn *gorm.DB) error {
+		applied, err := appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			d.logger.Info("Applying migration", "version", migration.Version, "name", migration.Name)
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Rollback reverts the given number of most recently applied migrations. The baseline
+// drops every table with its data, so it is only rolled back when forced.
+func (d *Database) Rollback(steps int, force bool) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+	byVersion := make(map[int64]*Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	return d.withMigrationLock(func(conn *gorm.DB) error {
+		var applied []schemaMigration
+		if err := conn.Order("version DESC").Limit(steps).Find(&applied).Error; err != nil {
+			return err
+		}
+
+		// Check every step can be rolled back before rolling back any
+		for _, record := range applied {
+			migration, ok := byVersion[record.Version]
+			if !ok {
+				return fmt.Errorf("migration %d_%s is applied but unknown to this build", record.Version, record.Name)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s cannot be rolled back", migration.Version, migration.Name)
+			}
+			if migration.Version == baselineVersion && !force {
+				return fmt.Errorf("refusing to roll back migration %d_%s, which drops every table; use -force if you really mean it", migration.Version, migration.Name)
+			}
+		}
+
+		for _, record := range applied {
+			migration := byVersion[record.Version]
+			d.logger.Info("Rolling back migration", "version", migration.Version, "name", migration.Name)
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{}, migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("failed to roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// MigrationStatus lists every known migration and when it was applied, followed by
+// applied migrations this build does not know about
+func (d *Database) MigrationStatus() ([]*MigrationStatus, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]schemaMigration)
+	if d.DB.Migrator().HasTable(&schemaMigration{}) {
+		if applied, err = appliedMigrations(d.DB); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]*MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := &MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range applied {
+		statuses = append(statuses, &MigrationStatus{Version: record.Version, Name: record.Name, AppliedAt: &record.AppliedAt})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// PendingMigrations returns how many known migrations have not been applied yet
+func (d *Database) PendingMigrations(ctx context.Context) (int, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+
+	db := d.DB.WithContext(ctx)
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return len(migrations), nil
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// withMigrationLock runs fc on a single connection holding the migration advisory lock,
+// after making sure schema_migrations exists. A database created before versioned
+// migrations is first brought up to the baseline, which is then recorded as applied.
+func (d *Database) withMigrationLock(fc func(conn *gorm.DB) error) error {
+	return d.DB.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer func() {
+			if err := conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockID).Error; err != nil {
+				d.logger.Error("Failed to release migration lock", "error", err)
+			}
+		}()
+
+		if !conn.Migrator().HasTable(&schemaMigration{}) {
+			legacy := conn.Migrator().HasTable("user")
+			if legacy {
+				d.logger.Info("Upgrading database created before versioned migrations")
+				if err := (&Database{DB: conn, currency: d.currency, logger: d.logger}).upgradeLegacySchema(); err != nil {
+					return err
+				}
+			}
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(`CREATE TABLE schema_migrations (
+					version bigint PRIMARY KEY,
+					name text NOT NULL,
+					applied_at timestamptz NOT NULL
+				)`).Error; err != nil {
+					return err
+				}
+				if !legacy {
+					return nil
+				}
+				return tx.Create(&schemaMigration{Version: baselineVersion, Name: "baseline", AppliedAt: time.Now()}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create schema_migrations: %w", err)
+			}
+		}
+
+		return fc(conn)
+	})
+}
+
+// appliedMigrations returns the applied migrations by version
+func appliedMigrations(db *gorm.DB) (map[int64]schemaMigration, error) {
+	var records []schemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]schemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
@@ -0,0 +1,38 @@
+DROP TABLE IF EXISTS
+    "bundle_game",
+    "coupon_developer",
+    "coupon_category",
+    "invoice_line",
+    "invoice",
+    "game_price",
+    "guest_cart_item",
+    "coupon_redemption",
+    "redeem_code_use",
+    "gift",
+    "points_ledger",
+    "developer_member",
+    "review_reply",
+    "review_vote",
+    "review_report",
+    "order_item",
+    "library_item",
+    "favorite_item",
+    "cart_item",
+    "notification_preference",
+    "notification",
+    "guest_cart",
+    "bundle",
+    "redeem_code",
+    "review",
+    "restrict",
+    "order",
+    "library",
+    "favorite",
+    "shopping_cart",
+    "game",
+    "user",
+    "tax_rule",
+    "coupon",
+    "category",
+    "developer",
+    "role";
@@ -0,0 +1,584 @@
+-- Baseline schema, matching what GORM AutoMigrate created before versioned migrations.
+
+CREATE TABLE "role" (
+    "id" bigserial,
+    "type" text NOT NULL UNIQUE,
+    "description" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_role_deleted_at" ON "role" ("deleted_at");
+
+CREATE TABLE "developer" (
+    "id" bigserial,
+    "name" text NOT NULL UNIQUE,
+    "country" text,
+    "description" text,
+    "website_url" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_developer_deleted_at" ON "developer" ("deleted_at");
+
+CREATE TABLE "category" (
+    "id" bigserial,
+    "name" text NOT NULL UNIQUE,
+    "description" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_category_deleted_at" ON "category" ("deleted_at");
+
+CREATE TABLE "coupon" (
+    "id" bigserial,
+    "code" text NOT NULL,
+    "type" text NOT NULL,
+    "value" bigint NOT NULL,
+    "min_total" bigint NOT NULL DEFAULT 0,
+    "currency" varchar(3) NOT NULL,
+    "max_uses" bigint NOT NULL DEFAULT 0,
+    "max_uses_per_user" bigint NOT NULL DEFAULT 1,
+    "uses" bigint NOT NULL DEFAULT 0,
+    "starts_at" timestamptz,
+    "ends_at" timestamptz,
+    "active" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_coupon_deleted_at" ON "coupon" ("deleted_at");
+CREATE UNIQUE INDEX "idx_coupon_code" ON "coupon" ("code");
+
+CREATE TABLE "tax_rule" (
+    "id" bigserial,
+    "region" varchar(8) NOT NULL,
+    "name" text NOT NULL,
+    "rate" bigint NOT NULL,
+    "inclusive" boolean NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_tax_rule_region" ON "tax_rule" ("region");
+
+CREATE TABLE "user" (
+    "id" bigserial,
+    "nickname" text NOT NULL UNIQUE,
+    "email" text NOT NULL UNIQUE,
+    "password" text NOT NULL,
+    "role_id" bigint NOT NULL DEFAULT 2,
+    "points" bigint DEFAULT 0,
+    "region" varchar(8),
+    "currency" varchar(3),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "token" text,
+    "refresh_token" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_role_users" FOREIGN KEY ("role_id") REFERENCES "role"("id")
+);
+CREATE INDEX "idx_user_deleted_at" ON "user" ("deleted_at");
+
+CREATE TABLE "game" (
+    "id" bigserial,
+    "title" varchar(255) NOT NULL,
+    "description" text,
+    "price" bigint NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "discount" bigint NOT NULL DEFAULT 0,
+    "release_date" timestamptz,
+    "developer_id" bigint NOT NULL,
+    "category_id" bigint NOT NULL,
+    "image_data" bytea,
+    "image_name" varchar(255),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_developer_games" FOREIGN KEY ("developer_id") REFERENCES "developer"("id"),
+    CONSTRAINT "fk_category_games" FOREIGN KEY ("category_id") REFERENCES "category"("id")
+);
+CREATE INDEX "idx_game_deleted_at" ON "game" ("deleted_at");
+
+CREATE TABLE "shopping_cart" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "coupon_id" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_shopping_cart_coupon" FOREIGN KEY ("coupon_id") REFERENCES "coupon"("id"),
+    CONSTRAINT "fk_user_shopping_cart" FOREIGN KEY ("user_id") REFERENCES "user"("id")
+);
+CREATE INDEX "idx_shopping_cart_deleted_at" ON "shopping_cart" ("deleted_at");
+CREATE UNIQUE INDEX "idx_shopping_cart_user_id" ON "shopping_cart" ("user_id");
+
+CREATE TABLE "favorite" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user_favorites" FOREIGN KEY ("user_id") REFERENCES "user"("id")
+);
+CREATE INDEX "idx_favorite_deleted_at" ON "favorite" ("deleted_at");
+CREATE UNIQUE INDEX "idx_favorite_user_id" ON "favorite" ("user_id");
+
+CREATE TABLE "library" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user_library" FOREIGN KEY ("user_id") REFERENCES "user"("id")
+);
+CREATE INDEX "idx_library_deleted_at" ON "library" ("deleted_at");
+CREATE UNIQUE INDEX "idx_library_user_id" ON "library" ("user_id");
+
+CREATE TABLE "order" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "subtotal" bigint NOT NULL DEFAULT 0,
+    "coupon_id" bigint,
+    "coupon_discount" bigint NOT NULL DEFAULT 0,
+    "tax_region" text,
+    "tax_name" text,
+    "tax_rate" bigint NOT NULL DEFAULT 0,
+    "tax_inclusive" boolean NOT NULL DEFAULT false,
+    "net_total" bigint NOT NULL DEFAULT 0,
+    "tax_total" bigint NOT NULL DEFAULT 0,
+    "gross_total" bigint NOT NULL DEFAULT 0,
+    "points_redeemed" bigint NOT NULL DEFAULT 0,
+    "points_discount" bigint NOT NULL DEFAULT 0,
+    "points_earned" bigint NOT NULL DEFAULT 0,
+    "total_cost" bigint NOT NULL,
+    "refunded_amount" bigint NOT NULL DEFAULT 0,
+    "status" text NOT NULL DEFAULT 'pending',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user_orders" FOREIGN KEY ("user_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_order_coupon" FOREIGN KEY ("coupon_id") REFERENCES "coupon"("id")
+);
+CREATE INDEX "idx_order_deleted_at" ON "order" ("deleted_at");
+
+CREATE TABLE "restrict" (
+    "id" bigserial,
+    "game_id" bigint NOT NULL,
+    "region" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_game_restricts" FOREIGN KEY ("game_id") REFERENCES "game"("id")
+);
+CREATE INDEX "idx_restrict_deleted_at" ON "restrict" ("deleted_at");
+
+CREATE TABLE "review" (
+    "id" bigserial,
+    "game_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "title" text NOT NULL,
+    "description" text,
+    "rating" bigint NOT NULL,
+    "verified_purchase" boolean NOT NULL DEFAULT false,
+    "status" text NOT NULL DEFAULT 'visible',
+    "helpful_count" bigint NOT NULL DEFAULT 0,
+    "unhelpful_count" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_game_reviews" FOREIGN KEY ("game_id") REFERENCES "game"("id"),
+    CONSTRAINT "fk_user_reviews" FOREIGN KEY ("user_id") REFERENCES "user"("id")
+);
+CREATE INDEX "idx_review_deleted_at" ON "review" ("deleted_at");
+CREATE INDEX "idx_review_status" ON "review" ("status");
+CREATE INDEX "idx_review_listing" ON "review" ("game_id","status");
+CREATE UNIQUE INDEX "idx_review_game_user" ON "review" ("game_id","user_id") WHERE deleted_at IS NULL;
+
+CREATE TABLE "redeem_code" (
+    "id" bigserial,
+    "code" text NOT NULL,
+    "game_id" bigint,
+    "credit_points" bigint NOT NULL DEFAULT 0,
+    "max_uses" bigint NOT NULL DEFAULT 1,
+    "uses" bigint NOT NULL DEFAULT 0,
+    "expires_at" timestamptz,
+    "note" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_redeem_code_game" FOREIGN KEY ("game_id") REFERENCES "game"("id")
+);
+CREATE INDEX "idx_redeem_code_deleted_at" ON "redeem_code" ("deleted_at");
+CREATE UNIQUE INDEX "idx_redeem_code_code" ON "redeem_code" ("code");
+
+CREATE TABLE "bundle" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "description" text,
+    "price" bigint NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "active" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_bundle_deleted_at" ON "bundle" ("deleted_at");
+
+CREATE TABLE "guest_cart" (
+    "id" bigserial,
+    "token" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_guest_cart_token" ON "guest_cart" ("token");
+CREATE INDEX "idx_guest_cart_updated_at" ON "guest_cart" ("updated_at");
+
+CREATE TABLE "notification" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "game_id" bigint,
+    "type" text NOT NULL,
+    "title" text NOT NULL,
+    "message" text,
+    "read_at" timestamptz,
+    "emailed_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_notification_user" FOREIGN KEY ("user_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_notification_game" FOREIGN KEY ("game_id") REFERENCES "game"("id")
+);
+CREATE INDEX "idx_notification_user_id" ON "notification" ("user_id");
+
+CREATE TABLE "notification_preference" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "price_drops" boolean NOT NULL,
+    "releases" boolean NOT NULL,
+    "email" boolean NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_notification_preference_user" FOREIGN KEY ("user_id") REFERENCES "user"("id")
+);
+CREATE UNIQUE INDEX "idx_notification_preference_user_id" ON "notification_preference" ("user_id");
+
+CREATE TABLE "cart_item" (
+    "id" bigserial,
+    "shopping_cart_id" bigint NOT NULL,
+    "game_id" bigint,
+    "bundle_id" bigint,
+    "quantity" bigint NOT NULL DEFAULT 1,
+    "added_price" bigint,
+    "added_currency" varchar(3),
+    "gift_recipient_id" bigint,
+    "gift_message" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_cart_item_bundle" FOREIGN KEY ("bundle_id") REFERENCES "bundle"("id"),
+    CONSTRAINT "fk_cart_item_gift_recipient" FOREIGN KEY ("gift_recipient_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_game_cart_items" FOREIGN KEY ("game_id") REFERENCES "game"("id"),
+    CONSTRAINT "fk_shopping_cart_cart_items" FOREIGN KEY ("shopping_cart_id") REFERENCES "shopping_cart"("id")
+);
+CREATE INDEX "idx_cart_item_deleted_at" ON "cart_item" ("deleted_at");
+
+CREATE TABLE "favorite_item" (
+    "id" bigserial,
+    "favorite_id" bigint NOT NULL,
+    "game_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "watched_price" bigint,
+    "watched_currency" varchar(3),
+    "release_notified" boolean NOT NULL DEFAULT false,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_game_favorite_items" FOREIGN KEY ("game_id") REFERENCES "game"("id"),
+    CONSTRAINT "fk_favorite_favorite_items" FOREIGN KEY ("favorite_id") REFERENCES "favorite"("id")
+);
+CREATE INDEX "idx_favorite_item_deleted_at" ON "favorite_item" ("deleted_at");
+
+CREATE TABLE "library_item" (
+    "id" bigserial,
+    "library_id" bigint NOT NULL,
+    "game_id" bigint NOT NULL,
+    "locked" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_library_library_items" FOREIGN KEY ("library_id") REFERENCES "library"("id"),
+    CONSTRAINT "fk_game_library_items" FOREIGN KEY ("game_id") REFERENCES "game"("id")
+);
+CREATE INDEX "idx_library_item_deleted_at" ON "library_item" ("deleted_at");
+
+CREATE TABLE "order_item" (
+    "id" bigserial,
+    "order_id" bigint NOT NULL,
+    "game_id" bigint,
+    "bundle_id" bigint,
+    "price" bigint NOT NULL,
+    "quantity" bigint NOT NULL DEFAULT 1,
+    "net_amount" bigint NOT NULL DEFAULT 0,
+    "tax_amount" bigint NOT NULL DEFAULT 0,
+    "gross_amount" bigint NOT NULL DEFAULT 0,
+    "pre_order" boolean NOT NULL DEFAULT false,
+    "gift_recipient_id" bigint,
+    "gift_message" text,
+    "refunded_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_order_item_gift_recipient" FOREIGN KEY ("gift_recipient_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_game_order_items" FOREIGN KEY ("game_id") REFERENCES "game"("id"),
+    CONSTRAINT "fk_order_order_items" FOREIGN KEY ("order_id") REFERENCES "order"("id"),
+    CONSTRAINT "fk_order_item_bundle" FOREIGN KEY ("bundle_id") REFERENCES "bundle"("id")
+);
+CREATE INDEX "idx_order_item_deleted_at" ON "order_item" ("deleted_at");
+
+CREATE TABLE "review_report" (
+    "id" bigserial,
+    "review_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "reason" text NOT NULL,
+    "resolved" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_review_report_user" FOREIGN KEY ("user_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_review_reports" FOREIGN KEY ("review_id") REFERENCES "review"("id")
+);
+CREATE INDEX "idx_review_report_deleted_at" ON "review_report" ("deleted_at");
+CREATE INDEX "idx_review_report_resolved" ON "review_report" ("resolved");
+CREATE UNIQUE INDEX "idx_review_report_review_user" ON "review_report" ("review_id","user_id") WHERE deleted_at IS NULL;
+
+CREATE TABLE "review_vote" (
+    "id" bigserial,
+    "review_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "helpful" boolean NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_review_vote_review" FOREIGN KEY ("review_id") REFERENCES "review"("id"),
+    CONSTRAINT "fk_review_vote_user" FOREIGN KEY ("user_id") REFERENCES "user"("id")
+);
+CREATE INDEX "idx_review_vote_deleted_at" ON "review_vote" ("deleted_at");
+CREATE UNIQUE INDEX "idx_review_vote_review_user" ON "review_vote" ("review_id","user_id") WHERE deleted_at IS NULL;
+
+CREATE TABLE "review_reply" (
+    "id" bigserial,
+    "review_id" bigint NOT NULL,
+    "developer_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "body" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_review_reply_developer" FOREIGN KEY ("developer_id") REFERENCES "developer"("id"),
+    CONSTRAINT "fk_review_reply_user" FOREIGN KEY ("user_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_review_reply" FOREIGN KEY ("review_id") REFERENCES "review"("id")
+);
+CREATE INDEX "idx_review_reply_deleted_at" ON "review_reply" ("deleted_at");
+CREATE UNIQUE INDEX "idx_review_reply_review" ON "review_reply" ("review_id") WHERE deleted_at IS NULL;
+
+CREATE TABLE "developer_member" (
+    "id" bigserial,
+    "developer_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_developer_member_user" FOREIGN KEY ("user_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_developer_members" FOREIGN KEY ("developer_id") REFERENCES "developer"("id")
+);
+CREATE INDEX "idx_developer_member_deleted_at" ON "developer_member" ("deleted_at");
+CREATE INDEX "idx_developer_member_user_id" ON "developer_member" ("user_id");
+CREATE UNIQUE INDEX "idx_developer_member" ON "developer_member" ("developer_id","user_id") WHERE deleted_at IS NULL;
+
+CREATE TABLE "points_ledger" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "amount" bigint NOT NULL,
+    "balance" bigint NOT NULL,
+    "reason" text NOT NULL,
+    "order_id" bigint,
+    "game_id" bigint,
+    "note" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_points_ledger_user" FOREIGN KEY ("user_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_points_ledger_order" FOREIGN KEY ("order_id") REFERENCES "order"("id"),
+    CONSTRAINT "fk_points_ledger_game" FOREIGN KEY ("game_id") REFERENCES "game"("id")
+);
+CREATE INDEX "idx_points_ledger_reason" ON "points_ledger" ("reason");
+CREATE INDEX "idx_points_ledger_user_id" ON "points_ledger" ("user_id");
+CREATE INDEX "idx_points_ledger_game_id" ON "points_ledger" ("game_id");
+CREATE INDEX "idx_points_ledger_order_id" ON "points_ledger" ("order_id");
+
+CREATE TABLE "gift" (
+    "id" bigserial,
+    "order_item_id" bigint NOT NULL,
+    "sender_id" bigint NOT NULL,
+    "recipient_id" bigint NOT NULL,
+    "game_id" bigint NOT NULL,
+    "message" text,
+    "status" text NOT NULL DEFAULT 'pending',
+    "responded_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_gift_order_item" FOREIGN KEY ("order_item_id") REFERENCES "order_item"("id"),
+    CONSTRAINT "fk_gift_sender" FOREIGN KEY ("sender_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_gift_recipient" FOREIGN KEY ("recipient_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_gift_game" FOREIGN KEY ("game_id") REFERENCES "game"("id")
+);
+CREATE INDEX "idx_gift_deleted_at" ON "gift" ("deleted_at");
+CREATE INDEX "idx_gift_status" ON "gift" ("status");
+CREATE INDEX "idx_gift_recipient_id" ON "gift" ("recipient_id");
+CREATE INDEX "idx_gift_sender_id" ON "gift" ("sender_id");
+CREATE UNIQUE INDEX "idx_gift_order_item_id" ON "gift" ("order_item_id");
+
+CREATE TABLE "redeem_code_use" (
+    "id" bigserial,
+    "redeem_code_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_redeem_code_use_user" FOREIGN KEY ("user_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_redeem_code_redeem_code_uses" FOREIGN KEY ("redeem_code_id") REFERENCES "redeem_code"("id")
+);
+CREATE INDEX "idx_redeem_code_use_user_id" ON "redeem_code_use" ("user_id");
+CREATE UNIQUE INDEX "idx_redeem_code_use_code_user" ON "redeem_code_use" ("redeem_code_id","user_id");
+
+CREATE TABLE "coupon_redemption" (
+    "id" bigserial,
+    "coupon_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "order_id" bigint NOT NULL,
+    "discount" bigint NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_coupon_redemption_coupon" FOREIGN KEY ("coupon_id") REFERENCES "coupon"("id"),
+    CONSTRAINT "fk_coupon_redemption_user" FOREIGN KEY ("user_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_coupon_redemption_order" FOREIGN KEY ("order_id") REFERENCES "order"("id")
+);
+CREATE UNIQUE INDEX "idx_coupon_redemption_order_id" ON "coupon_redemption" ("order_id");
+CREATE INDEX "idx_coupon_redemption_coupon_user" ON "coupon_redemption" ("coupon_id","user_id");
+
+CREATE TABLE "guest_cart_item" (
+    "id" bigserial,
+    "guest_cart_id" bigint NOT NULL,
+    "game_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_guest_cart_item_game" FOREIGN KEY ("game_id") REFERENCES "game"("id"),
+    CONSTRAINT "fk_guest_cart_items" FOREIGN KEY ("guest_cart_id") REFERENCES "guest_cart"("id")
+);
+CREATE UNIQUE INDEX "idx_guest_cart_item" ON "guest_cart_item" ("guest_cart_id","game_id");
+
+CREATE TABLE "game_price" (
+    "id" bigserial,
+    "game_id" bigint NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "price" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_game_prices" FOREIGN KEY ("game_id") REFERENCES "game"("id")
+);
+CREATE UNIQUE INDEX "idx_game_price_currency" ON "game_price" ("game_id","currency");
+
+CREATE TABLE "invoice" (
+    "id" bigserial,
+    "sequence" bigint NOT NULL,
+    "number" text NOT NULL,
+    "order_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "buyer_name" text NOT NULL,
+    "buyer_email" text NOT NULL,
+    "buyer_region" text,
+    "currency" varchar(3) NOT NULL,
+    "subtotal" bigint NOT NULL,
+    "coupon_discount" bigint NOT NULL,
+    "tax_name" text,
+    "tax_rate" bigint NOT NULL,
+    "tax_inclusive" boolean NOT NULL,
+    "net_total" bigint NOT NULL,
+    "tax_total" bigint NOT NULL,
+    "gross_total" bigint NOT NULL,
+    "points_discount" bigint NOT NULL,
+    "total_paid" bigint NOT NULL,
+    "ordered_at" timestamptz,
+    "issued_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_invoice_order" FOREIGN KEY ("order_id") REFERENCES "order"("id")
+);
+CREATE INDEX "idx_invoice_user_id" ON "invoice" ("user_id");
+CREATE UNIQUE INDEX "idx_invoice_order_id" ON "invoice" ("order_id");
+CREATE UNIQUE INDEX "idx_invoice_number" ON "invoice" ("number");
+CREATE UNIQUE INDEX "idx_invoice_sequence" ON "invoice" ("sequence");
+
+CREATE TABLE "invoice_line" (
+    "id" bigserial,
+    "invoice_id" bigint NOT NULL,
+    "description" text NOT NULL,
+    "quantity" bigint NOT NULL,
+    "unit_price" bigint NOT NULL,
+    "net_amount" bigint NOT NULL,
+    "tax_amount" bigint NOT NULL,
+    "gross_amount" bigint NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_invoice_lines" FOREIGN KEY ("invoice_id") REFERENCES "invoice"("id")
+);
+CREATE INDEX "idx_invoice_line_invoice_id" ON "invoice_line" ("invoice_id");
+
+CREATE TABLE "coupon_category" (
+    "coupon_id" bigint,
+    "category_id" bigint,
+    PRIMARY KEY ("coupon_id","category_id"),
+    CONSTRAINT "fk_coupon_category_coupon" FOREIGN KEY ("coupon_id") REFERENCES "coupon"("id"),
+    CONSTRAINT "fk_coupon_category_category" FOREIGN KEY ("category_id") REFERENCES "category"("id")
+);
+
+CREATE TABLE "coupon_developer" (
+    "coupon_id" bigint,
+    "developer_id" bigint,
+    PRIMARY KEY ("coupon_id","developer_id"),
+    CONSTRAINT "fk_coupon_developer_coupon" FOREIGN KEY ("coupon_id") REFERENCES "coupon"("id"),
+    CONSTRAINT "fk_coupon_developer_developer" FOREIGN KEY ("developer_id") REFERENCES "developer"("id")
+);
+
+CREATE TABLE "bundle_game" (
+    "bundle_id" bigint,
+    "game_id" bigint,
+    PRIMARY KEY ("bundle_id","game_id"),
+    CONSTRAINT "fk_bundle_game_bundle" FOREIGN KEY ("bundle_id") REFERENCES "bundle"("id"),
+    CONSTRAINT "fk_bundle_game_game" FOREIGN KEY ("game_id") REFERENCES "game"("id")
+);
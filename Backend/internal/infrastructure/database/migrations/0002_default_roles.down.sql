@@ -0,0 +1,3 @@
+DELETE FROM "role"
+WHERE "type" IN ('admin', 'user')
+  AND NOT EXISTS (SELECT 1 FROM "user" WHERE "user"."role_id" = "role"."id");
@@ -0,0 +1,6 @@
+-- Roles the application relies on. New users get role 2 by default, so on an empty
+-- database admin must be created first.
+INSERT INTO "role" ("type", "description", "created_at", "updated_at") VALUES
+    ('admin', 'Administrator with full access', now(), now()),
+    ('user', 'Regular user with limited access', now(), now())
+ON CONFLICT ("type") DO NOTHING;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_library_item_order_item_id";
+ALTER TABLE "library_item" DROP COLUMN IF EXISTS "order_item_id";
@@ -0,0 +1,32 @@
+-- Remember which order item granted each library copy, so refunds can take it back
+ALTER TABLE "library_item" ADD COLUMN IF NOT EXISTS "order_item_id" bigint;
+CREATE INDEX IF NOT EXISTS "idx_library_item_order_item_id" ON "library_item" ("order_item_id");
+
+-- Copies bought for oneself, alone or in a bundle, and accepted gifts of paid orders
+UPDATE "library_item" SET "order_item_id" = "granted"."order_item_id"
+FROM (
+    SELECT DISTINCT ON ("library"."id", "game_id") "library"."id" AS "library_id", "game_id", "order_item_id"
+    FROM (
+        SELECT "order"."user_id", "order_item"."game_id", "order_item"."id" AS "order_item_id"
+        FROM "order_item"
+        JOIN "order" ON "order"."id" = "order_item"."order_id"
+        WHERE "order"."status" = 'paid' AND "order_item"."game_id" IS NOT NULL
+          AND "order_item"."gift_recipient_id" IS NULL AND "order_item"."refunded_at" IS NULL
+        UNION ALL
+        SELECT "order"."user_id", "bundle_game"."game_id", "order_item"."id"
+        FROM "order_item"
+        JOIN "order" ON "order"."id" = "order_item"."order_id"
+        JOIN "bundle_game" ON "bundle_game"."bundle_id" = "order_item"."bundle_id"
+        WHERE "order"."status" = 'paid' AND "order_item"."refunded_at" IS NULL
+        UNION ALL
+        SELECT "gift"."recipient_id", "gift"."game_id", "gift"."order_item_id"
+        FROM "gift"
+        JOIN "order_item" ON "order_item"."id" = "gift"."order_item_id"
+        WHERE "gift"."status" = 'accepted' AND "order_item"."refunded_at" IS NULL
+    ) AS "purchase"
+    JOIN "library" ON "library"."user_id" = "purchase"."user_id"
+    ORDER BY "library"."id", "game_id", "order_item_id"
+) AS "granted"
+WHERE "library_item"."library_id" = "granted"."library_id"
+  AND "library_item"."game_id" = "granted"."game_id"
+  AND "library_item"."order_item_id" IS NULL;
@@ -0,0 +1,15 @@
+-- The games of each ordered bundle as it was when ordered, granted on payment
+CREATE TABLE "order_item_game" (
+    "order_item_id" bigint,
+    "game_id" bigint,
+    PRIMARY KEY ("order_item_id","game_id"),
+    CONSTRAINT "fk_order_item_bundle_games" FOREIGN KEY ("order_item_id") REFERENCES "order_item"("id"),
+    CONSTRAINT "fk_order_item_game_game" FOREIGN KEY ("game_id") REFERENCES "game"("id")
+);
+CREATE INDEX "idx_order_item_game_game_id" ON "order_item_game" ("game_id");
+
+-- Bundles ordered before had no snapshot; their current games are the best record left
+INSERT INTO "order_item_game" ("order_item_id", "game_id")
+SELECT "order_item"."id", "bundle_game"."game_id"
+FROM "order_item"
+JOIN "bundle_game" ON "bundle_game"."bundle_id" = "order_item"."bundle_id";
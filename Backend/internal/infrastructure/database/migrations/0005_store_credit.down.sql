@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "store_credit_ledger";
+DROP TABLE IF EXISTS "store_credit";
+ALTER TABLE "redeem_code" DROP COLUMN IF EXISTS "credit_currency";
+ALTER TABLE "redeem_code" DROP COLUMN IF EXISTS "credit_amount";
@@ -0,0 +1,32 @@
+-- Store credit is money owed to the user, kept per currency apart from loyalty points
+ALTER TABLE "redeem_code" ADD COLUMN IF NOT EXISTS "credit_amount" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "redeem_code" ADD COLUMN IF NOT EXISTS "credit_currency" varchar(3);
+
+CREATE TABLE "store_credit" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "balance" bigint NOT NULL DEFAULT 0,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_store_credit_user" FOREIGN KEY ("user_id") REFERENCES "user"("id")
+);
+CREATE UNIQUE INDEX "idx_store_credit_user_currency" ON "store_credit" ("user_id","currency");
+
+CREATE TABLE "store_credit_ledger" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "amount" bigint NOT NULL,
+    "balance" bigint NOT NULL,
+    "reason" text NOT NULL,
+    "redeem_code_id" bigint,
+    "note" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_store_credit_ledger_user" FOREIGN KEY ("user_id") REFERENCES "user"("id"),
+    CONSTRAINT "fk_store_credit_ledger_redeem_code" FOREIGN KEY ("redeem_code_id") REFERENCES "redeem_code"("id")
+);
+CREATE INDEX "idx_store_credit_ledger_user_id" ON "store_credit_ledger" ("user_id");
+CREATE INDEX "idx_store_credit_ledger_reason" ON "store_credit_ledger" ("reason");
+CREATE INDEX "idx_store_credit_ledger_redeem_code_id" ON "store_credit_ledger" ("redeem_code_id");
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_store_credit_ledger_order_id";
+ALTER TABLE "store_credit_ledger" DROP COLUMN IF EXISTS "order_id";
+ALTER TABLE "invoice" DROP COLUMN IF EXISTS "credit_paid";
+ALTER TABLE "order" DROP COLUMN IF EXISTS "credit_paid";
@@ -0,0 +1,7 @@
+-- Store credit pays part of an order, and is given back when the order is refunded
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS "credit_paid" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "invoice" ADD COLUMN IF NOT EXISTS "credit_paid" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "store_credit_ledger" ADD COLUMN IF NOT EXISTS "order_id" bigint;
+ALTER TABLE "store_credit_ledger" ADD CONSTRAINT "fk_store_credit_ledger_order" FOREIGN KEY ("order_id") REFERENCES "order"("id");
+CREATE INDEX IF NOT EXISTS "idx_store_credit_ledger_order_id" ON "store_credit_ledger" ("order_id");
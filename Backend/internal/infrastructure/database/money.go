@@ -0,0 +1,129 @@
+package database
+
+import (
+	"fmt"
+	"math"
+
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/infrastructure/database/legacy"
+)
+
+// moneyColumn is a column holding an amount of money
+type moneyColumn struct {
+	Table  string
+	Column string
+}
+
+// moneyColumns lists the amounts older databases stored as decimal major units
+var moneyColumns = []moneyColumn{
+	{"game", "price"},
+	{"bundle", "price"},
+	{"order", "subtotal"},
+	{"order", "coupon_discount"},
+	{"order", "points_discount"},
+	{"order", "total_cost"},
+	{"order", "refunded_amount"},
+	{"order_item", "price"},
+	{"coupon", "value"},
+	{"coupon", "min_total"},
+	{"coupon_redemption", "discount"},
+	{"cart_item", "added_price"},
+	{"favorite_item", "watched_price"},
+}
+
+// migrateMoney converts amounts stored as decimal major units to integer minor units of
+// the store currency, and gives existing priced rows that currency. It must run before
+// the schema migration, which cannot add the required currency columns to filled tables.
+func (d *Database) migrateMoney() error {
+	currency := d.currency
+	factor := int64(math.Pow10(models.CurrencyExponent(currency)))
+
+	for _, c := range moneyColumns {
+		var dataType string
+		if err := d.DB.Raw(
+			"SELECT data_type FROM information_schema.columns WHERE table_schema = 'public' AND table_name = ? AND column_name = ?",
+			c.Table, c.Column,
+		).Scan(&dataType).Error; err != nil {
+			return fmt.Errorf("failed to inspect %s.%s: %w", c.Table, c.Column, err)
+		}
+		if dataType != "double precision" && dataType != "numeric" && dataType != "real" {
+			continue
+		}
+
+		// Percent coupons keep their value, fixed ones are an amount
+		using := fmt.Sprintf("round(%s * %d)", c.Column, factor)
+		if c.Table == "coupon" && c.Column == "value" {
+			using = fmt.Sprintf("CASE WHEN type = '%s' THEN round(value * %d) ELSE round(value) END", models.CouponTypeFixed, factor)
+		}
+		if err := d.DB.Exec(fmt.Sprintf(`ALTER TABLE %q ALTER COLUMN %s TYPE bigint USING %s`, c.Table, c.Column, using)).Error; err != nil {
+			return fmt.Errorf("failed to convert %s.%s: %w", c.Table, c.Column, err)
+		}
+	}
+
+	for _, model := range []interface{}{&legacy.Game{}, &legacy.Bundle{}, &legacy.Order{}, &legacy.Coupon{}} {
+		migrator := d.DB.Migrator()
+		if !migrator.HasTable(model) || migrator.HasColumn(model, "Currency") {
+			continue
+		}
+		stmt := &gorm.Statement{DB: d.DB}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		table := stmt.Schema.Table
+		if err := d.DB.Exec(fmt.Sprintf(`ALTER TABLE %q ADD COLUMN currency varchar(3) NOT NULL DEFAULT '%s'`, table, currency)).Error; err != nil {
+			return fmt.Errorf("failed to add %s.currency: %w", table, err)
+		}
+		if err := d.DB.Exec(fmt.Sprintf(`ALTER TABLE %q ALTER COLUMN currency DROP DEFAULT`, table)).Error; err != nil {
+			return fmt.Errorf("failed to add %s.currency: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// fillPriceCurrencies gives price snapshots taken before currencies existed the store currency
+func (d *Database) fillPriceCurrencies() error {
+	currency := d.currency
+	if err := d.DB.Model(&legacy.CartItem{}).
+		Where("added_price IS NOT NULL AND (added_currency IS NULL OR added_currency = '')").
+		Update("added_currency", currency).Error; err != nil {
+		return fmt.Errorf("failed to fill cart item currencies: %w", err)
+	}
+	if err := d.DB.Model(&legacy.FavoriteItem{}).
+		Where("watched_price IS NOT NULL AND (watched_currency IS NULL OR watched_currency = '')").
+		Update("watched_currency", currency).Error; err != nil {
+		return fmt.Errorf("failed to fill wishlist currencies: %w", err)
+	}
+	return nil
+}
+
+// fillOrderTotals records the discounted amounts of orders placed before taxes were charged.
+// Their items get their share of the coupon, and no tax. Orders older still have no subtotal
+// and are taken at their total.
+func (d *Database) fillOrderTotals() error {
+	if err := d.DB.Exec(`UPDATE order_item AS i
+		SET net_amount = amount, gross_amount = amount
+		FROM (
+			SELECT i.id, CASE WHEN o.subtotal > 0
+				THEN round(i.price * i.quantity * (o.subtotal - o.coupon_discount)::numeric / o.subtotal)
+				ELSE i.price * i.quantity END AS amount
+			FROM order_item AS i JOIN "order" AS o ON o.id = i.order_id
+			WHERE o.gross_total = 0 AND o.tax_total = 0
+		) AS filled
+		WHERE filled.id = i.id AND i.gross_amount = 0`).Error; err != nil {
+		return fmt.Errorf("failed to fill order item totals: %w", err)
+	}
+	if err := d.DB.Exec(`UPDATE "order"
+		SET net_total = amount, gross_total = amount
+		FROM (
+			SELECT id, CASE WHEN subtotal > 0 THEN subtotal - coupon_discount ELSE total_cost + points_discount END AS amount
+			FROM "order"
+			WHERE gross_total = 0 AND tax_total = 0
+		) AS filled
+		WHERE filled.id = "order".id`).Error; err != nil {
+		return fmt.Errorf("failed to fill order totals: %w", err)
+	}
+	return nil
+}
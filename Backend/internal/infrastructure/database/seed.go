@@ -0,0 +1,653 @@
+package database
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+
+	"uniStore/Backend/internal/assets"
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/utils"
+)
+
+//go:embed fixtures/*.yaml
+var fixtureFiles embed.FS
+
+// loadTestPassword is the password of every generated load test user
+const loadTestPassword = "loadtest123"
+
+// seedBatchSize is how many generated rows are inserted per statement
+const seedBatchSize = 500
+
+// Fixtures is a set of seed data. Records refer to each other by name: games by title,
+// categories and developers by name, users by nickname.
+type Fixtures struct {
+	Categories []CategoryFixture  `yaml:"categories"`
+	Developers []DeveloperFixture `yaml:"developers"`
+	Games      []GameFixture      `yaml:"games"`
+	Users      []UserFixture      `yaml:"users"`
+	Orders     []OrderFixture     `yaml:"orders"`
+	Reviews    []ReviewFixture    `yaml:"reviews"`
+	Generate   *GenerateFixture   `yaml:"generate"`
+}
+
+// CategoryFixture is a seeded category
+type CategoryFixture struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// DeveloperFixture is a seeded developer
+type DeveloperFixture struct {
+	Name        string `yaml:"name"`
+	Country     string `yaml:"country"`
+	Description string `yaml:"description"`
+	WebsiteURL  string `yaml:"website_url"`
+}
+
+// GameFixture is a seeded game, priced in minor units of the store currency
+type GameFixture struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Price       int64  `yaml:"price"`
+	Discount    int    `yaml:"discount"`
+	ReleaseDate string `yaml:"release_date"` // YYYY-MM-DD, today when empty
+	Developer   string `yaml:"developer"`
+	Category    string `yaml:"category"`
+}
+
+// UserFixture is a seeded user with the games in their cart and favorites
+type UserFixture struct {
+	Nickname  string   `yaml:"nickname"`
+	Email     string   `yaml:"email"`
+	Password  string   `yaml:"password"`
+	Role      string   `yaml:"role"`
+	Points    int      `yaml:"points"`
+	Region    string   `yaml:"region"`
+	Cart      []string `yaml:"cart"`
+	Favorites []string `yaml:"favorites"`
+}
+
+// OrderFixture is a seeded order of one copy of each game. Paid orders add the games to the user's library.
+type OrderFixture struct {
+	User   string   `yaml:"user"`
+	Status string   `yaml:"status"`
+	Games  []string `yaml:"games"`
+}
+
+// ReviewFixture is a seeded review
+type ReviewFixture struct {
+	Game        string `yaml:"game"`
+	User        string `yaml:"user"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Rating      int    `yaml:"rating"`
+}
+
+// GenerateFixture sets how many games, users and paid orders to generate for load testing
+type GenerateFixture struct {
+	Games  int `yaml:"games"`
+	Users  int `yaml:"users"`
+	Orders int `yaml:"orders"`
+}
+
+// FixtureSets lists the names of the bundled fixture sets
+func FixtureSets() []string {
+	files, _ := fixtureFiles.ReadDir("fixtures")
+	sets := make([]string, 0, len(files))
+	for _, file := range files {
+		sets = append(sets, strings.TrimSuffix(file.Name(), ".yaml"))
+	}
+	sort.Strings(sets)
+	return sets
+}
+
+// LoadFixtures reads a bundled fixture set by name, or a YAML or JSON fixture file by path
+func LoadFixtures(nameOrPath string) (*Fixtures, error) {
+	var content []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(nameOrPath)) {
+	case ".yaml", ".yml", ".json":
+		content, err = os.ReadFile(nameOrPath)
+	default:
+		content, err = fixtureFiles.ReadFile(path.Join("fixtures", nameOrPath+".yaml"))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("unknown fixture set %q, expected one of %s or a .yaml or .json file",
+				nameOrPath, strings.Join(FixtureSets(), ", "))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML, so both formats are decoded the same way
+	var fixtures Fixtures
+	if err := yaml.Unmarshal(content, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures %s: %w", nameOrPath, err)
+	}
+	return &fixtures, nil
+}
+
+// Seed adds the fixtures to the database. Records that already exist are left untouched,
+// and carts, favorites, orders and reviews are only added for users the fixtures create,
+// so seeding the same set twice adds nothing. Generated load test data is topped up to
+// the requested counts.
+func (d *Database) Seed(fixtures *Fixtures) error {
+	err := d.DB.Transaction(func(tx *gorm.DB) error {
+		return (&seeder{tx: tx, currency: d.currency, logger: d.logger}).seed(fixtures)
+	})
+	if err != nil {
+		return err
+	}
+
+	if fixtures.Generate != nil {
+		return (&seeder{tx: d.DB, currency: d.currency, logger: d.logger}).generate(*fixtures.Generate)
+	}
+	return nil
+}
+
+// seeder adds fixtures within a transaction
+type seeder struct {
+	tx       *gorm.DB
+	currency string
+	logger   *slog.Logger
+
+	categories map[string]int
+	developers map[string]int
+	games      map[string]*models.Game
+	users      map[string]*models.User // Users created by this run, by nickname
+}
+
+// seed adds the fixture records in dependency order
+func (s *seeder) seed(fixtures *Fixtures) error {
+	s.categories = make(map[string]int)
+	s.developers = make(map[string]int)
+	s.games = make(map[string]*models.Game)
+	s.users = make(map[string]*models.User)
+
+	for _, fixture := range fixtures.Categories {
+		category := models.Category{Name: fixture.Name}
+		if err := s.tx.Where(category).Attrs(models.Category{Description: fixture.Description}).
+			FirstOrCreate(&category).Error; err != nil {
+			return fmt.Errorf("failed to seed category %s: %w", fixture.Name, err)
+		}
+		s.categories[category.Name] = category.ID
+	}
+
+	for _, fixture := range fixtures.Developers {
+		developer := models.Developer{Name: fixture.Name}
+		if err := s.tx.Where(developer).Attrs(models.Developer{
+			Country:     fixture.Country,
+			Description: fixture.Description,
+			WebsiteURL:  fixture.WebsiteURL,
+		}).FirstOrCreate(&developer).Error; err != nil {
+			return fmt.Errorf("failed to seed developer %s: %w", fixture.Name, err)
+		}
+		s.developers[developer.Name] = developer.ID
+	}
+
+	for _, fixture := range fixtures.Games {
+		if err := s.seedGame(fixture); err != nil {
+			return fmt.Errorf("failed to seed game %s: %w", fixture.Title, err)
+		}
+	}
+
+	for _, fixture := range fixtures.Users {
+		if err := s.seedUser(fixture); err != nil {
+			return fmt.Errorf("failed to seed user %s: %w", fixture.Nickname, err)
+		}
+	}
+
+	for i, fixture := range fixtures.Orders {
+		if err := s.seedOrder(fixture); err != nil {
+			return fmt.Errorf("failed to seed order %d: %w", i+1, err)
+		}
+	}
+
+	for _, fixture := range fixtures.Reviews {
+		if err := s.seedReview(fixture); err != nil {
+			return fmt.Errorf("failed to seed review %s: %w", fixture.Title, err)
+		}
+	}
+	return nil
+}
+
+// seedGame adds a game unless one with its title exists
+func (s *seeder) seedGame(fixture GameFixture) error {
+	var game models.Game
+	err := s.tx.Where("title = ?", fixture.Title).First(&game).Error
+	if err == nil {
+		s.games[game.Title] = &game
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	categoryID, err := s.lookup(s.categories, &models.Category{}, "category", fixture.Category)
+	if err != nil {
+		return err
+	}
+	developerID, err := s.lookup(s.developers, &models.Developer{}, "developer", fixture.Developer)
+	if err != nil {
+		return err
+	}
+	releaseDate := time.Now()
+	if fixture.ReleaseDate != "" {
+		if releaseDate, err = time.Parse("2006-01-02", fixture.ReleaseDate); err != nil {
+			return fmt.Errorf("invalid release date %q", fixture.ReleaseDate)
+		}
+	}
+
+	game = models.Game{
+		Title:       fixture.Title,
+		Description: fixture.Description,
+		Price:       fixture.Price,
+		Currency:    s.currency,
+		Discount:    fixture.Discount,
+		ReleaseDate: releaseDate,
+		DeveloperID: developerID,
+		CategoryID:  categoryID,
+		ImageData:   assets.GameBlankImage,
+		ImageName:   "gameBlankImage.png",
+	}
+	if err := s.tx.Create(&game).Error; err != nil {
+		return err
+	}
+	s.logger.Info("Seeded game", "title", game.Title)
+	s.games[game.Title] = &game
+	return nil
+}
+
+// seedUser adds a user with an empty library and their cart and favorites, unless the email or nickname is taken
+func (s *seeder) seedUser(fixture UserFixture) error {
+	var count int64
+	if err := s.tx.Model(&models.User{}).Where("email = ? OR nickname = ?", fixture.Email, fixture.Nickname).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	role := fixture.Role
+	if role == "" {
+		role = "user"
+	}
+	var roleID int
+	if err := s.tx.Model(&models.Role{}).Where("type = ?", role).Select("id").Scan(&roleID).Error; err != nil {
+		return err
+	}
+	if roleID == 0 {
+		return fmt.Errorf("unknown role %s", role)
+	}
+
+	password, err := utils.HashPassword(fixture.Password)
+	if err != nil {
+		return err
+	}
+
+	user := &models.User{
+		Nickname: fixture.Nickname,
+		Email:    fixture.Email,
+		Password: password,
+		RoleID:   roleID,
+		Points:   fixture.Points,
+		Region:   strings.ToUpper(fixture.Region),
+	}
+	if err := s.tx.Create(user).Error; err != nil {
+		return err
+	}
+	if err := createUserCollections(s.tx, []*models.User{user}); err != nil {
+		return err
+	}
+
+	for _, title := range fixture.Cart {
+		game, err := s.game(title)
+		if err != nil {
+			return err
+		}
+		price := models.PercentOff(game.Price, int64(game.Discount))
+		if err := s.tx.Create(&models.CartItem{
+			ShoppingCartID: user.ShoppingCart.ID,
+			GameID:         &game.ID,
+			Quantity:       1,
+			AddedPrice:     &price,
+			AddedCurrency:  game.Currency,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	for _, title := range fixture.Favorites {
+		game, err := s.game(title)
+		if err != nil {
+			return err
+		}
+		if err := s.tx.Create(&models.FavoriteItem{FavoriteID: user.Favorites.ID, GameID: game.ID}).Error; err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("Seeded user", "nickname", user.Nickname)
+	s.users[user.Nickname] = user
+	return nil
+}
+
+// seedOrder adds an order for a user the fixtures created
+func (s *seeder) seedOrder(fixture OrderFixture) error {
+	user, ok := s.users[fixture.User]
+	if !ok {
+		return nil
+	}
+
+	games := make([]*models.Game, 0, len(fixture.Games))
+	for _, title := range fixture.Games {
+		game, err := s.game(title)
+		if err != nil {
+			return err
+		}
+		games = append(games, game)
+	}
+
+	status := fixture.Status
+	if status == "" {
+		status = models.OrderStatusPaid
+	}
+	return createOrder(s.tx, user, games, status, s.currency)
+}
+
+// seedReview adds a review by a user the fixtures created
+func (s *seeder) seedReview(fixture ReviewFixture) error {
+	user, ok := s.users[fixture.User]
+	if !ok {
+		return nil
+	}
+	game, err := s.game(fixture.Game)
+	if err != nil {
+		return err
+	}
+
+	return s.tx.Create(&models.Review{
+		GameID:      game.ID,
+		UserID:      user.ID,
+		Title:       fixture.Title,
+		Description: fixture.Description,
+		Rating:      fixture.Rating,
+		Status:      models.ReviewStatusVisible,
+	}).Error
+}
+
+// game finds a game by title among the seeded games or in the database
+func (s *seeder) game(title string) (*models.Game, error) {
+	if game, ok := s.games[title]; ok {
+		return game, nil
+	}
+	var game models.Game
+	if err := s.tx.Where("title = ?", title).First(&game).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("unknown game %s", title)
+		}
+		return nil, err
+	}
+	s.games[title] = &game
+	return &game, nil
+}
+
+// lookup finds the ID of a record by name among the seeded ones or in the database
+func (s *seeder) lookup(seeded map[string]int, model interface{}, kind, name string) (int, error) {
+	if id, ok := seeded[name]; ok {
+		return id, nil
+	}
+	var id int
+	if err := s.tx.Model(model).Where("name = ?", name).Select("id").Scan(&id).Error; err != nil {
+		return 0, err
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("unknown %s %s", kind, name)
+	}
+	seeded[name] = id
+	return id, nil
+}
+
+// generate tops up generated load test games, users and paid orders to the requested counts
+func (s *seeder) generate(counts GenerateFixture) error {
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	if err := s.generateGames(counts.Games, random); err != nil {
+		return fmt.Errorf("failed to generate games: %w", err)
+	}
+	if err := s.generateUsers(counts.Users); err != nil {
+		return fmt.Errorf("failed to generate users: %w", err)
+	}
+	if err := s.generateOrders(counts.Orders, random); err != nil {
+		return fmt.Errorf("failed to generate orders: %w", err)
+	}
+	return nil
+}
+
+// generateGames adds games titled "Load Test Game N" in random categories
+func (s *seeder) generateGames(count int, random *rand.Rand) error {
+	var existing int64
+	if err := s.tx.Model(&models.Game{}).Where("title LIKE ?", "Load Test Game %").Count(&existing).Error; err != nil {
+		return err
+	}
+	if int(existing) >= count {
+		return nil
+	}
+
+	var categoryIDs, developerIDs []int
+	if err := s.tx.Model(&models.Category{}).Pluck("id", &categoryIDs).Error; err != nil {
+		return err
+	}
+	if err := s.tx.Model(&models.Developer{}).Pluck("id", &developerIDs).Error; err != nil {
+		return err
+	}
+	if len(categoryIDs) == 0 || len(developerIDs) == 0 {
+		return errors.New("at least one category and developer are needed to generate games")
+	}
+
+	games := make([]*models.Game, 0, count-int(existing))
+	for i := int(existing) + 1; i <= count; i++ {
+		games = append(games, &models.Game{
+			Title:       fmt.Sprintf("Load Test Game %d", i),
+			Description: "Generated for load testing",
+			Price:       int64(random.Intn(60)+1)*100 - 1,
+			Currency:    s.currency,
+			Discount:    []int{0, 0, 0, 10, 25, 50}[random.Intn(6)],
+			ReleaseDate: time.Now().AddDate(0, 0, -random.Intn(3650)),
+			DeveloperID: developerIDs[random.Intn(len(developerIDs))],
+			CategoryID:  categoryIDs[random.Intn(len(categoryIDs))],
+			ImageName:   "gameBlankImage.png",
+		})
+	}
+	if err := s.tx.CreateInBatches(games, seedBatchSize).Error; err != nil {
+		return err
+	}
+	s.logger.Info("Generated games", "count", len(games))
+	return nil
+}
+
+// generateUsers adds users named loadtestN with empty carts, favorites and libraries
+func (s *seeder) generateUsers(count int) error {
+	var existing int64
+	if err := s.tx.Model(&models.User{}).Where("nickname LIKE ?", "loadtest%").Count(&existing).Error; err != nil {
+		return err
+	}
+	if int(existing) >= count {
+		return nil
+	}
+
+	var roleID int
+	if err := s.tx.Model(&models.Role{}).Where("type = ?", "user").Select("id").Scan(&roleID).Error; err != nil {
+		return err
+	}
+	// Hashing is slow, so every generated user shares one hash
+	password, err := utils.HashPassword(loadTestPassword)
+	if err != nil {
+		return err
+	}
+
+	for start := int(existing) + 1; start <= count; start += seedBatchSize {
+		users := make([]*models.User, 0, seedBatchSize)
+		for i := start; i <= count && i < start+seedBatchSize; i++ {
+			users = append(users, &models.User{
+				Nickname: fmt.Sprintf("loadtest%d", i),
+				Email:    fmt.Sprintf("loadtest%d@example.com", i),
+				Password: password,
+				RoleID:   roleID,
+				Region:   "US",
+			})
+		}
+		err := s.tx.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(users).Error; err != nil {
+				return err
+			}
+			return createUserCollections(tx, users)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	s.logger.Info("Generated users", "count", count-int(existing))
+	return nil
+}
+
+// generateOrders adds paid orders of one to three games the generated users do not own yet
+func (s *seeder) generateOrders(count int, random *rand.Rand) error {
+	var users []*models.User
+	if err := s.tx.Where("nickname LIKE ?", "loadtest%").Preload("Library.LibraryItems").Find(&users).Error; err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return nil
+	}
+	userIDs := make([]int, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+
+	var existing int64
+	if err := s.tx.Model(&models.Order{}).Where("user_id IN ?", userIDs).Count(&existing).Error; err != nil {
+		return err
+	}
+	if int(existing) >= count {
+		return nil
+	}
+
+	var games []*models.Game
+	if err := s.tx.Select("id", "price", "currency", "discount").Where("currency = ?", s.currency).Find(&games).Error; err != nil {
+		return err
+	}
+	if len(games) == 0 {
+		return errors.New("no games to order")
+	}
+
+	owned := make(map[int]map[int]bool, len(users))
+	for _, user := range users {
+		owned[user.ID] = make(map[int]bool)
+		if user.Library != nil {
+			for _, item := range user.Library.LibraryItems {
+				owned[user.ID][item.GameID] = true
+			}
+		}
+	}
+
+	created := 0
+	for created < count-int(existing) {
+		err := s.tx.Transaction(func(tx *gorm.DB) error {
+			for i := 0; i < seedBatchSize && created < count-int(existing); i++ {
+				user := users[random.Intn(len(users))]
+				var picked []*models.Game
+				for n := random.Intn(3) + 1; len(picked) < n && len(owned[user.ID]) < len(games); {
+					game := games[random.Intn(len(games))]
+					if !owned[user.ID][game.ID] {
+						owned[user.ID][game.ID] = true
+						picked = append(picked, game)
+					}
+				}
+				if len(picked) == 0 {
+					return errors.New("generated users already own every game")
+				}
+				if err := createOrder(tx, user, picked, models.OrderStatusPaid, s.currency); err != nil {
+					return err
+				}
+				created++
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	s.logger.Info("Generated orders", "count", created)
+	return nil
+}
+
+// createUserCollections creates the empty cart, favorites and library every user has
+func createUserCollections(tx *gorm.DB, users []*models.User) error {
+	carts := make([]*models.ShoppingCart, len(users))
+	favorites := make([]*models.Favorite, len(users))
+	libraries := make([]*models.Library, len(users))
+	for i, user := range users {
+		carts[i] = &models.ShoppingCart{UserID: user.ID}
+		favorites[i] = &models.Favorite{UserID: user.ID}
+		libraries[i] = &models.Library{UserID: user.ID}
+		user.ShoppingCart, user.Favorites, user.Library = carts[i], favorites[i], libraries[i]
+	}
+
+	if err := tx.Create(carts).Error; err != nil {
+		return err
+	}
+	if err := tx.Create(favorites).Error; err != nil {
+		return err
+	}
+	return tx.Create(libraries).Error
+}
+
+// createOrder creates an untaxed order of one copy of each game at its sale price,
+// adding the games to the user's library when it is paid
+func createOrder(tx *gorm.DB, user *models.User, games []*models.Game, status, currency string) error {
+	order := &models.Order{UserID: user.ID, Currency: currency, Status: status}
+	for _, game := range games {
+		price := models.PercentOff(game.Price, int64(game.Discount))
+		order.Subtotal += price
+		order.OrderItems = append(order.OrderItems, &models.OrderItem{
+			GameID:      &game.ID,
+			Price:       price,
+			Quantity:    1,
+			NetAmount:   price,
+			GrossAmount: price,
+		})
+	}
+	order.NetTotal, order.GrossTotal, order.TotalCost = order.Subtotal, order.Subtotal, order.Subtotal
+
+	if err := tx.Create(order).Error; err != nil {
+		return err
+	}
+	if status != models.OrderStatusPaid {
+		return nil
+	}
+
+	if user.Library == nil {
+		var library models.Library
+		if err := tx.Where("user_id = ?", user.ID).First(&library).Error; err != nil {
+			return err
+		}
+		user.Library = &library
+	}
+	items := make([]*models.LibraryItem, len(games))
+	for i, game := range games {
+		items[i] = &models.LibraryItem{LibraryID: user.Library.ID, GameID: game.ID}
+	}
+	return tx.Create(items).Error
+}
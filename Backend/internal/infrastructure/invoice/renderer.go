@@ -0,0 +1,263 @@
+package invoice
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// fontFamily is the embedded DejaVu Sans Condensed, so names and titles in Cyrillic, Greek
+// and other non-Latin scripts print as they are instead of as question marks
+const fontFamily = "DejaVu"
+
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	regularFont []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	boldFont []byte
+)
+
+// Seller holds the store's details printed on every invoice
+type Seller struct {
+	Name    string
+	Address string
+	TaxID   string
+}
+
+// Renderer renders invoices as PDF and HTML documents
+type Renderer struct {
+	seller Seller
+	html   *template.Template
+}
+
+// NewRenderer creates an invoice renderer for the seller
+func NewRenderer(seller Seller) *Renderer {
+	return &Renderer{
+		seller: seller,
+		html: template.Must(template.New("invoice").Funcs(template.FuncMap{
+			"money":  func(amount int64, currency string) string { return models.FormatMoney(amount, currency) },
+			"split":  func(text string) []string { return nonEmpty(strings.Split(text, "\n")) },
+			"totals": invoiceTotals,
+		}).Parse(htmlTemplate)),
+	}
+}
+
+// HTML renders the invoice as a standalone HTML page
+func (r *Renderer) HTML(invoice *models.Invoice) ([]byte, error) {
+	var buf bytes.Buffer
+	err := r.html.Execute(&buf, struct {
+		Seller  Seller
+		Invoice *models.Invoice
+	}{r.seller, invoice})
+	return buf.Bytes(), err
+}
+
+// PDF renders the invoice as an A4 PDF document
+func (r *Renderer) PDF(invoice *models.Invoice) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(fontFamily, "", regularFont)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", boldFont)
+	pdf.SetTitle("Invoice "+invoice.Number, true)
+	pdf.SetMargins(15, 15, 15)
+	pdf.AddPage()
+	money := func(amount int64) string { return models.FormatMoney(amount, invoice.Currency) }
+
+	// Header
+	pdf.SetFont(fontFamily, "B", 18)
+	pdf.CellFormat(0, 10, "Invoice "+invoice.Number, "", 1, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 10)
+	pdf.CellFormat(0, 5, "Issued "+invoice.IssuedAt.Format("2006-01-02"), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, fmt.Sprintf("Order #%d placed %s", invoice.OrderID, invoice.OrderedAt.Format("2006-01-02")), "", 1, "L", false, 0, "")
+	pdf.Ln(6)
+
+	// Seller and buyer
+	top := pdf.GetY()
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.CellFormat(90, 5, "Seller", "", 2, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 10)
+	for _, line := range sellerLines(r.seller) {
+		pdf.CellFormat(90, 5, line, "", 2, "L", false, 0, "")
+	}
+	bottom := pdf.GetY()
+	pdf.SetXY(110, top)
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.CellFormat(85, 5, "Buyer", "", 2, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 10)
+	for _, line := range buyerLines(invoice) {
+		pdf.CellFormat(85, 5, line, "", 2, "L", false, 0, "")
+	}
+	pdf.SetXY(15, max(bottom, pdf.GetY())+8)
+
+	// Lines
+	widths := []float64{70, 12, 25, 25, 23, 25}
+	pdf.SetFont(fontFamily, "B", 9)
+	pdf.SetFillColor(235, 235, 235)
+	for i, heading := range []string{"Description", "Qty", "Unit price", "Net", "Tax", "Gross"} {
+		align := "R"
+		if i == 0 {
+			align = "L"
+		}
+		pdf.CellFormat(widths[i], 7, heading, "B", 0, align, true, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetFont(fontFamily, "", 9)
+	for _, line := range invoice.Lines {
+		cells := []string{line.Description, strconv.Itoa(line.Quantity), money(line.UnitPrice),
+			money(line.NetAmount), money(line.TaxAmount), money(line.GrossAmount)}
+		for i, cell := range cells {
+			align := "R"
+			if i == 0 {
+				align = "L"
+				cell = truncate(pdf, cell, widths[0]-2)
+			}
+			pdf.CellFormat(widths[i], 6, cell, "", 0, align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	pdf.Ln(4)
+
+	// Totals
+	for _, total := range invoiceTotals(invoice) {
+		style := ""
+		if total.Strong {
+			style = "B"
+		}
+		pdf.SetFont(fontFamily, style, 10)
+		pdf.CellFormat(130, 6, total.Label, "", 0, "R", false, 0, "")
+		pdf.CellFormat(50, 6, money(total.Amount), "", 1, "R", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// invoiceTotal is a labelled amount in the totals of an invoice
+type invoiceTotal struct {
+	Label  string
+	Amount int64
+	Strong bool
+}
+
+// invoiceTotals lists the totals printed under the lines, skipping discounts that were not given
+func invoiceTotals(invoice *models.Invoice) []invoiceTotal {
+	totals := []invoiceTotal{{Label: "Subtotal", Amount: invoice.Subtotal}}
+	if invoice.CouponDiscount > 0 {
+		totals = append(totals, invoiceTotal{Label: "Coupon discount", Amount: -invoice.CouponDiscount})
+	}
+	totals = append(totals, invoiceTotal{Label: "Net total", Amount: invoice.NetTotal})
+	if invoice.TaxName != "" {
+		label := fmt.Sprintf("%s %s", invoice.TaxName, formatRate(invoice.TaxRate))
+		if invoice.TaxInclusive {
+			label += " (included)"
+		}
+		totals = append(totals, invoiceTotal{Label: label, Amount: invoice.TaxTotal})
+	}
+	totals = append(totals, invoiceTotal{Label: "Total", Amount: invoice.GrossTotal, Strong: true})
+	if invoice.PointsDiscount > 0 {
+		totals = append(totals, invoiceTotal{Label: "Paid with loyalty points", Amount: -invoice.PointsDiscount})
+	}
+	if invoice.CreditPaid > 0 {
+		totals = append(totals, invoiceTotal{Label: "Paid with store credit", Amount: -invoice.CreditPaid})
+	}
+	if invoice.PointsDiscount > 0 || invoice.CreditPaid > 0 {
+		totals = append(totals, invoiceTotal{Label: "Amount paid", Amount: invoice.TotalPaid, Strong: true})
+	}
+	return totals
+}
+
+// sellerLines returns the non-empty lines of the seller's details
+func sellerLines(seller Seller) []string {
+	lines := []string{seller.Name}
+	lines = append(lines, strings.Split(seller.Address, "\n")...)
+	if seller.TaxID != "" {
+		lines = append(lines, "Tax ID: "+seller.TaxID)
+	}
+	return nonEmpty(lines)
+}
+
+// buyerLines returns the non-empty lines of the buyer's details
+func buyerLines(invoice *models.Invoice) []string {
+	lines := []string{invoice.BuyerName, invoice.BuyerEmail}
+	if invoice.BuyerRegion != "" {
+		lines = append(lines, "Region: "+invoice.BuyerRegion)
+	}
+	return nonEmpty(lines)
+}
+
+// nonEmpty drops blank lines
+func nonEmpty(lines []string) []string {
+	kept := lines[:0]
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return kept
+}
+
+// truncate shortens text to fit the width, ending it with an ellipsis
+func truncate(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// formatRate formats a rate in basis points as a percentage, e.g. 2000 as "20%" and 750 as "7.5%"
+func formatRate(rate int) string {
+	percent := strconv.FormatFloat(float64(rate)/100, 'f', 2, 64)
+	percent = strings.TrimRight(strings.TrimRight(percent, "0"), ".")
+	return percent + "%"
+}
+
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.Invoice.Number}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 14px; color: #222; max-width: 800px; margin: 40px auto; }
+h1 { margin-bottom: 4px; }
+.parties { display: flex; justify-content: space-between; margin: 24px 0; }
+.parties div { width: 48%; }
+table { width: 100%; border-collapse: collapse; }
+th { background: #ebebeb; text-align: right; padding: 6px; }
+td { text-align: right; padding: 6px; border-bottom: 1px solid #eee; }
+th:first-child, td:first-child { text-align: left; }
+.totals td { border: none; }
+.strong td { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>Invoice {{.Invoice.Number}}</h1>
+<div>Issued {{.Invoice.IssuedAt.Format "2006-01-02"}}</div>
+<div>Order #{{.Invoice.OrderID}} placed {{.Invoice.OrderedAt.Format "2006-01-02"}}</div>
+<div class="parties">
+<div><strong>Seller</strong><br>{{.Seller.Name}}<br>{{range $line := split .Seller.Address}}{{$line}}<br>{{end}}{{with .Seller.TaxID}}Tax ID: {{.}}{{end}}</div>
+<div><strong>Buyer</strong><br>{{.Invoice.BuyerName}}<br>{{.Invoice.BuyerEmail}}{{with .Invoice.BuyerRegion}}<br>Region: {{.}}{{end}}</div>
+</div>
+{{$currency := .Invoice.Currency}}
+<table>
+<tr><th>Description</th><th>Qty</th><th>Unit price</th><th>Net</th><th>Tax</th><th>Gross</th></tr>
+{{range .Invoice.Lines}}<tr><td>{{.Description}}</td><td>{{.Quantity}}</td><td>{{money .UnitPrice $currency}}</td><td>{{money .NetAmount $currency}}</td><td>{{money .TaxAmount $currency}}</td><td>{{money .GrossAmount $currency}}</td></tr>
+{{end}}</table>
+<table class="totals">
+{{range totals .Invoice}}<tr{{if .Strong}} class="strong"{{end}}><td>{{.Label}}</td><td>{{money .Amount $currency}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// gormLogger writes GORM's logs to slog: failed queries as errors, slow ones as warnings
+// and every other query at debug level
+type gormLogger struct {
+	logger    *slog.Logger
+	slowQuery time.Duration
+	silent    bool
+}
+
+// NewGormLogger creates a GORM logger writing to logger. Queries taking longer than
+// slowQuery are logged as warnings; 0 disables them.
+func NewGormLogger(logger *slog.Logger, slowQuery time.Duration) gormlogger.Interface {
+	return &gormLogger{logger: logger, slowQuery: slowQuery}
+}
+
+// LogMode implements gormlogger.Interface. Only silencing the logger is supported; the
+// level is otherwise set on the slog logger.
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.silent = level == gormlogger.Silent
+	return &copied
+}
+
+// Info implements gormlogger.Interface.
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if !l.silent {
+		l.logger.InfoContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+// Warn implements gormlogger.Interface.
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if !l.silent {
+		l.logger.WarnContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+// Error implements gormlogger.Interface.
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if !l.silent {
+		l.logger.ErrorContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+// Trace implements gormlogger.Interface.
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	var level slog.Level
+	var msg string
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		level, msg = slog.LevelError, "Query failed"
+	case l.slowQuery > 0 && elapsed > l.slowQuery:
+		level, msg = slog.LevelWarn, "Slow query"
+	default:
+		level, msg = slog.LevelDebug, "Query"
+	}
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if level == slog.LevelError {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
@@ -0,0 +1,58 @@
+package mail
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// SMTPMailer sends emails through an SMTP server
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer creates a mailer for the SMTP server; without a username it sends unauthenticated
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	mailer := &SMTPMailer{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		from: from,
+	}
+	if username != "" {
+		mailer.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return mailer
+}
+
+// Send sends a plain text email
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", headerValue(to))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", headerValue(subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(body)
+
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(msg.String()))
+}
+
+// headerValue keeps a value on a single header line
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
+
+// LogMailer writes emails to the log instead of sending them, for setups without SMTP
+type LogMailer struct {
+	Logger *slog.Logger
+}
+
+// Send logs the email
+func (m LogMailer) Send(to, subject, body string) error {
+	m.Logger.Info("Email", "to", to, "subject", subject, "body", body)
+	return nil
+}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// namespace prefixes every metric name
+const namespace = "unistore"
+
+// Metrics collects the metrics of one backend instance in its own registry, so they can
+// be served on /metrics for Prometheus to scrape
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	dbQueries    *prometheus.HistogramVec
+
+	signups       prometheus.Counter
+	logins        *prometheus.CounterVec
+	ordersCreated *prometheus.CounterVec
+	ordersPaid    *prometheus.CounterVec
+	revenue       *prometheus.CounterVec
+}
+
+// New creates the metrics of the instance, including Go runtime and process metrics
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests handled, by method, route template and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to handle HTTP requests, by method, route template and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		dbQueries: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Time taken by database queries, by operation, table and result.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table", "status"}),
+		signups: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "signups_total",
+			Help:      "Users who signed up.",
+		}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Login attempts, by result (succeeded or failed).",
+		}, []string{"result"}),
+		ordersCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_created_total",
+			Help:      "Orders placed, by currency.",
+		}, []string{"currency"}),
+		ordersPaid: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_paid_total",
+			Help:      "Orders paid, by currency.",
+		}, []string{"currency"}),
+		revenue: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "revenue_total",
+			Help:      "Amount charged for paid orders in major currency units, by currency.",
+		}, []string{"currency"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.dbQueries,
+		m.signups,
+		m.logins,
+		m.ordersCreated,
+		m.ordersPaid,
+		m.revenue,
+	)
+
+	// Show every result up front instead of only once it first happened
+	m.logins.WithLabelValues("succeeded")
+	m.logins.WithLabelValues("failed")
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveRequest records a handled HTTP request
+func (m *Metrics) ObserveRequest(method, route, status string, seconds float64) {
+	m.httpRequests.WithLabelValues(method, route, status).Inc()
+	m.httpDuration.WithLabelValues(method, route, status).Observe(seconds)
+}
+
+// UserSignedUp counts a new user
+func (m *Metrics) UserSignedUp() {
+	m.signups.Inc()
+}
+
+// LoginAttempted counts a login attempt
+func (m *Metrics) LoginAttempted(succeeded bool) {
+	result := "failed"
+	if succeeded {
+		result = "succeeded"
+	}
+	m.logins.WithLabelValues(result).Inc()
+}
+
+// OrderCreated counts a placed order
+func (m *Metrics) OrderCreated(currency string) {
+	m.ordersCreated.WithLabelValues(currency).Inc()
+}
+
+// OrderPaid counts a paid order and adds its total, in minor units, to the revenue
+func (m *Metrics) OrderPaid(total int64, currency string) {
+	m.ordersPaid.WithLabelValues(currency).Inc()
+	m.revenue.WithLabelValues(currency).Add(models.MajorUnits(total, currency))
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BundleRepositoryImpl implementation
+type bundleRepositoryImpl struct {
+	db *database.Database
+}
+
+// NewBundleRepository creates a new bundle repository
+func NewBundleRepository(db *database.Database) models.BundleRepository {
+	return &bundleRepositoryImpl{db: db}
+}
+
+// Create implements models.BundleRepository.
+func (b *bundleRepositoryImpl) Create(ctx context.Context, bundle *models.Bundle) error {
+	return b.db.DB.WithContext(ctx).Omit("Games.*").Create(bundle).Error
+}
+
+// FindByID implements models.BundleRepository.
+func (b *bundleRepositoryImpl) FindByID(ctx context.Context, id int) (*models.Bundle, error) {
+	var bundle models.Bundle
+	err := b.db.DB.WithContext(ctx).Where("id = ?", id).
+		Preload("Games.Prices").
+		First(&bundle).Error
+	return &bundle, err
+}
+
+// FindAll implements models.BundleRepository.
+func (b *bundleRepositoryImpl) FindAll(ctx context.Context, limit int, offset int, activeOnly bool) ([]*models.Bundle, error) {
+	var bundles []*models.Bundle
+	query := b.db.DB.WithContext(ctx).Order("id")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Limit(limit).Offset(offset).
+		Preload("Games.Prices").
+		Find(&bundles).Error
+	return bundles, err
+}
+
+// Update implements models.BundleRepository.
+// The bundle's games are replaced by bundle.Games.
+func (b *bundleRepositoryImpl) Update(ctx context.Context, bundle *models.Bundle) error {
+	return b.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(bundle).Error; err != nil {
+			return err
+		}
+		return tx.Model(bundle).Omit("Games.*").Association("Games").Replace(bundle.Games)
+	})
+}
+
+// Delete implements models.BundleRepository.
+func (b *bundleRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return b.db.DB.WithContext(ctx).Delete(&models.Bundle{}, id).Error
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/infrastructure/database"
@@ -19,20 +20,21 @@ func NewCartRepository(db *database.Database) models.CartRepository {
 }
 
 // Create creates a new shopping cart
-func (c *cartRepositoryImpl) Create(cart *models.ShoppingCart) error {
-	return c.db.DB.Create(cart).Error
+func (c *cartRepositoryImpl) Create(ctx context.Context, cart *models.ShoppingCart) error {
+	return c.db.DB.WithContext(ctx).Create(cart).Error
 }
 
 // AddGameToCart implements models.CartRepository.
-func (c *cartRepositoryImpl) AddGameToCart(userID int, gameID int, quantity int) error {
-	cart, err := c.FindByUserID(userID)
+// Adding a game already in the cart refreshes the price it was added at.
+func (c *cartRepositoryImpl) AddGameToCart(ctx context.Context, userID int, gameID int, quantity int, price models.Money) error {
+	cart, err := c.FindByUserID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// Create a new cart if not exists
 			cart = &models.ShoppingCart{
 				UserID: userID,
 			}
-			if err := c.db.DB.Create(cart).Error; err != nil {
+			if err := c.db.DB.WithContext(ctx).Create(cart).Error; err != nil {
 				return err
 			}
 		} else {
@@ -40,67 +42,205 @@ func (c *cartRepositoryImpl) AddGameToCart(userID int, gameID int, quantity int)
 		}
 	}
 
-	// Check if the game already exists in the cart
+	// Check if the game is already in the cart for the user themselves
 	var cartItem models.CartItem
-	result := c.db.DB.Where("shopping_cart_id = ? AND game_id = ?", cart.ID, gameID).First(&cartItem)
+	result := c.db.DB.WithContext(ctx).Where("shopping_cart_id = ? AND game_id = ? AND gift_recipient_id IS NULL", cart.ID, gameID).First(&cartItem)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			// Add new item to cart
 			cartItem = models.CartItem{
 				ShoppingCartID: cart.ID,
-				GameID:         gameID,
+				GameID:         &gameID,
 				Quantity:       quantity,
+				AddedPrice:     &price.Amount,
+				AddedCurrency:  price.Currency,
 			}
-			return c.db.DB.Create(&cartItem).Error
+			return c.db.DB.WithContext(ctx).Create(&cartItem).Error
 		}
 		return result.Error
 	}
 
-	// Update quantity of existing item
+	// Update quantity and price of existing item
 	cartItem.Quantity = quantity
-	return c.db.DB.Save(&cartItem).Error
+	cartItem.AddedPrice = &price.Amount
+	cartItem.AddedCurrency = price.Currency
+	return c.db.DB.WithContext(ctx).Save(&cartItem).Error
 }
 
 // ClearCart implements models.CartRepository.
-func (c *cartRepositoryImpl) ClearCart(userID int) error {
-	cart, err := c.FindByUserID(userID)
+func (c *cartRepositoryImpl) ClearCart(ctx context.Context, userID int) error {
+	cart, err := c.FindByUserID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	return c.db.DB.Where("shopping_cart_id = ?", cart.ID).Delete(&models.CartItem{}).Error
+	return c.db.DB.WithContext(ctx).Where("shopping_cart_id = ?", cart.ID).Delete(&models.CartItem{}).Error
 }
 
 // FindByUserID implements models.CartRepository.
-func (c *cartRepositoryImpl) FindByUserID(userID int) (*models.ShoppingCart, error) {
+func (c *cartRepositoryImpl) FindByUserID(ctx context.Context, userID int) (*models.ShoppingCart, error) {
 	var cart models.ShoppingCart
-	err := c.db.DB.Where("user_id = ?", userID).First(&cart).Error
+	err := c.db.DB.WithContext(ctx).Where("user_id = ?", userID).First(&cart).Error
 	return &cart, err
 }
 
 // GetCartItems implements models.CartRepository.
-func (c *cartRepositoryImpl) GetCartItems(userID int) ([]*models.CartItem, error) {
-	cart, err := c.FindByUserID(userID)
+func (c *cartRepositoryImpl) GetCartItems(ctx context.Context, userID int) ([]*models.CartItem, error) {
+	cart, err := c.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Deleted games and bundles are still loaded so the cart can report them as unavailable
 	var cartItems []*models.CartItem
-	err = c.db.DB.Where("shopping_cart_id = ?", cart.ID).
-		Preload("Game").
+	err = c.db.DB.WithContext(ctx).Where("shopping_cart_id = ?", cart.ID).
+		Order("id").
+		Preload("Game", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Game.Restricts").
+		Preload("Game.Prices").
+		Preload("Bundle", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Bundle.Games.Restricts").
+		Preload("Bundle.Games.Prices").
+		Preload("GiftRecipient").
 		Find(&cartItems).Error
 
 	return cartItems, err
 }
 
 // RemoveGameFromCart implements models.CartRepository.
-func (c *cartRepositoryImpl) RemoveGameFromCart(userID int, gameID int) error {
-	cart, err := c.FindByUserID(userID)
+func (c *cartRepositoryImpl) RemoveGameFromCart(ctx context.Context, userID int, gameID int) error {
+	cart, err := c.FindByUserID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	return c.db.DB.Where("shopping_cart_id = ? AND game_id = ?", cart.ID, gameID).
+	return c.db.DB.WithContext(ctx).Where("shopping_cart_id = ? AND game_id = ?", cart.ID, gameID).
 		Delete(&models.CartItem{}).Error
 }
+
+// SetGift implements models.CartRepository.
+// The user's own copy of the game in the cart becomes a gift for the recipient.
+func (c *cartRepositoryImpl) SetGift(ctx context.Context, userID int, gameID int, recipientID int, message string) error {
+	cart, err := c.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	result := c.db.DB.WithContext(ctx).Model(&models.CartItem{}).
+		Where("shopping_cart_id = ? AND game_id = ? AND gift_recipient_id IS NULL", cart.ID, gameID).
+		Updates(map[string]interface{}{
+			"gift_recipient_id": recipientID,
+			"gift_message":      message,
+			"quantity":          1,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// AddGiftCopy implements models.CartRepository.
+func (c *cartRepositoryImpl) AddGiftCopy(ctx context.Context, userID int, gameID int, recipientID int, message string, price models.Money) error {
+	var cart models.ShoppingCart
+	if err := c.db.DB.WithContext(ctx).Where("user_id = ?", userID).
+		Attrs(models.ShoppingCart{UserID: userID}).
+		FirstOrCreate(&cart).Error; err != nil {
+		return err
+	}
+
+	cartItem := &models.CartItem{
+		ShoppingCartID:  cart.ID,
+		GameID:          &gameID,
+		Quantity:        1,
+		AddedPrice:      &price.Amount,
+		AddedCurrency:   price.Currency,
+		GiftRecipientID: &recipientID,
+		GiftMessage:     message,
+	}
+	return c.db.DB.WithContext(ctx).Create(cartItem).Error
+}
+
+// RemoveGift implements models.CartRepository.
+// The gift copy for the recipient becomes the user's own copy, or is dropped when the
+// user's own copy is already in the cart.
+func (c *cartRepositoryImpl) RemoveGift(ctx context.Context, userID int, gameID int, recipientID int) error {
+	cart, err := c.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var gift models.CartItem
+		if err := tx.Where("shopping_cart_id = ? AND game_id = ? AND gift_recipient_id = ?", cart.ID, gameID, recipientID).
+			First(&gift).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.CartItem{}).
+			Where("shopping_cart_id = ? AND game_id = ? AND gift_recipient_id IS NULL", cart.ID, gameID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return tx.Delete(&gift).Error
+		}
+
+		return tx.Model(&gift).Updates(map[string]interface{}{
+			"gift_recipient_id": nil,
+			"gift_message":      "",
+		}).Error
+	})
+}
+
+// SetCoupon implements models.CartRepository.
+func (c *cartRepositoryImpl) SetCoupon(ctx context.Context, userID int, couponID *int) error {
+	cart, err := c.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.db.DB.WithContext(ctx).Model(cart).Update("coupon_id", couponID).Error
+}
+
+// AddBundleToCart implements models.CartRepository.
+// A bundle is always a single copy; adding it again only refreshes the price it was added at.
+func (c *cartRepositoryImpl) AddBundleToCart(ctx context.Context, userID int, bundleID int, price models.Money) error {
+	var cart models.ShoppingCart
+	if err := c.db.DB.WithContext(ctx).Where("user_id = ?", userID).
+		Attrs(models.ShoppingCart{UserID: userID}).
+		FirstOrCreate(&cart).Error; err != nil {
+		return err
+	}
+
+	cartItem := models.CartItem{
+		ShoppingCartID: cart.ID,
+		BundleID:       &bundleID,
+		Quantity:       1,
+	}
+	return c.db.DB.WithContext(ctx).Where("shopping_cart_id = ? AND bundle_id = ?", cart.ID, bundleID).
+		Assign(models.CartItem{AddedPrice: &price.Amount, AddedCurrency: price.Currency}).
+		FirstOrCreate(&cartItem).Error
+}
+
+// RemoveBundleFromCart implements models.CartRepository.
+func (c *cartRepositoryImpl) RemoveBundleFromCart(ctx context.Context, userID int, bundleID int) error {
+	cart, err := c.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	result := c.db.DB.WithContext(ctx).Where("shopping_cart_id = ? AND bundle_id = ?", cart.ID, bundleID).
+		Delete(&models.CartItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/infrastructure/database"
 )
@@ -16,30 +18,30 @@ func NewCategoryRepository(db *database.Database) models.CategoryRepository {
 }
 
 // Create implements models.CategoryRepository.
-func (c *categoryRepositoryImpl) Create(category *models.Category) error {
-	return c.db.DB.Create(category).Error
+func (c *categoryRepositoryImpl) Create(ctx context.Context, category *models.Category) error {
+	return c.db.DB.WithContext(ctx).Create(category).Error
 }
 
 // Delete implements models.CategoryRepository.
-func (c *categoryRepositoryImpl) Delete(id int) error {
-	return c.db.DB.Delete(&models.Category{}, id).Error
+func (c *categoryRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return c.db.DB.WithContext(ctx).Delete(&models.Category{}, id).Error
 }
 
 // FindAll implements models.CategoryRepository.
-func (c *categoryRepositoryImpl) FindAll(limit int, offset int) ([]*models.Category, error) {
+func (c *categoryRepositoryImpl) FindAll(ctx context.Context, limit int, offset int) ([]*models.Category, error) {
 	var categories []*models.Category
-	err := c.db.DB.Limit(limit).Offset(offset).Find(&categories).Error
+	err := c.db.DB.WithContext(ctx).Limit(limit).Offset(offset).Find(&categories).Error
 	return categories, err
 }
 
 // FindByID implements models.CategoryRepository.
-func (c *categoryRepositoryImpl) FindByID(id int) (*models.Category, error) {
+func (c *categoryRepositoryImpl) FindByID(ctx context.Context, id int) (*models.Category, error) {
 	var category models.Category
-	err := c.db.DB.First(&category, id).Error
+	err := c.db.DB.WithContext(ctx).First(&category, id).Error
 	return &category, err
 }
 
 // Update implements models.CategoryRepository.
-func (c *categoryRepositoryImpl) Update(category *models.Category) error {
-	return c.db.DB.Save(category).Error
+func (c *categoryRepositoryImpl) Update(ctx context.Context, category *models.Category) error {
+	return c.db.DB.WithContext(ctx).Save(category).Error
 }
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CouponRepositoryImpl implementation
+type couponRepositoryImpl struct {
+	db *database.Database
+}
+
+// NewCouponRepository creates a new coupon repository
+func NewCouponRepository(db *database.Database) models.CouponRepository {
+	return &couponRepositoryImpl{db: db}
+}
+
+// Create implements models.CouponRepository.
+func (c *couponRepositoryImpl) Create(ctx context.Context, coupon *models.Coupon) error {
+	return c.db.DB.WithContext(ctx).Create(coupon).Error
+}
+
+// FindByID implements models.CouponRepository.
+func (c *couponRepositoryImpl) FindByID(ctx context.Context, id int) (*models.Coupon, error) {
+	var coupon models.Coupon
+	err := c.db.DB.WithContext(ctx).Where("id = ?", id).
+		Preload("Categories").
+		Preload("Developers").
+		First(&coupon).Error
+	return &coupon, err
+}
+
+// FindByCode implements models.CouponRepository.
+func (c *couponRepositoryImpl) FindByCode(ctx context.Context, code string) (*models.Coupon, error) {
+	var coupon models.Coupon
+	err := c.db.DB.WithContext(ctx).Where("code = ?", code).
+		Preload("Categories").
+		Preload("Developers").
+		First(&coupon).Error
+	return &coupon, err
+}
+
+// FindAll implements models.CouponRepository.
+func (c *couponRepositoryImpl) FindAll(ctx context.Context, limit int, offset int) ([]*models.Coupon, error) {
+	var coupons []*models.Coupon
+	err := c.db.DB.WithContext(ctx).Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Preload("Categories").
+		Preload("Developers").
+		Find(&coupons).Error
+	return coupons, err
+}
+
+// Update implements models.CouponRepository.
+func (c *couponRepositoryImpl) Update(ctx context.Context, coupon *models.Coupon) error {
+	return c.db.DB.WithContext(ctx).Omit(clause.Associations).Save(coupon).Error
+}
+
+// CountUserRedemptions implements models.CouponRepository.
+func (c *couponRepositoryImpl) CountUserRedemptions(ctx context.Context, couponID int, userID int) (int64, error) {
+	var count int64
+	err := c.db.DB.WithContext(ctx).Model(&models.CouponRedemption{}).
+		Where("coupon_id = ? AND user_id = ?", couponID, userID).
+		Count(&count).Error
+	return count, err
+}
+
+// redeemCoupon records the order's coupon use within tx. The coupon row is locked
+// so that usage limits hold under concurrent checkouts.
+func redeemCoupon(tx *gorm.DB, order *models.Order) error {
+	var coupon models.Coupon
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&coupon, *order.CouponID).Error; err != nil {
+		return err
+	}
+
+	if coupon.MaxUses > 0 && coupon.Uses >= coupon.MaxUses {
+		return errors.New("coupon usage limit reached")
+	}
+	if coupon.MaxUsesPerUser > 0 {
+		var count int64
+		if err := tx.Model(&models.CouponRedemption{}).
+			Where("coupon_id = ? AND user_id = ?", coupon.ID, order.UserID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= int64(coupon.MaxUsesPerUser) {
+			return errors.New("you have already used this coupon")
+		}
+	}
+
+	redemption := &models.CouponRedemption{
+		CouponID: coupon.ID,
+		UserID:   order.UserID,
+		OrderID:  order.ID,
+		Discount: order.CouponDiscount,
+	}
+	if err := tx.Omit(clause.Associations).Create(redemption).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&coupon).UpdateColumn("uses", gorm.Expr("uses + 1")).Error
+}
+
+// releaseCoupon gives the coupon use of a cancelled or refunded order back within tx
+func releaseCoupon(tx *gorm.DB, order *models.Order) error {
+	result := tx.Where("order_id = ?", order.ID).Delete(&models.CouponRedemption{})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+
+	return tx.Model(&models.Coupon{}).
+		Where("id = ? AND uses > 0", *order.CouponID).
+		UpdateColumn("uses", gorm.Expr("uses - 1")).Error
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/infrastructure/database"
 )
@@ -16,30 +18,61 @@ func NewDeveloperRepository(db *database.Database) models.DeveloperRepository {
 }
 
 // Create implements models.DeveloperRepository.
-func (d *developerRepositoryImpl) Create(developer *models.Developer) error {
-	return d.db.DB.Create(developer).Error
+func (d *developerRepositoryImpl) Create(ctx context.Context, developer *models.Developer) error {
+	return d.db.DB.WithContext(ctx).Create(developer).Error
 }
 
 // Delete implements models.DeveloperRepository.
-func (d *developerRepositoryImpl) Delete(id int) error {
-	return d.db.DB.Delete(&models.Developer{}, id).Error
+func (d *developerRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return d.db.DB.WithContext(ctx).Delete(&models.Developer{}, id).Error
 }
 
 // FindAll implements models.DeveloperRepository.
-func (d *developerRepositoryImpl) FindAll(limit int, offset int) ([]*models.Developer, error) {
+func (d *developerRepositoryImpl) FindAll(ctx context.Context, limit int, offset int) ([]*models.Developer, error) {
 	var developers []*models.Developer
-	err := d.db.DB.Limit(limit).Offset(offset).Find(&developers).Error
+	err := d.db.DB.WithContext(ctx).Limit(limit).Offset(offset).Find(&developers).Error
 	return developers, err
 }
 
 // FindByID implements models.DeveloperRepository.
-func (d *developerRepositoryImpl) FindByID(id int) (*models.Developer, error) {
+func (d *developerRepositoryImpl) FindByID(ctx context.Context, id int) (*models.Developer, error) {
 	var developer models.Developer
-	err := d.db.DB.First(&developer, id).Error
+	err := d.db.DB.WithContext(ctx).First(&developer, id).Error
 	return &developer, err
 }
 
 // Update implements models.DeveloperRepository.
-func (d *developerRepositoryImpl) Update(developer *models.Developer) error {
-	return d.db.DB.Save(developer).Error
+func (d *developerRepositoryImpl) Update(ctx context.Context, developer *models.Developer) error {
+	return d.db.DB.WithContext(ctx).Save(developer).Error
+}
+
+// AddMember implements models.DeveloperRepository.
+func (d *developerRepositoryImpl) AddMember(ctx context.Context, developerID int, userID int) error {
+	member := models.DeveloperMember{DeveloperID: developerID, UserID: userID}
+	return d.db.DB.WithContext(ctx).Where("developer_id = ? AND user_id = ?", developerID, userID).
+		FirstOrCreate(&member).Error
+}
+
+// RemoveMember implements models.DeveloperRepository.
+func (d *developerRepositoryImpl) RemoveMember(ctx context.Context, developerID int, userID int) error {
+	return d.db.DB.WithContext(ctx).Where("developer_id = ? AND user_id = ?", developerID, userID).
+		Delete(&models.DeveloperMember{}).Error
+}
+
+// IsMember implements models.DeveloperRepository.
+func (d *developerRepositoryImpl) IsMember(ctx context.Context, developerID int, userID int) (bool, error) {
+	var count int64
+	err := d.db.DB.WithContext(ctx).Model(&models.DeveloperMember{}).
+		Where("developer_id = ? AND user_id = ?", developerID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindMembers implements models.DeveloperRepository.
+func (d *developerRepositoryImpl) FindMembers(ctx context.Context, developerID int) ([]*models.DeveloperMember, error) {
+	var members []*models.DeveloperMember
+	err := d.db.DB.WithContext(ctx).Where("developer_id = ?", developerID).
+		Preload("User").
+		Find(&members).Error
+	return members, err
 }
@@ -7,32 +7,52 @@ import (
 
 // Factory provides all repositories
 type Factory struct {
-	UserRepository      models.UserRepository
-	RoleRepository      models.RoleRepository
-	GameRepository      models.GameRepository
-	CategoryRepository  models.CategoryRepository
-	DeveloperRepository models.DeveloperRepository
-	CartRepository      models.CartRepository
-	FavoriteRepository  models.FavoriteRepository
-	LibraryRepository   models.LibraryRepository
-	OrderRepository     models.OrderRepository
-	ReviewRepository    models.ReviewRepository
-	RestrictRepository  models.RestrictRepository
+	UserRepository         models.UserRepository
+	RoleRepository         models.RoleRepository
+	GameRepository         models.GameRepository
+	CategoryRepository     models.CategoryRepository
+	DeveloperRepository    models.DeveloperRepository
+	CartRepository         models.CartRepository
+	FavoriteRepository     models.FavoriteRepository
+	LibraryRepository      models.LibraryRepository
+	OrderRepository        models.OrderRepository
+	ReviewRepository       models.ReviewRepository
+	RestrictRepository     models.RestrictRepository
+	PointsRepository       models.PointsRepository
+	StoreCreditRepository  models.StoreCreditRepository
+	GiftRepository         models.GiftRepository
+	RedeemCodeRepository   models.RedeemCodeRepository
+	CouponRepository       models.CouponRepository
+	BundleRepository       models.BundleRepository
+	GuestCartRepository    models.GuestCartRepository
+	NotificationRepository models.NotificationRepository
+	TaxRuleRepository      models.TaxRuleRepository
+	InvoiceRepository      models.InvoiceRepository
 }
 
 // NewFactory creates a new repository factory
 func NewFactory(db *database.Database) *Factory {
 	return &Factory{
-		UserRepository:      NewUserRepository(db),
-		RoleRepository:      NewRoleRepository(db),
-		GameRepository:      NewGameRepository(db),
-		CategoryRepository:  NewCategoryRepository(db),
-		DeveloperRepository: NewDeveloperRepository(db),
-		CartRepository:      NewCartRepository(db),
-		FavoriteRepository:  NewFavoriteRepository(db),
-		LibraryRepository:   NewLibraryRepository(db),
-		OrderRepository:     NewOrderRepository(db),
-		ReviewRepository:    NewReviewRepository(db),
-		RestrictRepository:  NewRestrictRepository(db),
+		UserRepository:         NewUserRepository(db),
+		RoleRepository:         NewRoleRepository(db),
+		GameRepository:         NewGameRepository(db),
+		CategoryRepository:     NewCategoryRepository(db),
+		DeveloperRepository:    NewDeveloperRepository(db),
+		CartRepository:         NewCartRepository(db),
+		FavoriteRepository:     NewFavoriteRepository(db),
+		LibraryRepository:      NewLibraryRepository(db),
+		OrderRepository:        NewOrderRepository(db),
+		ReviewRepository:       NewReviewRepository(db),
+		RestrictRepository:     NewRestrictRepository(db),
+		PointsRepository:       NewPointsRepository(db),
+		StoreCreditRepository:  NewStoreCreditRepository(db),
+		GiftRepository:         NewGiftRepository(db),
+		RedeemCodeRepository:   NewRedeemCodeRepository(db),
+		CouponRepository:       NewCouponRepository(db),
+		BundleRepository:       NewBundleRepository(db),
+		GuestCartRepository:    NewGuestCartRepository(db),
+		NotificationRepository: NewNotificationRepository(db),
+		TaxRuleRepository:      NewTaxRuleRepository(db),
+		InvoiceRepository:      NewInvoiceRepository(db),
 	}
 }
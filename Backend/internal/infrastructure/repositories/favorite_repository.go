@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/infrastructure/database"
@@ -19,20 +20,22 @@ func NewFavoriteRepository(db *database.Database) models.FavoriteRepository {
 }
 
 // Create creates a new favorite list
-func (f *favoriteRepositoryImpl) Create(favorite *models.Favorite) error {
-	return f.db.DB.Create(favorite).Error
+func (f *favoriteRepositoryImpl) Create(ctx context.Context, favorite *models.Favorite) error {
+	return f.db.DB.WithContext(ctx).Create(favorite).Error
 }
 
 // AddGameToFavorite implements models.FavoriteRepository.
-func (f *favoriteRepositoryImpl) AddGameToFavorite(userID int, gameID int) error {
-	favorite, err := f.FindByUserID(userID)
+// The game's current price and release state are the baseline for wishlist notifications;
+// without a price the first check sets the baseline.
+func (f *favoriteRepositoryImpl) AddGameToFavorite(ctx context.Context, userID int, gameID int, price *models.Money, released bool) error {
+	favorite, err := f.FindByUserID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// Create a new favorite list if not exists
 			favorite = &models.Favorite{
 				UserID: userID,
 			}
-			if err := f.db.DB.Create(favorite).Error; err != nil {
+			if err := f.db.DB.WithContext(ctx).Create(favorite).Error; err != nil {
 				return err
 			}
 		} else {
@@ -42,16 +45,21 @@ func (f *favoriteRepositoryImpl) AddGameToFavorite(userID int, gameID int) error
 
 	// Check if the game already exists in favorites
 	var favoriteItem models.FavoriteItem
-	result := f.db.DB.Where("favorite_id = ? AND game_id = ?", favorite.ID, gameID).First(&favoriteItem)
+	result := f.db.DB.WithContext(ctx).Where("favorite_id = ? AND game_id = ?", favorite.ID, gameID).First(&favoriteItem)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			// Add new item to favorites
 			favoriteItem = models.FavoriteItem{
-				FavoriteID: favorite.ID,
-				GameID:     gameID,
+				FavoriteID:      favorite.ID,
+				GameID:          gameID,
+				ReleaseNotified: released,
 			}
-			return f.db.DB.Create(&favoriteItem).Error
+			if price != nil {
+				favoriteItem.WatchedPrice = &price.Amount
+				favoriteItem.WatchedCurrency = price.Currency
+			}
+			return f.db.DB.WithContext(ctx).Create(&favoriteItem).Error
 		}
 		return result.Error
 	}
@@ -61,44 +69,83 @@ func (f *favoriteRepositoryImpl) AddGameToFavorite(userID int, gameID int) error
 }
 
 // ClearFavorite implements models.FavoriteRepository.
-func (f *favoriteRepositoryImpl) ClearFavorite(userID int) error {
-	favorite, err := f.FindByUserID(userID)
+func (f *favoriteRepositoryImpl) ClearFavorite(ctx context.Context, userID int) error {
+	favorite, err := f.FindByUserID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	return f.db.DB.Where("favorite_id = ?", favorite.ID).Delete(&models.FavoriteItem{}).Error
+	return f.db.DB.WithContext(ctx).Where("favorite_id = ?", favorite.ID).Delete(&models.FavoriteItem{}).Error
 }
 
 // FindByUserID implements models.FavoriteRepository.
-func (f *favoriteRepositoryImpl) FindByUserID(userID int) (*models.Favorite, error) {
+func (f *favoriteRepositoryImpl) FindByUserID(ctx context.Context, userID int) (*models.Favorite, error) {
 	var favorite models.Favorite
-	err := f.db.DB.Where("user_id = ?", userID).First(&favorite).Error
+	err := f.db.DB.WithContext(ctx).Where("user_id = ?", userID).First(&favorite).Error
 	return &favorite, err
 }
 
 // GetFavoriteItems implements models.FavoriteRepository.
-func (f *favoriteRepositoryImpl) GetFavoriteItems(userID int) ([]*models.FavoriteItem, error) {
-	favorite, err := f.FindByUserID(userID)
+func (f *favoriteRepositoryImpl) GetFavoriteItems(ctx context.Context, userID int) ([]*models.FavoriteItem, error) {
+	favorite, err := f.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	var favoriteItems []*models.FavoriteItem
-	err = f.db.DB.Where("favorite_id = ?", favorite.ID).
-		Preload("Game").
+	err = f.db.DB.WithContext(ctx).Where("favorite_id = ?", favorite.ID).
+		Preload("Game.Prices").
 		Find(&favoriteItems).Error
 
 	return favoriteItems, err
 }
 
 // RemoveGameFromFavorite implements models.FavoriteRepository.
-func (f *favoriteRepositoryImpl) RemoveGameFromFavorite(userID int, gameID int) error {
-	favorite, err := f.FindByUserID(userID)
+func (f *favoriteRepositoryImpl) RemoveGameFromFavorite(ctx context.Context, userID int, gameID int) error {
+	favorite, err := f.FindByUserID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	return f.db.DB.Where("favorite_id = ? AND game_id = ?", favorite.ID, gameID).
+	return f.db.DB.WithContext(ctx).Where("favorite_id = ? AND game_id = ?", favorite.ID, gameID).
 		Delete(&models.FavoriteItem{}).Error
 }
+
+// FindWatchedItems implements models.FavoriteRepository.
+// Items are returned by ID after afterID, with their owner and game, for batched wishlist checks.
+func (f *favoriteRepositoryImpl) FindWatchedItems(ctx context.Context, afterID int, limit int) ([]*models.FavoriteItem, error) {
+	var favoriteItems []*models.FavoriteItem
+	err := f.db.DB.WithContext(ctx).Where("id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Preload("Favorite.User").
+		Preload("Game.Prices").
+		Find(&favoriteItems).Error
+	return favoriteItems, err
+}
+
+// UpdateWatchedPrice implements models.FavoriteRepository.
+// The price is only updated if it is still from, so a change is claimed by a single caller.
+func (f *favoriteRepositoryImpl) UpdateWatchedPrice(ctx context.Context, itemID int, from *models.Money, to models.Money) (bool, error) {
+	query := f.db.DB.WithContext(ctx).Model(&models.FavoriteItem{}).Where("id = ?", itemID)
+	if from == nil {
+		query = query.Where("watched_price IS NULL")
+	} else {
+		query = query.Where("watched_price = ? AND watched_currency = ?", from.Amount, from.Currency)
+	}
+
+	result := query.UpdateColumns(map[string]interface{}{
+		"watched_price":    to.Amount,
+		"watched_currency": to.Currency,
+	})
+	return result.RowsAffected == 1, result.Error
+}
+
+// MarkReleaseNotified implements models.FavoriteRepository.
+// Reports whether this call marked the item, so a release is claimed by a single caller.
+func (f *favoriteRepositoryImpl) MarkReleaseNotified(ctx context.Context, itemID int) (bool, error) {
+	result := f.db.DB.WithContext(ctx).Model(&models.FavoriteItem{}).
+		Where("id = ? AND release_notified = ?", itemID, false).
+		UpdateColumn("release_notified", true)
+	return result.RowsAffected == 1, result.Error
+}
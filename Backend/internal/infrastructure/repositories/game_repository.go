@@ -1,8 +1,13 @@
 package repositories
 
 import (
+	"context"
+
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // GameRepositoryImpl implementation
@@ -16,55 +21,100 @@ func NewGameRepository(db *database.Database) models.GameRepository {
 }
 
 // Create implements models.GameRepository.
-func (g *gameRepositoryImpl) Create(game *models.Game) error {
-	return g.db.DB.Create(game).Error
+func (g *gameRepositoryImpl) Create(ctx context.Context, game *models.Game) error {
+	return g.db.DB.WithContext(ctx).Create(game).Error
 }
 
 // Delete implements models.GameRepository.
-func (g *gameRepositoryImpl) Delete(id int) error {
-	return g.db.DB.Delete(&models.Game{}, id).Error
+func (g *gameRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return g.db.DB.WithContext(ctx).Delete(&models.Game{}, id).Error
 }
 
 // FindAll implements models.GameRepository.
-func (g *gameRepositoryImpl) FindAll(limit int, offset int) ([]*models.Game, error) {
+func (g *gameRepositoryImpl) FindAll(ctx context.Context, limit int, offset int) ([]*models.Game, error) {
+	var games []*models.Game
+	err := g.db.DB.WithContext(ctx).Limit(limit).Offset(offset).
+		Preload("Developer").
+		Preload("Category").
+		Preload("Prices").
+		Find(&games).Error
+	return games, err
+}
+
+// FindDiscounted implements models.GameRepository.
+// Games on sale are returned biggest discount first.
+func (g *gameRepositoryImpl) FindDiscounted(ctx context.Context, limit int) ([]*models.Game, error) {
 	var games []*models.Game
-	err := g.db.DB.Limit(limit).Offset(offset).
+	err := g.db.DB.WithContext(ctx).Where("discount > 0").
+		Order("discount DESC, id").
+		Limit(limit).
 		Preload("Developer").
 		Preload("Category").
+		Preload("Prices").
 		Find(&games).Error
 	return games, err
 }
 
 // FindByCategory implements models.GameRepository.
-func (g *gameRepositoryImpl) FindByCategory(categoryID int) ([]*models.Game, error) {
+func (g *gameRepositoryImpl) FindByCategory(ctx context.Context, categoryID int) ([]*models.Game, error) {
 	var games []*models.Game
-	err := g.db.DB.Where("category_id = ?", categoryID).
+	err := g.db.DB.WithContext(ctx).Where("category_id = ?", categoryID).
 		Preload("Developer").
 		Preload("Category").
+		Preload("Prices").
 		Find(&games).Error
 	return games, err
 }
 
 // FindByDeveloper implements models.GameRepository.
-func (g *gameRepositoryImpl) FindByDeveloper(developerID int) ([]*models.Game, error) {
+func (g *gameRepositoryImpl) FindByDeveloper(ctx context.Context, developerID int) ([]*models.Game, error) {
 	var games []*models.Game
-	err := g.db.DB.Where("developer_id = ?", developerID).
+	err := g.db.DB.WithContext(ctx).Where("developer_id = ?", developerID).
 		Preload("Developer").
 		Preload("Category").
+		Preload("Prices").
 		Find(&games).Error
 	return games, err
 }
 
 // FindByID implements models.GameRepository.
-func (g *gameRepositoryImpl) FindByID(id int) (*models.Game, error) {
+func (g *gameRepositoryImpl) FindByID(ctx context.Context, id int) (*models.Game, error) {
 	var game models.Game
-	err := g.db.DB.Preload("Developer").
+	err := g.db.DB.WithContext(ctx).Preload("Developer").
 		Preload("Category").
+		Preload("Prices").
 		First(&game, id).Error
 	return &game, err
 }
 
 // Update implements models.GameRepository.
-func (g *gameRepositoryImpl) Update(game *models.Game) error {
-	return g.db.DB.Save(game).Error
+// The game's regional prices are replaced by game.Prices.
+func (g *gameRepositoryImpl) Update(ctx context.Context, game *models.Game) error {
+	return g.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Prices").Save(game).Error; err != nil {
+			return err
+		}
+
+		currencies := make([]string, 0, len(game.Prices))
+		for _, price := range game.Prices {
+			price.ID, price.GameID = 0, game.ID // Upserted by currency
+			currencies = append(currencies, price.Currency)
+		}
+
+		stale := tx.Where("game_id = ?", game.ID)
+		if len(currencies) > 0 {
+			stale = stale.Where("currency NOT IN ?", currencies)
+		}
+		if err := stale.Delete(&models.GamePrice{}).Error; err != nil {
+			return err
+		}
+		if len(game.Prices) == 0 {
+			return nil
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "game_id"}, {Name: "currency"}},
+			DoUpdates: clause.AssignmentColumns([]string{"price", "updated_at"}),
+		}).Create(&game.Prices).Error
+	})
 }
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// GiftRepositoryImpl implementation
+type giftRepositoryImpl struct {
+	db *database.Database
+}
+
+// NewGiftRepository creates a new gift repository
+func NewGiftRepository(db *database.Database) models.GiftRepository {
+	return &giftRepositoryImpl{db: db}
+}
+
+// FindByID implements models.GiftRepository.
+func (g *giftRepositoryImpl) FindByID(ctx context.Context, id int) (*models.Gift, error) {
+	var gift models.Gift
+	err := g.db.DB.WithContext(ctx).Where("id = ?", id).
+		Preload("Sender").
+		Preload("Game").
+		Preload("OrderItem.Order.OrderItems").
+		First(&gift).Error
+	return &gift, err
+}
+
+// FindByRecipientID implements models.GiftRepository.
+func (g *giftRepositoryImpl) FindByRecipientID(ctx context.Context, recipientID int) ([]*models.Gift, error) {
+	var gifts []*models.Gift
+	err := g.db.DB.WithContext(ctx).Where("recipient_id = ?", recipientID).
+		Order("created_at DESC").
+		Preload("Sender").
+		Preload("Game").
+		Find(&gifts).Error
+	return gifts, err
+}
+
+// HasPending implements models.GiftRepository.
+func (g *giftRepositoryImpl) HasPending(ctx context.Context, recipientID int, gameID int) (bool, error) {
+	var count int64
+	err := g.db.DB.WithContext(ctx).Model(&models.Gift{}).
+		Where("recipient_id = ? AND game_id = ? AND status = ?", recipientID, gameID, models.GiftStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Accept implements models.GiftRepository.
+// The gift is answered and the game granted to the recipient in a single transaction.
+func (g *giftRepositoryImpl) Accept(ctx context.Context, gift *models.Gift) error {
+	return g.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := respondToGift(tx, gift, models.GiftStatusAccepted); err != nil {
+			return err
+		}
+		return addGameToLibrary(tx, gift.RecipientID, gift.GameID, &gift.OrderItemID)
+	})
+}
+
+// Decline implements models.GiftRepository.
+// The gift is answered, its order item marked as refunded and the sender's points and
+// store credit settled in a single transaction.
+func (g *giftRepositoryImpl) Decline(ctx context.Context, gift *models.Gift, refundAmount int64, entries []*models.PointsLedger, credit *models.StoreCreditLedger) error {
+	return g.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := respondToGift(tx, gift, models.GiftStatusDeclined); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.OrderItem{}).
+			Where("id = ?", gift.OrderItemID).
+			Update("refunded_at", gift.RespondedAt).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Order{}).
+			Where("id = ?", gift.OrderItem.OrderID).
+			UpdateColumn("refunded_amount", gorm.Expr("refunded_amount + ?", refundAmount)).Error; err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			// Earned points the sender has already spent cannot be taken back
+			partial := entry.Reason == models.PointsReasonOrderReversed
+			if err := recordPoints(tx, entry, partial); err != nil {
+				return err
+			}
+		}
+
+		if credit != nil {
+			if err := recordCredit(tx, credit); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// respondToGift moves a pending gift to the given status within tx
+func respondToGift(tx *gorm.DB, gift *models.Gift, status string) error {
+	now := time.Now()
+	result := tx.Model(&models.Gift{}).
+		Where("id = ? AND status = ?", gift.ID, models.GiftStatusPending).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"responded_at": now,
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("gift has already been answered")
+	}
+
+	gift.Status = status
+	gift.RespondedAt = &now
+	gift.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GuestCartRepositoryImpl implementation
+type guestCartRepositoryImpl struct {
+	db *database.Database
+}
+
+// NewGuestCartRepository creates a new guest cart repository
+func NewGuestCartRepository(db *database.Database) models.GuestCartRepository {
+	return &guestCartRepositoryImpl{db: db}
+}
+
+// Create implements models.GuestCartRepository.
+func (g *guestCartRepositoryImpl) Create(ctx context.Context, cart *models.GuestCart) error {
+	return g.db.DB.WithContext(ctx).Omit(clause.Associations).Create(cart).Error
+}
+
+// FindByToken implements models.GuestCartRepository.
+func (g *guestCartRepositoryImpl) FindByToken(ctx context.Context, token string) (*models.GuestCart, error) {
+	var cart models.GuestCart
+	err := g.db.DB.WithContext(ctx).Where("token = ?", token).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Items.Game.Prices").
+		First(&cart).Error
+	return &cart, err
+}
+
+// AddGame implements models.GuestCartRepository.
+func (g *guestCartRepositoryImpl) AddGame(ctx context.Context, cartID int, gameID int) error {
+	return g.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item := models.GuestCartItem{GuestCartID: cartID, GameID: gameID}
+		if err := tx.Where("guest_cart_id = ? AND game_id = ?", cartID, gameID).
+			FirstOrCreate(&item).Error; err != nil {
+			return err
+		}
+		return touchGuestCart(tx, cartID)
+	})
+}
+
+// RemoveGame implements models.GuestCartRepository.
+func (g *guestCartRepositoryImpl) RemoveGame(ctx context.Context, cartID int, gameID int) error {
+	return g.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("guest_cart_id = ? AND game_id = ?", cartID, gameID).
+			Delete(&models.GuestCartItem{}).Error; err != nil {
+			return err
+		}
+		return touchGuestCart(tx, cartID)
+	})
+}
+
+// Clear implements models.GuestCartRepository.
+func (g *guestCartRepositoryImpl) Clear(ctx context.Context, cartID int) error {
+	return g.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("guest_cart_id = ?", cartID).
+			Delete(&models.GuestCartItem{}).Error; err != nil {
+			return err
+		}
+		return touchGuestCart(tx, cartID)
+	})
+}
+
+// MergeIntoUserCart implements models.GuestCartRepository.
+// The given games are added to the user's cart at their current price in the user's currency,
+// skipping games already in it, and the guest cart is deleted in a single transaction.
+func (g *guestCartRepositoryImpl) MergeIntoUserCart(ctx context.Context, cartID int, userID int, games []*models.Game, currency string) error {
+	return g.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cart models.ShoppingCart
+		if err := tx.Where("user_id = ?", userID).
+			Attrs(models.ShoppingCart{UserID: userID}).
+			FirstOrCreate(&cart).Error; err != nil {
+			return err
+		}
+
+		for _, game := range games {
+			item := models.CartItem{ShoppingCartID: cart.ID, GameID: &game.ID, Quantity: 1}
+			if price, ok := game.SalePriceIn(currency); ok {
+				item.AddedPrice, item.AddedCurrency = &price, currency
+			}
+			if err := tx.Where("shopping_cart_id = ? AND game_id = ? AND gift_recipient_id IS NULL", cart.ID, game.ID).
+				FirstOrCreate(&item).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("guest_cart_id = ?", cartID).Delete(&models.GuestCartItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.GuestCart{}, cartID).Error
+	})
+}
+
+// DeleteInactiveSince implements models.GuestCartRepository.
+// Guest carts not touched since the given time are deleted with their items; the number of deleted carts is returned.
+func (g *guestCartRepositoryImpl) DeleteInactiveSince(ctx context.Context, since time.Time) (int64, error) {
+	var deleted int64
+	err := g.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stale := tx.Model(&models.GuestCart{}).Select("id").Where("updated_at < ?", since)
+		if err := tx.Where("guest_cart_id IN (?)", stale).Delete(&models.GuestCartItem{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("updated_at < ?", since).Delete(&models.GuestCart{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
+
+// touchGuestCart records activity on a guest cart within tx
+func touchGuestCart(tx *gorm.DB, cartID int) error {
+	return tx.Model(&models.GuestCart{}).Where("id = ?", cartID).
+		UpdateColumn("updated_at", time.Now()).Error
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// InvoiceRepositoryImpl implementation
+type invoiceRepositoryImpl struct {
+	db *database.Database
+}
+
+// NewInvoiceRepository creates a new invoice repository
+func NewInvoiceRepository(db *database.Database) models.InvoiceRepository {
+	return &invoiceRepositoryImpl{db: db}
+}
+
+// Issue implements models.InvoiceRepository.
+func (i *invoiceRepositoryImpl) Issue(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+	var issued *models.Invoice
+	err := i.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		issued, err = issueInvoice(tx, invoice)
+		return err
+	})
+	return issued, err
+}
+
+// FindByOrderID implements models.InvoiceRepository.
+func (i *invoiceRepositoryImpl) FindByOrderID(ctx context.Context, orderID int) (*models.Invoice, error) {
+	var invoice models.Invoice
+	err := i.db.DB.WithContext(ctx).Where("order_id = ?", orderID).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		First(&invoice).Error
+	return &invoice, err
+}
+
+// issueInvoice numbers and saves the invoice within tx, unless its order already has one.
+// Invoices are numbered one at a time so the sequence has no gaps.
+func issueInvoice(tx *gorm.DB, invoice *models.Invoice) (*models.Invoice, error) {
+	if err := tx.Exec("LOCK TABLE invoice IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+		return nil, err
+	}
+
+	var existing models.Invoice
+	err := tx.Where("order_id = ?", invoice.OrderID).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		First(&existing).Error
+	if err == nil {
+		return &existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var last int
+	if err := tx.Model(&models.Invoice{}).Select("COALESCE(MAX(sequence), 0)").Scan(&last).Error; err != nil {
+		return nil, err
+	}
+	invoice.Sequence = last + 1
+	invoice.Number = models.InvoiceNumber(invoice.Sequence)
+
+	if err := tx.Omit("Order").Create(invoice).Error; err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
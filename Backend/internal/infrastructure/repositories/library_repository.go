@@ -1,7 +1,8 @@
 package repositories
 
 import (
-	"errors"
+	"context"
+	"time"
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/infrastructure/database"
 
@@ -19,65 +20,78 @@ func NewLibraryRepository(db *database.Database) models.LibraryRepository {
 }
 
 // Create creates a new library
-func (l *libraryRepositoryImpl) Create(library *models.Library) error {
-	return l.db.DB.Create(library).Error
+func (l *libraryRepositoryImpl) Create(ctx context.Context, library *models.Library) error {
+	return l.db.DB.WithContext(ctx).Create(library).Error
 }
 
 // AddGameToLibrary implements models.LibraryRepository.
-func (l *libraryRepositoryImpl) AddGameToLibrary(userID int, gameID int) error {
-	library, err := l.FindByUserID(userID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// Create a new library if not exists
-			library = &models.Library{
-				UserID: userID,
-			}
-			if err := l.db.DB.Create(library).Error; err != nil {
-				return err
-			}
-		} else {
-			return err
-		}
-	}
-
-	// Check if the game already exists in the library
-	var libraryItem models.LibraryItem
-	result := l.db.DB.Where("library_id = ? AND game_id = ?", library.ID, gameID).First(&libraryItem)
-
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			// Add new item to library
-			libraryItem = models.LibraryItem{
-				LibraryID: library.ID,
-				GameID:    gameID,
-			}
-			return l.db.DB.Create(&libraryItem).Error
-		}
-		return result.Error
-	}
-
-	// Item already exists, nothing to do
-	return nil
+func (l *libraryRepositoryImpl) AddGameToLibrary(ctx context.Context, userID int, gameID int) error {
+	return addGameToLibrary(l.db.DB.WithContext(ctx), userID, gameID, nil)
 }
 
 // FindByUserID implements models.LibraryRepository.
-func (l *libraryRepositoryImpl) FindByUserID(userID int) (*models.Library, error) {
+func (l *libraryRepositoryImpl) FindByUserID(ctx context.Context, userID int) (*models.Library, error) {
 	var library models.Library
-	err := l.db.DB.Where("user_id = ?", userID).First(&library).Error
+	err := l.db.DB.WithContext(ctx).Where("user_id = ?", userID).First(&library).Error
 	return &library, err
 }
 
 // GetLibraryItems implements models.LibraryRepository.
-func (l *libraryRepositoryImpl) GetLibraryItems(userID int) ([]*models.LibraryItem, error) {
-	library, err := l.FindByUserID(userID)
+func (l *libraryRepositoryImpl) GetLibraryItems(ctx context.Context, userID int) ([]*models.LibraryItem, error) {
+	library, err := l.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	var libraryItems []*models.LibraryItem
-	err = l.db.DB.Where("library_id = ?", library.ID).
+	err = l.db.DB.WithContext(ctx).Where("library_id = ?", library.ID).
 		Preload("Game").
 		Find(&libraryItems).Error
 
 	return libraryItems, err
 }
+
+// HasGame implements models.LibraryRepository.
+func (l *libraryRepositoryImpl) HasGame(ctx context.Context, userID int, gameID int) (bool, error) {
+	var count int64
+	err := l.db.DB.WithContext(ctx).Model(&models.LibraryItem{}).
+		Joins("JOIN library ON library.id = library_item.library_id").
+		Where("library.user_id = ? AND library_item.game_id = ?", userID, gameID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UnlockReleased implements models.LibraryRepository.
+// Pre-ordered games released by now become playable; the number of unlocked items is returned.
+func (l *libraryRepositoryImpl) UnlockReleased(ctx context.Context, now time.Time) (int64, error) {
+	result := l.db.DB.WithContext(ctx).Model(&models.LibraryItem{}).
+		Where("locked = ? AND game_id IN (?)", true,
+			l.db.DB.WithContext(ctx).Model(&models.Game{}).Select("id").Where("release_date <= ?", now)).
+		Updates(map[string]interface{}{
+			"locked":     false,
+			"updated_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// addGameToLibrary grants a game to the user within tx, creating the library if needed.
+// Games not released yet are added locked until their release date. A game the user
+// already owns keeps the order item it was first granted by.
+func addGameToLibrary(tx *gorm.DB, userID int, gameID int, orderItemID *int) error {
+	var library models.Library
+	if err := tx.Where("user_id = ?", userID).
+		Attrs(models.Library{UserID: userID}).
+		FirstOrCreate(&library).Error; err != nil {
+		return err
+	}
+
+	var game models.Game
+	if err := tx.Unscoped().Select("id", "release_date").Where("id = ?", gameID).First(&game).Error; err != nil {
+		return err
+	}
+
+	libraryItem := models.LibraryItem{LibraryID: library.ID, GameID: gameID}
+	return tx.Where("library_id = ? AND game_id = ?", library.ID, gameID).
+		Attrs(models.LibraryItem{Locked: game.ReleaseDate.After(time.Now()), OrderItemID: orderItemID}).
+		FirstOrCreate(&libraryItem).Error
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationRepositoryImpl implementation
+type notificationRepositoryImpl struct {
+	db *database.Database
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *database.Database) models.NotificationRepository {
+	return &notificationRepositoryImpl{db: db}
+}
+
+// Create implements models.NotificationRepository.
+func (n *notificationRepositoryImpl) Create(ctx context.Context, notification *models.Notification) error {
+	return n.db.DB.WithContext(ctx).Create(notification).Error
+}
+
+// FindByUserID implements models.NotificationRepository.
+func (n *notificationRepositoryImpl) FindByUserID(ctx context.Context, userID int, unreadOnly bool, limit int, offset int) ([]*models.Notification, error) {
+	query := n.db.DB.WithContext(ctx).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []*models.Notification
+	err := query.Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Preload("Game").
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// CountUnread implements models.NotificationRepository.
+func (n *notificationRepositoryImpl) CountUnread(ctx context.Context, userID int) (int64, error) {
+	var count int64
+	err := n.db.DB.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead implements models.NotificationRepository.
+// Returns gorm.ErrRecordNotFound when the user has no such notification.
+func (n *notificationRepositoryImpl) MarkRead(ctx context.Context, userID int, id int) error {
+	var notification models.Notification
+	if err := n.db.DB.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		return err
+	}
+	if notification.ReadAt != nil {
+		return nil
+	}
+
+	return n.db.DB.WithContext(ctx).Model(&notification).UpdateColumn("read_at", time.Now()).Error
+}
+
+// MarkAllRead implements models.NotificationRepository.
+func (n *notificationRepositoryImpl) MarkAllRead(ctx context.Context, userID int) error {
+	return n.db.DB.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		UpdateColumn("read_at", time.Now()).Error
+}
+
+// MarkEmailed implements models.NotificationRepository.
+func (n *notificationRepositoryImpl) MarkEmailed(ctx context.Context, id int) error {
+	return n.db.DB.WithContext(ctx).Model(&models.Notification{}).
+		Where("id = ?", id).
+		UpdateColumn("emailed_at", time.Now()).Error
+}
+
+// FindPreference implements models.NotificationRepository.
+// Users who never changed their preferences get in-app price drop and release notifications, without email.
+func (n *notificationRepositoryImpl) FindPreference(ctx context.Context, userID int) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+	err := n.db.DB.WithContext(ctx).Where("user_id = ?", userID).First(&preference).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.NotificationPreference{
+			UserID:     userID,
+			PriceDrops: true,
+			Releases:   true,
+		}, nil
+	}
+	return &preference, err
+}
+
+// SavePreference implements models.NotificationRepository.
+func (n *notificationRepositoryImpl) SavePreference(ctx context.Context, preference *models.NotificationPreference) error {
+	return n.db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price_drops", "releases", "email", "updated_at"}),
+	}).Create(preference).Error
+}
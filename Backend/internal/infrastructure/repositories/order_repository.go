@@ -1,11 +1,14 @@
 package repositories
 
 import (
+	"context"
 	"errors"
+	"time"
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/infrastructure/database"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // OrderRepositoryImpl implementation
@@ -19,159 +22,338 @@ func NewOrderRepository(db *database.Database) models.OrderRepository {
 }
 
 // Create implements models.OrderRepository.
-func (o *orderRepositoryImpl) Create(order *models.Order) error {
-	return o.db.DB.Create(order).Error
+func (o *orderRepositoryImpl) Create(ctx context.Context, order *models.Order) error {
+	return o.db.DB.WithContext(ctx).Create(order).Error
 }
 
-// CreateFromCart implements models.OrderRepository.
-func (o *orderRepositoryImpl) CreateFromCart(userID int) (*models.Order, error) {
-	// Start a transaction
-	tx := o.db.DB.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	// Get user's cart
-	var cart models.ShoppingCart
-	if err := tx.Where("user_id = ?", userID).First(&cart).Error; err != nil {
-		tx.Rollback()
-		return nil, err
-	}
+// PlaceOrder implements models.OrderRepository.
+// The order with its items and the games of its bundles is saved, the coupon use
+// recorded, redeemed points and spent store credit debited and the ordered items taken
+// out of the user's cart in a single transaction. Items added to the cart after it was reviewed stay in it.
+func (o *orderRepositoryImpl) PlaceOrder(ctx context.Context, order *models.Order, cartItemIDs []int) error {
+	return o.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(order).Error; err != nil {
+			return err
+		}
+		for _, item := range order.OrderItems {
+			item.OrderID = order.ID
+			if err := tx.Omit(clause.Associations).Create(item).Error; err != nil {
+				return err
+			}
+			for _, game := range item.BundleGames {
+				game.OrderItemID = item.ID
+			}
+			if len(item.BundleGames) > 0 {
+				if err := tx.Create(item.BundleGames).Error; err != nil {
+					return err
+				}
+			}
+		}
 
-	// Get cart items
-	var cartItems []*models.CartItem
-	if err := tx.Where("shopping_cart_id = ?", cart.ID).Preload("Game").Find(&cartItems).Error; err != nil {
-		tx.Rollback()
-		return nil, err
-	}
+		if order.CouponID != nil {
+			if err := redeemCoupon(tx, order); err != nil {
+				return err
+			}
+		}
 
-	// Check if cart is empty
-	if len(cartItems) == 0 {
-		tx.Rollback()
-		return nil, errors.New("cart is empty")
-	}
+		if order.PointsRedeemed > 0 {
+			entry := &models.PointsLedger{
+				UserID:  order.UserID,
+				Amount:  -order.PointsRedeemed,
+				Reason:  models.PointsReasonOrderRedeemed,
+				OrderID: &order.ID,
+			}
+			if err := recordPoints(tx, entry, false); err != nil {
+				return err
+			}
+		}
 
-	// Calculate total cost
-	var totalCost float64
-	for _, item := range cartItems {
-		totalCost += item.Game.Price * float64(item.Quantity)
-	}
+		if order.CreditPaid > 0 {
+			entry := &models.StoreCreditLedger{
+				UserID:   order.UserID,
+				Currency: order.Currency,
+				Amount:   -order.CreditPaid,
+				Reason:   models.CreditReasonOrderPaid,
+				OrderID:  &order.ID,
+			}
+			if err := recordCredit(tx, entry); err != nil {
+				return err
+			}
+		}
 
-	// Create new order
-	order := &models.Order{
-		UserID:    userID,
-		TotalCost: totalCost,
-		Status:    "pending",
-	}
+		var cart models.ShoppingCart
+		if err := tx.Where("user_id = ?", order.UserID).First(&cart).Error; err != nil {
+			return err
+		}
+		if order.CouponID != nil {
+			if err := tx.Model(&cart).Where("coupon_id = ?", *order.CouponID).
+				Update("coupon_id", nil).Error; err != nil {
+				return err
+			}
+		}
+		if len(cartItemIDs) == 0 {
+			return nil
+		}
+		return tx.Where("shopping_cart_id = ? AND id IN ?", cart.ID, cartItemIDs).Delete(&models.CartItem{}).Error
+	})
+}
 
-	if err := tx.Create(order).Error; err != nil {
-		tx.Rollback()
-		return nil, err
-	}
+// TransitionStatus implements models.OrderRepository.
+// The status change only applies if the order is still in fromStatus, so concurrent
+// transitions cannot apply the accompanying ledger entries twice. Paid orders are
+// fulfilled and refunded orders have their remaining items marked as refunded in
+// the same transaction.
+func (o *orderRepositoryImpl) TransitionStatus(ctx context.Context, order *models.Order, fromStatus string, entries []*models.PointsLedger, credit *models.StoreCreditLedger, invoice *models.Invoice) error {
+	return o.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Order{}).
+			Where("id = ? AND status = ?", order.ID, fromStatus).
+			Updates(map[string]interface{}{
+				"status":          order.Status,
+				"points_earned":   order.PointsEarned,
+				"refunded_amount": order.RefundedAmount,
+				"updated_at":      order.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("order status has changed")
+		}
 
-	// Create order items
-	for _, cartItem := range cartItems {
-		orderItem := &models.OrderItem{
-			OrderID:  order.ID,
-			GameID:   cartItem.GameID,
-			Price:    cartItem.Game.Price,
-			Quantity: cartItem.Quantity,
+		switch order.Status {
+		case models.OrderStatusPaid:
+			if err := fulfilOrder(tx, order); err != nil {
+				return err
+			}
+			if invoice != nil {
+				if _, err := issueInvoice(tx, invoice); err != nil {
+					return err
+				}
+			}
+		case models.OrderStatusRefunded:
+			if err := refundOrderItems(tx, order); err != nil {
+				return err
+			}
 		}
-		if err := tx.Create(orderItem).Error; err != nil {
-			tx.Rollback()
-			return nil, err
+
+		// Closed orders no longer count towards coupon limits
+		if order.CouponID != nil && (order.Status == models.OrderStatusCancelled || order.Status == models.OrderStatusRefunded) {
+			if err := releaseCoupon(tx, order); err != nil {
+				return err
+			}
 		}
-	}
 
-	// Add games to user's library
-	var library models.Library
-	result := tx.Where("user_id = ?", userID).First(&library)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			// Create library if not exists
-			library = models.Library{
-				UserID: userID,
+		for _, entry := range entries {
+			// Earned points the user has already spent cannot be taken back
+			partial := entry.Reason == models.PointsReasonOrderReversed
+			if err := recordPoints(tx, entry, partial); err != nil {
+				return err
 			}
-			if err := tx.Create(&library).Error; err != nil {
-				tx.Rollback()
-				return nil, err
+		}
+
+		if credit != nil {
+			if err := recordCredit(tx, credit); err != nil {
+				return err
 			}
-		} else {
-			tx.Rollback()
-			return nil, result.Error
 		}
-	}
 
-	// Add games to library
-	for _, cartItem := range cartItems {
-		// Check if game already in library
-		var libraryItem models.LibraryItem
-		result := tx.Where("library_id = ? AND game_id = ?", library.ID, cartItem.GameID).First(&libraryItem)
-		if result.Error != nil {
-			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-				// Add to library
-				libraryItem = models.LibraryItem{
-					LibraryID: library.ID,
-					GameID:    cartItem.GameID,
-				}
-				if err := tx.Create(&libraryItem).Error; err != nil {
-					tx.Rollback()
-					return nil, err
+		return nil
+	})
+}
+
+// fulfilOrder grants the order's games, including every game its bundles had when ordered,
+// to the buyer and sends gift items to their recipients
+func fulfilOrder(tx *gorm.DB, order *models.Order) error {
+	for _, item := range order.OrderItems {
+		if item.BundleID != nil {
+			var gameIDs []int
+			if err := tx.Model(&models.OrderItemGame{}).Where("order_item_id = ?", item.ID).
+				Pluck("game_id", &gameIDs).Error; err != nil {
+				return err
+			}
+			for _, gameID := range gameIDs {
+				if err := addGameToLibrary(tx, order.UserID, gameID, &item.ID); err != nil {
+					return err
 				}
-			} else {
-				tx.Rollback()
-				return nil, result.Error
 			}
+			continue
+		}
+
+		if item.GiftRecipientID == nil {
+			if err := addGameToLibrary(tx, order.UserID, *item.GameID, &item.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		gift := &models.Gift{
+			OrderItemID: item.ID,
+			SenderID:    order.UserID,
+			RecipientID: *item.GiftRecipientID,
+			GameID:      *item.GameID,
+			Message:     item.GiftMessage,
+			Status:      models.GiftStatusPending,
+		}
+		if err := tx.Omit(clause.Associations).Create(gift).Error; err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	// Clear cart
-	if err := tx.Where("shopping_cart_id = ?", cart.ID).Delete(&models.CartItem{}).Error; err != nil {
-		tx.Rollback()
-		return nil, err
+// refundOrderItems marks the order's remaining items as refunded, withdraws their unanswered
+// gifts and takes back every copy they granted, from the buyer and from gift recipients alike
+func refundOrderItems(tx *gorm.DB, order *models.Order) error {
+	now := time.Now()
+	if err := tx.Model(&models.OrderItem{}).
+		Where("order_id = ? AND refunded_at IS NULL", order.ID).
+		Update("refunded_at", now).Error; err != nil {
+		return err
 	}
 
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return nil, err
+	orderItemIDs := tx.Model(&models.OrderItem{}).Select("id").Where("order_id = ?", order.ID)
+	if err := tx.Where("status = ? AND order_item_id IN (?)", models.GiftStatusPending, orderItemIDs).
+		Delete(&models.Gift{}).Error; err != nil {
+		return err
 	}
 
-	return order, nil
+	return tx.Where("order_item_id IN (?)", orderItemIDs).Delete(&models.LibraryItem{}).Error
 }
 
 // FindAll implements models.OrderRepository.
-func (o *orderRepositoryImpl) FindAll(limit int, offset int) ([]*models.Order, error) {
+func (o *orderRepositoryImpl) FindAll(ctx context.Context, limit int, offset int) ([]*models.Order, error) {
 	var orders []*models.Order
-	err := o.db.DB.Limit(limit).Offset(offset).
+	err := o.db.DB.WithContext(ctx).Limit(limit).Offset(offset).
 		Preload("OrderItems").
 		Preload("OrderItems.Game").
+		Preload("OrderItems.Bundle.Games").
+		Preload("Coupon").
 		Find(&orders).Error
 	return orders, err
 }
 
 // FindByID implements models.OrderRepository.
-func (o *orderRepositoryImpl) FindByID(id int) (*models.Order, error) {
+func (o *orderRepositoryImpl) FindByID(ctx context.Context, id int) (*models.Order, error) {
 	var order models.Order
-	err := o.db.DB.Where("id = ?", id).
+	err := o.db.DB.WithContext(ctx).Where("id = ?", id).
 		Preload("OrderItems").
 		Preload("OrderItems.Game").
+		Preload("OrderItems.Bundle.Games").
+		Preload("Coupon").
 		First(&order).Error
 	return &order, err
 }
 
 // FindByUserID implements models.OrderRepository.
-func (o *orderRepositoryImpl) FindByUserID(userID int) ([]*models.Order, error) {
+func (o *orderRepositoryImpl) FindByUserID(ctx context.Context, userID int) ([]*models.Order, error) {
 	var orders []*models.Order
-	err := o.db.DB.Where("user_id = ?", userID).
+	err := o.db.DB.WithContext(ctx).Where("user_id = ?", userID).
 		Preload("OrderItems").
 		Preload("OrderItems.Game").
+		Preload("OrderItems.Bundle.Games").
+		Preload("Coupon").
 		Find(&orders).Error
 	return orders, err
 }
 
+// HasPendingGame implements models.OrderRepository.
+// Games bought for the user themselves count, alone or as part of a bundle; gifts do not.
+func (o *orderRepositoryImpl) HasPendingGame(ctx context.Context, userID int, gameID int) (bool, error) {
+	var count int64
+	err := o.db.DB.WithContext(ctx).Model(&models.OrderItem{}).
+		Joins("JOIN \"order\" ON \"order\".id = order_item.order_id AND \"order\".deleted_at IS NULL").
+		Where("\"order\".user_id = ? AND \"order\".status = ?", userID, models.OrderStatusPending).
+		Where("order_item.gift_recipient_id IS NULL").
+		Where("order_item.game_id = ? OR order_item.id IN (?)", gameID,
+			o.db.DB.WithContext(ctx).Model(&models.OrderItemGame{}).Select("order_item_id").Where("game_id = ?", gameID)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindItemByID implements models.OrderRepository.
+// The item comes with its game and its order, including all of the order's items.
+func (o *orderRepositoryImpl) FindItemByID(ctx context.Context, id int) (*models.OrderItem, error) {
+	var item models.OrderItem
+	err := o.db.DB.WithContext(ctx).Where("id = ?", id).
+		Preload("Game").
+		Preload("Order.OrderItems").
+		First(&item).Error
+	return &item, err
+}
+
+// CancelPreOrderItem implements models.OrderRepository.
+// The item is marked refunded, its share refunded on the order and its locked copy
+// taken back, or its unanswered gift withdrawn, in a single transaction.
+func (o *orderRepositoryImpl) CancelPreOrderItem(ctx context.Context, order *models.Order, item *models.OrderItem, refundAmount int64, entries []*models.PointsLedger, credit *models.StoreCreditLedger) error {
+	return o.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.OrderItem{}).
+			Where("id = ? AND refunded_at IS NULL", item.ID).
+			Update("refunded_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("pre-order is already cancelled")
+		}
+
+		// The copy is with the buyer, or with the gift recipient once accepted
+		ownerID := order.UserID
+		if item.GiftRecipientID != nil {
+			var gift models.Gift
+			if err := tx.Where("order_item_id = ?", item.ID).First(&gift).Error; err != nil {
+				return err
+			}
+			if gift.Status == models.GiftStatusPending {
+				if err := tx.Delete(&gift).Error; err != nil {
+					return err
+				}
+				ownerID = 0
+			} else {
+				ownerID = gift.RecipientID
+			}
+		}
+
+		// Only a copy still locked can be taken back; a released game stays with its owner
+		if ownerID != 0 {
+			result := tx.Where("game_id = ? AND locked = ? AND library_id IN (?)", *item.GameID, true,
+				tx.Model(&models.Library{}).Select("id").Where("user_id = ?", ownerID)).
+				Delete(&models.LibraryItem{})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errors.New("game has already been released")
+			}
+		}
+
+		if err := tx.Model(&models.Order{}).
+			Where("id = ?", order.ID).
+			UpdateColumn("refunded_amount", gorm.Expr("refunded_amount + ?", refundAmount)).Error; err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			// Earned points the user has already spent cannot be taken back
+			partial := entry.Reason == models.PointsReasonOrderReversed
+			if err := recordPoints(tx, entry, partial); err != nil {
+				return err
+			}
+		}
+
+		if credit != nil {
+			if err := recordCredit(tx, credit); err != nil {
+				return err
+			}
+		}
+
+		item.RefundedAt = &now
+		order.RefundedAmount += refundAmount
+		return nil
+	})
+}
+
 // Update implements models.OrderRepository.
-func (o *orderRepositoryImpl) Update(order *models.Order) error {
-	return o.db.DB.Save(order).Error
+func (o *orderRepositoryImpl) Update(ctx context.Context, order *models.Order) error {
+	return o.db.DB.WithContext(ctx).Save(order).Error
 }
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PointsRepositoryImpl implementation
+type pointsRepositoryImpl struct {
+	db *database.Database
+}
+
+// NewPointsRepository creates a new points repository
+func NewPointsRepository(db *database.Database) models.PointsRepository {
+	return &pointsRepositoryImpl{db: db}
+}
+
+// Record implements models.PointsRepository.
+func (p *pointsRepositoryImpl) Record(ctx context.Context, entry *models.PointsLedger) error {
+	return p.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return recordPoints(tx, entry, false)
+	})
+}
+
+// FindByUserID implements models.PointsRepository.
+func (p *pointsRepositoryImpl) FindByUserID(ctx context.Context, userID int, limit int, offset int) ([]*models.PointsLedger, error) {
+	var entries []*models.PointsLedger
+	err := p.db.DB.WithContext(ctx).Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&entries).Error
+	return entries, err
+}
+
+// recordPoints applies a ledger entry to the user's balance within tx.
+// A debit larger than the balance fails unless partial is set, in which case
+// only the available balance is debited.
+func recordPoints(tx *gorm.DB, entry *models.PointsLedger, partial bool) error {
+	var user models.User
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "points").
+		First(&user, entry.UserID).Error; err != nil {
+		return err
+	}
+
+	if user.Points+entry.Amount < 0 {
+		if !partial {
+			return errors.New("insufficient points")
+		}
+		entry.Amount = -user.Points
+	}
+	if entry.Amount == 0 {
+		return nil
+	}
+
+	entry.Balance = user.Points + entry.Amount
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if err := tx.Model(&user).UpdateColumn("points", entry.Balance).Error; err != nil {
+		return err
+	}
+
+	return tx.Omit(clause.Associations).Create(entry).Error
+}
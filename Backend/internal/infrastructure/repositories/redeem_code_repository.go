@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RedeemCodeRepositoryImpl implementation
+type redeemCodeRepositoryImpl struct {
+	db *database.Database
+}
+
+// NewRedeemCodeRepository creates a new redeem code repository
+func NewRedeemCodeRepository(db *database.Database) models.RedeemCodeRepository {
+	return &redeemCodeRepositoryImpl{db: db}
+}
+
+// CreateBatch implements models.RedeemCodeRepository.
+func (r *redeemCodeRepositoryImpl) CreateBatch(ctx context.Context, codes []*models.RedeemCode) error {
+	return r.db.DB.WithContext(ctx).Omit(clause.Associations).CreateInBatches(codes, 100).Error
+}
+
+// FindAll implements models.RedeemCodeRepository.
+func (r *redeemCodeRepositoryImpl) FindAll(ctx context.Context, limit int, offset int) ([]*models.RedeemCode, error) {
+	var codes []*models.RedeemCode
+	err := r.db.DB.WithContext(ctx).Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Preload("Game").
+		Preload("RedeemCodeUses").
+		Find(&codes).Error
+	return codes, err
+}
+
+// Redeem implements models.RedeemCodeRepository.
+// The code is locked while its use is recorded and the game or credit granted,
+// so a code can never be redeemed more often than allowed.
+func (r *redeemCodeRepositoryImpl) Redeem(ctx context.Context, code string, userID int) (*models.RedeemCode, error) {
+	var redeemCode models.RedeemCode
+	err := r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("code = ?", code).
+			First(&redeemCode).Error; err != nil {
+			return err
+		}
+
+		if redeemCode.ExpiresAt != nil && time.Now().After(*redeemCode.ExpiresAt) {
+			return errors.New("code has expired")
+		}
+		if redeemCode.Uses >= redeemCode.MaxUses {
+			return errors.New("code has already been used")
+		}
+
+		var count int64
+		if err := tx.Model(&models.RedeemCodeUse{}).
+			Where("redeem_code_id = ? AND user_id = ?", redeemCode.ID, userID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("you have already redeemed this code")
+		}
+
+		if redeemCode.GameID != nil {
+			if err := tx.Model(&models.LibraryItem{}).
+				Joins("JOIN library ON library.id = library_item.library_id").
+				Where("library.user_id = ? AND library_item.game_id = ?", userID, *redeemCode.GameID).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return errors.New("you already own this game")
+			}
+			if err := addGameToLibrary(tx, userID, *redeemCode.GameID, nil); err != nil {
+				return err
+			}
+		}
+
+		if redeemCode.CreditAmount > 0 {
+			entry := &models.StoreCreditLedger{
+				UserID:       userID,
+				Currency:     redeemCode.CreditCurrency,
+				Amount:       redeemCode.CreditAmount,
+				Reason:       models.CreditReasonCodeRedeemed,
+				RedeemCodeID: &redeemCode.ID,
+				Note:         redeemCode.Code,
+			}
+			if err := recordCredit(tx, entry); err != nil {
+				return err
+			}
+		}
+
+		// Codes generated before store credit had its own balance still credit points
+		if redeemCode.CreditPoints > 0 {
+			entry := &models.PointsLedger{
+				UserID: userID,
+				Amount: redeemCode.CreditPoints,
+				Reason: models.PointsReasonCodeRedeemed,
+				Note:   redeemCode.Code,
+			}
+			if err := recordPoints(tx, entry, false); err != nil {
+				return err
+			}
+		}
+
+		use := &models.RedeemCodeUse{RedeemCodeID: redeemCode.ID, UserID: userID}
+		if err := tx.Create(use).Error; err != nil {
+			return err
+		}
+
+		redeemCode.Uses++
+		return tx.Model(&redeemCode).UpdateColumn("uses", redeemCode.Uses).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &redeemCode, nil
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/infrastructure/database"
 )
@@ -16,39 +18,39 @@ func NewRestrictRepository(db *database.Database) models.RestrictRepository {
 }
 
 // Create implements models.RestrictRepository.
-func (r *restrictRepositoryImpl) Create(restrict *models.Restrict) error {
-	return r.db.DB.Create(restrict).Error
+func (r *restrictRepositoryImpl) Create(ctx context.Context, restrict *models.Restrict) error {
+	return r.db.DB.WithContext(ctx).Create(restrict).Error
 }
 
 // Delete implements models.RestrictRepository.
-func (r *restrictRepositoryImpl) Delete(id int) error {
-	return r.db.DB.Delete(&models.Restrict{}, id).Error
+func (r *restrictRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return r.db.DB.WithContext(ctx).Delete(&models.Restrict{}, id).Error
 }
 
 // FindAll implements models.RestrictRepository.
-func (r *restrictRepositoryImpl) FindAll(limit int, offset int) ([]*models.Restrict, error) {
+func (r *restrictRepositoryImpl) FindAll(ctx context.Context, limit int, offset int) ([]*models.Restrict, error) {
 	var restricts []*models.Restrict
-	err := r.db.DB.Limit(limit).Offset(offset).
+	err := r.db.DB.WithContext(ctx).Limit(limit).Offset(offset).
 		Preload("Game").
 		Find(&restricts).Error
 	return restricts, err
 }
 
 // FindByGameID implements models.RestrictRepository.
-func (r *restrictRepositoryImpl) FindByGameID(gameID int) ([]*models.Restrict, error) {
+func (r *restrictRepositoryImpl) FindByGameID(ctx context.Context, gameID int) ([]*models.Restrict, error) {
 	var restricts []*models.Restrict
-	err := r.db.DB.Where("game_id = ?", gameID).Find(&restricts).Error
+	err := r.db.DB.WithContext(ctx).Where("game_id = ?", gameID).Find(&restricts).Error
 	return restricts, err
 }
 
 // FindByID implements models.RestrictRepository.
-func (r *restrictRepositoryImpl) FindByID(id int) (*models.Restrict, error) {
+func (r *restrictRepositoryImpl) FindByID(ctx context.Context, id int) (*models.Restrict, error) {
 	var restrict models.Restrict
-	err := r.db.DB.First(&restrict, id).Error
+	err := r.db.DB.WithContext(ctx).First(&restrict, id).Error
 	return &restrict, err
 }
 
 // Update implements models.RestrictRepository.
-func (r *restrictRepositoryImpl) Update(restrict *models.Restrict) error {
-	return r.db.DB.Save(restrict).Error
+func (r *restrictRepositoryImpl) Update(ctx context.Context, restrict *models.Restrict) error {
+	return r.db.DB.WithContext(ctx).Save(restrict).Error
 }
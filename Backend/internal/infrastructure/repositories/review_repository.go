@@ -1,8 +1,12 @@
 package repositories
 
 import (
+	"context"
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ReviewRepositoryImpl implementation
@@ -16,44 +20,274 @@ func NewReviewRepository(db *database.Database) models.ReviewRepository {
 }
 
 // Create implements models.ReviewRepository.
-func (r *reviewRepositoryImpl) Create(review *models.Review) error {
-	return r.db.DB.Create(review).Error
+// gorm.ErrDuplicatedKey is returned when the user has already reviewed the game.
+func (r *reviewRepositoryImpl) Create(ctx context.Context, review *models.Review) error {
+	return createReview(r.db.DB.WithContext(ctx), review)
+}
+
+// CreateWithBonus implements models.ReviewRepository.
+// The bonus is credited together with the review unless the user was already
+// awarded a review bonus for the same game.
+func (r *reviewRepositoryImpl) CreateWithBonus(ctx context.Context, review *models.Review, bonus *models.PointsLedger) error {
+	return r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createReview(tx, review); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.PointsLedger{}).
+			Where("user_id = ? AND reason = ? AND game_id = ?", bonus.UserID, bonus.Reason, bonus.GameID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		return recordPoints(tx, bonus, false)
+	})
 }
 
 // Delete implements models.ReviewRepository.
-func (r *reviewRepositoryImpl) Delete(id int) error {
-	return r.db.DB.Delete(&models.Review{}, id).Error
+func (r *reviewRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return r.db.DB.WithContext(ctx).Delete(&models.Review{}, id).Error
 }
 
 // FindByGameID implements models.ReviewRepository.
-func (r *reviewRepositoryImpl) FindByGameID(gameID int) ([]*models.Review, error) {
+// Only visible reviews are listed; the total count ignores pagination.
+func (r *reviewRepositoryImpl) FindByGameID(ctx context.Context, gameID int, query models.ReviewQuery) ([]*models.Review, int64, error) {
+	db := r.db.DB.WithContext(ctx).Model(&models.Review{}).
+		Where("game_id = ? AND status = ?", gameID, models.ReviewStatusVisible)
+	if query.Rating != 0 {
+		db = db.Where("rating = ?", query.Rating)
+	}
+	// Share the filters between the count and the page query
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	switch query.Sort {
+	case models.ReviewSortHelpful:
+		db = db.Order("helpful_count - unhelpful_count DESC").Order("helpful_count DESC")
+	case models.ReviewSortHighest:
+		db = db.Order("rating DESC")
+	case models.ReviewSortLowest:
+		db = db.Order("rating ASC")
+	}
+
 	var reviews []*models.Review
-	err := r.db.DB.Where("game_id = ?", gameID).
+	err := db.Order("created_at DESC").Order("id DESC").
+		Limit(query.Limit).Offset(query.Offset).
 		Preload("User").
+		Preload("Reply.Developer").
 		Find(&reviews).Error
-	return reviews, err
+	return reviews, total, err
 }
 
 // FindByID implements models.ReviewRepository.
-func (r *reviewRepositoryImpl) FindByID(id int) (*models.Review, error) {
+func (r *reviewRepositoryImpl) FindByID(ctx context.Context, id int) (*models.Review, error) {
 	var review models.Review
-	err := r.db.DB.Where("id = ?", id).
+	err := r.db.DB.WithContext(ctx).Where("id = ?", id).
 		Preload("User").
 		Preload("Game").
+		Preload("Reply.Developer").
 		First(&review).Error
 	return &review, err
 }
 
 // FindByUserID implements models.ReviewRepository.
-func (r *reviewRepositoryImpl) FindByUserID(userID int) ([]*models.Review, error) {
+func (r *reviewRepositoryImpl) FindByUserID(ctx context.Context, userID int) ([]*models.Review, error) {
 	var reviews []*models.Review
-	err := r.db.DB.Where("user_id = ?", userID).
+	err := r.db.DB.WithContext(ctx).Where("user_id = ?", userID).
 		Preload("Game").
+		Preload("Reply.Developer").
 		Find(&reviews).Error
 	return reviews, err
 }
 
+// FindByGameAndUser implements models.ReviewRepository.
+func (r *reviewRepositoryImpl) FindByGameAndUser(ctx context.Context, gameID, userID int) (*models.Review, error) {
+	var review models.Review
+	err := r.db.DB.WithContext(ctx).Where("game_id = ? AND user_id = ?", gameID, userID).
+		First(&review).Error
+	return &review, err
+}
+
 // Update implements models.ReviewRepository.
-func (r *reviewRepositoryImpl) Update(review *models.Review) error {
-	return r.db.DB.Save(review).Error
+func (r *reviewRepositoryImpl) Update(ctx context.Context, review *models.Review) error {
+	return r.db.DB.WithContext(ctx).Save(review).Error
+}
+
+// CreateReport implements models.ReviewRepository.
+// gorm.ErrDuplicatedKey is returned when the user has already reported the review.
+func (r *reviewRepositoryImpl) CreateReport(ctx context.Context, report *models.ReviewReport) error {
+	result := r.db.DB.WithContext(ctx).Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "review_id"}, {Name: "user_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+		DoNothing:   true,
+	}).Create(report)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	return nil
+}
+
+// FindReport implements models.ReviewRepository.
+func (r *reviewRepositoryImpl) FindReport(ctx context.Context, reviewID, userID int) (*models.ReviewReport, error) {
+	var report models.ReviewReport
+	err := r.db.DB.WithContext(ctx).Where("review_id = ? AND user_id = ?", reviewID, userID).
+		First(&report).Error
+	return &report, err
+}
+
+// FindModerationQueue implements models.ReviewRepository.
+// The queue contains reviews held for moderation and reviews with unresolved reports.
+func (r *reviewRepositoryImpl) FindModerationQueue(ctx context.Context, limit, offset int) ([]*models.Review, error) {
+	openReports := r.db.DB.WithContext(ctx).Model(&models.ReviewReport{}).
+		Select("review_id").
+		Where("resolved = ?", false)
+
+	var reviews []*models.Review
+	err := r.db.DB.WithContext(ctx).Where("status = ? OR id IN (?)", models.ReviewStatusPending, openReports).
+		Preload("User").
+		Preload("Game").
+		Preload("Reports", "resolved = ?", false).
+		Preload("Reports.User").
+		Order("created_at").
+		Limit(limit).Offset(offset).
+		Find(&reviews).Error
+	return reviews, err
+}
+
+// Moderate implements models.ReviewRepository.
+// Setting a moderation status resolves all open reports of the review.
+func (r *reviewRepositoryImpl) Moderate(ctx context.Context, reviewID int, status string) error {
+	return r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Review{}).Where("id = ?", reviewID).Update("status", status)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&models.ReviewReport{}).
+			Where("review_id = ? AND resolved = ?", reviewID, false).
+			Update("resolved", true).Error
+	})
+}
+
+// Vote implements models.ReviewRepository.
+// The vote and the cached counters on the review are changed atomically.
+func (r *reviewRepositoryImpl) Vote(ctx context.Context, reviewID, userID int, helpful bool) error {
+	counter := func(helpful bool) string {
+		if helpful {
+			return "helpful_count"
+		}
+		return "unhelpful_count"
+	}
+
+	return r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// First vote of the user on this review; a vote already there, even one cast
+		// concurrently, leaves the insert to do nothing and is changed below instead
+		vote := models.ReviewVote{
+			ReviewID: reviewID,
+			UserID:   userID,
+			Helpful:  helpful,
+		}
+		result := tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "review_id"}, {Name: "user_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+			DoNothing:   true,
+		}).Create(&vote)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return tx.Model(&models.Review{}).Where("id = ?", reviewID).
+				UpdateColumn(counter(helpful), gorm.Expr(counter(helpful)+" + 1")).Error
+		}
+
+		vote = models.ReviewVote{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("review_id = ? AND user_id = ?", reviewID, userID).
+			First(&vote).Error; err != nil {
+			return err
+		}
+
+		// Same vote again, nothing to do
+		if vote.Helpful == helpful {
+			return nil
+		}
+
+		// Vote changed: move it from one counter to the other
+		vote.Helpful = helpful
+		if err := tx.Save(&vote).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Review{}).Where("id = ?", reviewID).
+			UpdateColumns(map[string]interface{}{
+				counter(helpful):  gorm.Expr(counter(helpful) + " + 1"),
+				counter(!helpful): gorm.Expr(counter(!helpful) + " - 1"),
+			}).Error
+	})
+}
+
+// FindReply implements models.ReviewRepository.
+func (r *reviewRepositoryImpl) FindReply(ctx context.Context, reviewID int) (*models.ReviewReply, error) {
+	var reply models.ReviewReply
+	err := r.db.DB.WithContext(ctx).Where("review_id = ?", reviewID).
+		Preload("Developer").
+		First(&reply).Error
+	return &reply, err
+}
+
+// SaveReply implements models.ReviewRepository.
+// A new reply replaces one written concurrently to the same review.
+func (r *reviewRepositoryImpl) SaveReply(ctx context.Context, reply *models.ReviewReply) error {
+	db := r.db.DB.WithContext(ctx).Omit(clause.Associations)
+	if reply.ID != 0 {
+		return db.Save(reply).Error
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "review_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+		DoUpdates:   clause.AssignmentColumns([]string{"user_id", "body", "updated_at"}),
+	}).Create(reply).Error
+}
+
+// DeleteReply implements models.ReviewRepository.
+func (r *reviewRepositoryImpl) DeleteReply(ctx context.Context, reviewID int) error {
+	result := r.db.DB.WithContext(ctx).Where("review_id = ?", reviewID).Delete(&models.ReviewReply{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// createReview inserts a review unless the user already has one for the game, in which
+// case gorm.ErrDuplicatedKey is returned. Concurrent reviews of the same game by the same
+// user meet in the unique index instead of failing on it.
+func createReview(tx *gorm.DB, review *models.Review) error {
+	result := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "game_id"}, {Name: "user_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+		DoNothing:   true,
+	}).Create(review)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	return nil
 }
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/infrastructure/database"
 )
@@ -16,37 +18,37 @@ func NewRoleRepository(db *database.Database) models.RoleRepository {
 }
 
 // Create creates a new role
-func (r *roleRepositoryImpl) Create(role *models.Role) error {
-	return r.db.DB.Create(role).Error
+func (r *roleRepositoryImpl) Create(ctx context.Context, role *models.Role) error {
+	return r.db.DB.WithContext(ctx).Create(role).Error
 }
 
 // FindByID finds a role by ID
-func (r *roleRepositoryImpl) FindByID(id int) (*models.Role, error) {
+func (r *roleRepositoryImpl) FindByID(ctx context.Context, id int) (*models.Role, error) {
 	var role models.Role
-	err := r.db.DB.First(&role, id).Error
+	err := r.db.DB.WithContext(ctx).First(&role, id).Error
 	return &role, err
 }
 
 // FindByType finds a role by type
-func (r *roleRepositoryImpl) FindByType(roleType string) (*models.Role, error) {
+func (r *roleRepositoryImpl) FindByType(ctx context.Context, roleType string) (*models.Role, error) {
 	var role models.Role
-	err := r.db.DB.Where("type = ?", roleType).First(&role).Error
+	err := r.db.DB.WithContext(ctx).Where("type = ?", roleType).First(&role).Error
 	return &role, err
 }
 
 // Update updates a role
-func (r *roleRepositoryImpl) Update(role *models.Role) error {
-	return r.db.DB.Save(role).Error
+func (r *roleRepositoryImpl) Update(ctx context.Context, role *models.Role) error {
+	return r.db.DB.WithContext(ctx).Save(role).Error
 }
 
 // FindAll finds all roles
-func (r *roleRepositoryImpl) FindAll() ([]*models.Role, error) {
+func (r *roleRepositoryImpl) FindAll(ctx context.Context) ([]*models.Role, error) {
 	var roles []*models.Role
-	err := r.db.DB.Find(&roles).Error
+	err := r.db.DB.WithContext(ctx).Find(&roles).Error
 	return roles, err
 }
 
 // Delete deletes a role by ID
-func (r *roleRepositoryImpl) Delete(id int) error {
-	return r.db.DB.Delete(&models.Role{}, id).Error
+func (r *roleRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return r.db.DB.WithContext(ctx).Delete(&models.Role{}, id).Error
 }
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StoreCreditRepositoryImpl implementation
+type storeCreditRepositoryImpl struct {
+	db *database.Database
+}
+
+// NewStoreCreditRepository creates a new store credit repository
+func NewStoreCreditRepository(db *database.Database) models.StoreCreditRepository {
+	return &storeCreditRepositoryImpl{db: db}
+}
+
+// FindBalances implements models.StoreCreditRepository.
+func (s *storeCreditRepositoryImpl) FindBalances(ctx context.Context, userID int) ([]*models.StoreCredit, error) {
+	var balances []*models.StoreCredit
+	err := s.db.DB.WithContext(ctx).Where("user_id = ? AND balance > 0", userID).
+		Order("currency").
+		Find(&balances).Error
+	return balances, err
+}
+
+// FindByUserID implements models.StoreCreditRepository.
+func (s *storeCreditRepositoryImpl) FindByUserID(ctx context.Context, userID int, limit int, offset int) ([]*models.StoreCreditLedger, error) {
+	var entries []*models.StoreCreditLedger
+	err := s.db.DB.WithContext(ctx).Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&entries).Error
+	return entries, err
+}
+
+// recordCredit applies a ledger entry to the user's balance in the entry's currency within tx.
+// A debit larger than the balance fails.
+func recordCredit(tx *gorm.DB, entry *models.StoreCreditLedger) error {
+	credit := models.StoreCredit{UserID: entry.UserID, Currency: entry.Currency}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&credit).Error; err != nil {
+		return err
+	}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND currency = ?", entry.UserID, entry.Currency).
+		First(&credit).Error; err != nil {
+		return err
+	}
+
+	if credit.Balance+entry.Amount < 0 {
+		return errors.New("insufficient store credit")
+	}
+	if entry.Amount == 0 {
+		return nil
+	}
+
+	entry.Balance = credit.Balance + entry.Amount
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if err := tx.Model(&credit).Updates(map[string]interface{}{
+		"balance":    entry.Balance,
+		"updated_at": entry.CreatedAt,
+	}).Error; err != nil {
+		return err
+	}
+
+	return tx.Omit(clause.Associations).Create(entry).Error
+}
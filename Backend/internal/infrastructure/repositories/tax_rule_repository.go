@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+
+	"uniStore/Backend/internal/domain/models"
+	"uniStore/Backend/internal/infrastructure/database"
+)
+
+// TaxRuleRepositoryImpl implementation
+type taxRuleRepositoryImpl struct {
+	db *database.Database
+}
+
+// NewTaxRuleRepository creates a new tax rule repository
+func NewTaxRuleRepository(db *database.Database) models.TaxRuleRepository {
+	return &taxRuleRepositoryImpl{db: db}
+}
+
+// Create implements models.TaxRuleRepository.
+func (t *taxRuleRepositoryImpl) Create(ctx context.Context, rule *models.TaxRule) error {
+	return t.db.DB.WithContext(ctx).Create(rule).Error
+}
+
+// FindByID implements models.TaxRuleRepository.
+func (t *taxRuleRepositoryImpl) FindByID(ctx context.Context, id int) (*models.TaxRule, error) {
+	var rule models.TaxRule
+	err := t.db.DB.WithContext(ctx).Where("id = ?", id).First(&rule).Error
+	return &rule, err
+}
+
+// FindByRegion implements models.TaxRuleRepository.
+func (t *taxRuleRepositoryImpl) FindByRegion(ctx context.Context, region string) (*models.TaxRule, error) {
+	var rule models.TaxRule
+	err := t.db.DB.WithContext(ctx).Where("region = ?", region).First(&rule).Error
+	return &rule, err
+}
+
+// FindAll implements models.TaxRuleRepository.
+func (t *taxRuleRepositoryImpl) FindAll(ctx context.Context) ([]*models.TaxRule, error) {
+	var rules []*models.TaxRule
+	err := t.db.DB.WithContext(ctx).Order("region").Find(&rules).Error
+	return rules, err
+}
+
+// Update implements models.TaxRuleRepository.
+func (t *taxRuleRepositoryImpl) Update(ctx context.Context, rule *models.TaxRule) error {
+	return t.db.DB.WithContext(ctx).Save(rule).Error
+}
+
+// Delete implements models.TaxRuleRepository.
+func (t *taxRuleRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return t.db.DB.WithContext(ctx).Delete(&models.TaxRule{}, id).Error
+}
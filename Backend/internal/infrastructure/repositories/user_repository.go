@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"uniStore/Backend/internal/domain/models"
 	"uniStore/Backend/internal/infrastructure/database"
 )
@@ -16,44 +18,52 @@ func NewUserRepository(db *database.Database) models.UserRepository {
 }
 
 // Create creates a new user
-func (r *userRepositoryImpl) Create(user *models.User) error {
-	return r.db.DB.Create(user).Error
+func (r *userRepositoryImpl) Create(ctx context.Context, user *models.User) error {
+	return r.db.DB.WithContext(ctx).Create(user).Error
 }
 
 // FindByID finds a user by ID
-func (r *userRepositoryImpl) FindByID(id int) (*models.User, error) {
+func (r *userRepositoryImpl) FindByID(ctx context.Context, id int) (*models.User, error) {
 	var user models.User
-	err := r.db.DB.Preload("Role").First(&user, id).Error
+	err := r.db.DB.WithContext(ctx).Preload("Role").First(&user, id).Error
 	return &user, err
 }
 
 // FindByEmail finds a user by email
-func (r *userRepositoryImpl) FindByEmail(email string) (*models.User, error) {
+func (r *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	err := r.db.DB.Preload("Role").Where("email = ?", email).First(&user).Error
+	err := r.db.DB.WithContext(ctx).Preload("Role").Where("email = ?", email).First(&user).Error
 	return &user, err
 }
 
 // FindByNickname finds a user by nickname
-func (r *userRepositoryImpl) FindByNickname(nickname string) (*models.User, error) {
+func (r *userRepositoryImpl) FindByNickname(ctx context.Context, nickname string) (*models.User, error) {
 	var user models.User
-	err := r.db.DB.Preload("Role").Where("nickname = ?", nickname).First(&user).Error
+	err := r.db.DB.WithContext(ctx).Preload("Role").Where("nickname = ?", nickname).First(&user).Error
 	return &user, err
 }
 
 // Update updates a user
-func (r *userRepositoryImpl) Update(user *models.User) error {
-	return r.db.DB.Save(user).Error
+func (r *userRepositoryImpl) Update(ctx context.Context, user *models.User) error {
+	// Points only change through the points ledger
+	return r.db.DB.WithContext(ctx).Omit("Points").Save(user).Error
 }
 
 // Delete deletes a user
-func (r *userRepositoryImpl) Delete(id int) error {
-	return r.db.DB.Delete(&models.User{}, id).Error
+func (r *userRepositoryImpl) Delete(ctx context.Context, id int) error {
+	return r.db.DB.WithContext(ctx).Delete(&models.User{}, id).Error
 }
 
 // FindAll finds all users
-func (r *userRepositoryImpl) FindAll(limit, offset int) ([]*models.User, error) {
+func (r *userRepositoryImpl) FindAll(ctx context.Context, limit, offset int) ([]*models.User, error) {
 	var users []*models.User
-	err := r.db.DB.Preload("Role").Limit(limit).Offset(offset).Find(&users).Error
+	err := r.db.DB.WithContext(ctx).Preload("Role").Limit(limit).Offset(offset).Find(&users).Error
 	return users, err
 }
+
+// CountByRole counts the users with a role
+func (r *userRepositoryImpl) CountByRole(ctx context.Context, roleID int) (int64, error) {
+	var count int64
+	err := r.db.DB.WithContext(ctx).Model(&models.User{}).Where("role_id = ?", roleID).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// job is a function run at a fixed interval
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+// Scheduler runs background jobs at fixed intervals
+type Scheduler struct {
+	jobs   []job
+	logger *slog.Logger
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates a scheduler without jobs, logging failed runs to logger
+func New(logger *slog.Logger) *Scheduler {
+	return &Scheduler{logger: logger, stop: make(chan struct{})}
+}
+
+// Every registers a job to run once the scheduler starts and then every interval
+func (s *Scheduler) Every(interval time.Duration, name string, run func(ctx context.Context) error) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start starts running the registered jobs in the background
+func (s *Scheduler) Start() {
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(j)
+	}
+}
+
+// Stop stops the jobs, waiting for running ones to finish
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// loop runs a job until the scheduler stops. A failed run is logged and retried at the next interval.
+func (s *Scheduler) loop(j job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.run(context.Background()); err != nil {
+			s.logger.Error("Job failed", "job", j.name, "error", err)
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// spanKey stores the span of a query on its statement
+const spanKey = "tracing:span"
+
+// querySpan is the span of a running query and the context it replaced on the statement
+type querySpan struct {
+	span   trace.Span
+	parent context.Context
+}
+
+// InstrumentDB starts a span for every query run through db, as a child of the span in the
+// query's context. Spans carry the SQL with placeholders, never the bound values.
+func InstrumentDB(db *gorm.DB) error {
+	tracer := otel.Tracer("uniStore/Backend/internal/infrastructure/tracing")
+	start := func(operation string) func(db *gorm.DB) {
+		return func(db *gorm.DB) {
+			parent := db.Statement.Context
+			ctx, span := tracer.Start(parent, "db."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationName(operation)))
+			db.Statement.Context = ctx
+			db.InstanceSet(spanKey, querySpan{span: span, parent: parent})
+		}
+	}
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", start("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", endQuery),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", start("query")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", endQuery),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", start("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", endQuery),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", start("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", endQuery),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", start("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", endQuery),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", start("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", endQuery),
+	)
+}
+
+// endQuery ends the span of a query, recording its SQL, table and any error
+func endQuery(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	query, ok := value.(querySpan)
+	if !ok {
+		return
+	}
+	span := query.span
+	defer span.End()
+
+	// Later queries of the same statement must not become children of this one
+	db.Statement.Context = query.parent
+
+	span.SetAttributes(semconv.DBQueryText(db.Statement.SQL.String()))
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBCollectionName(db.Statement.Table))
+	}
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,142 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+
+	"uniStore/Backend/internal/domain/services"
+	"uniStore/Backend/internal/infrastructure/database"
+	"uniStore/Backend/internal/infrastructure/repositories"
+	"uniStore/Backend/internal/infrastructure/tracing"
+	"uniStore/Backend/internal/interfaces/middleware"
+)
+
+const (
+	incomingTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	incomingSpanID  = "00f067aa0ba902b7"
+	bundleID        = 987654 // Bound to the query, so it must not show up in its span
+)
+
+// TestRequestTrace follows a request through the middleware, a service and its queries
+func TestRequestTrace(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewTracerProvider(exporter, "test", 1)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	// A dry run builds the SQL and runs the callbacks without a database
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=test"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+		NamingStrategy:       schema.NamingStrategy{SingularTable: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := tracing.InstrumentDB(db); err != nil {
+		t.Fatalf("failed to instrument database: %v", err)
+	}
+	wrapped := &database.Database{DB: db}
+	bundleService := services.NewBundleService(repositories.NewBundleRepository(wrapped), repositories.NewGameRepository(wrapped), services.CurrencyRules{Default: "USD"})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.Tracing())
+	router.GET("/bundles/:id", func(c *gin.Context) {
+		bundleService.GetBundleByID(c.Request.Context(), bundleID)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/bundles/1", nil)
+	req.Header.Set("traceparent", "00-"+incomingTraceID+"-"+incomingSpanID+"-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("failed to flush spans: %v", err)
+	}
+	spans := exporter.GetSpans()
+
+	server := findSpan(t, spans, "GET /bundles/:id")
+	t.Run("server span continues the incoming trace", func(t *testing.T) {
+		if got := server.SpanContext.TraceID().String(); got != incomingTraceID {
+			t.Errorf("trace ID = %s, want %s", got, incomingTraceID)
+		}
+		if got := server.Parent.SpanID().String(); got != incomingSpanID {
+			t.Errorf("parent span ID = %s, want %s", got, incomingSpanID)
+		}
+		if !server.Parent.IsRemote() {
+			t.Error("parent span is not remote")
+		}
+		if server.SpanKind != trace.SpanKindServer {
+			t.Errorf("span kind = %v, want server", server.SpanKind)
+		}
+	})
+
+	service := findSpan(t, spans, "BundleService.GetBundleByID")
+	t.Run("service span is a child of the server span", func(t *testing.T) {
+		if service.Parent.SpanID() != server.SpanContext.SpanID() {
+			t.Errorf("parent span ID = %s, want %s", service.Parent.SpanID(), server.SpanContext.SpanID())
+		}
+		if service.SpanContext.TraceID() != server.SpanContext.TraceID() {
+			t.Error("service span is in another trace")
+		}
+	})
+
+	query := findSpan(t, spans, "db.query")
+	t.Run("query span is a child of the service span without bound values", func(t *testing.T) {
+		if query.Parent.SpanID() != service.SpanContext.SpanID() {
+			t.Errorf("parent span ID = %s, want %s", query.Parent.SpanID(), service.SpanContext.SpanID())
+		}
+		if query.SpanKind != trace.SpanKindClient {
+			t.Errorf("span kind = %v, want client", query.SpanKind)
+		}
+
+		var text string
+		for _, attr := range query.Attributes {
+			if attr.Key == semconv.DBQueryTextKey {
+				text = attr.Value.AsString()
+			}
+		}
+		if !strings.Contains(text, `"bundle"`) || !strings.Contains(text, "$1") {
+			t.Errorf("query text = %q, want the bundle query with placeholders", text)
+		}
+		for _, attr := range query.Attributes {
+			if strings.Contains(attr.Value.Emit(), strconv.Itoa(bundleID)) {
+				t.Errorf("attribute %s = %q leaks the bound value", attr.Key, attr.Value.Emit())
+			}
+		}
+	})
+}
+
+// findSpan returns the first span with the name
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name
+	}
+	t.Fatalf("no span named %q among %v", name, names)
+	return tracetest.SpanStub{}
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"uniStore/Backend/internal/domain/services"
+	"uniStore/Backend/internal/interfaces/dto"
+)
+
+// BundleHandler handles HTTP requests related to bundles
+type BundleHandler struct {
+	bundleService services.BundleService
+}
+
+// NewBundleHandler creates a new bundle handler
+func NewBundleHandler(bundleService services.BundleService) *BundleHandler {
+	return &BundleHandler{
+		bundleService: bundleService,
+	}
+}
+
+// CreateBundle creates a bundle
+// @Summary Create a bundle
+// @Description Creates a bundle selling several games as one product at its own price (admin only)
+// @Tags Bundles
+// @Accept json
+// @Produce json
+// @Param bundle body dto.BundleCreateDTO true "Bundle data"
+// @Success 201 {object} dto.BundleDTO "Bundle created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/bundles [post]
+func (h *BundleHandler) CreateBundle(c *gin.Context) {
+	var bundleDTO dto.BundleCreateDTO
+	if err := c.BindJSON(&bundleDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bundle, err := h.bundleService.CreateBundle(c.Request.Context(), &bundleDTO)
+	if err != nil {
+		writeBundleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, bundle)
+}
+
+// GetBundleByID retrieves a bundle by ID
+// @Summary Get bundle by ID
+// @Description Returns a bundle with its games
+// @Tags Bundles
+// @Accept json
+// @Produce json
+// @Param bundle_id path int true "Bundle ID"
+// @Success 200 {object} dto.BundleDTO "Bundle details"
+// @Failure 400 {object} map[string]interface{} "Invalid bundle ID"
+// @Failure 404 {object} map[string]interface{} "Bundle not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/bundles/{bundle_id} [get]
+func (h *BundleHandler) GetBundleByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("bundle_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bundle ID"})
+		return
+	}
+
+	bundle, err := h.bundleService.GetBundleByID(c.Request.Context(), id)
+	if err != nil {
+		writeBundleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bundle)
+}
+
+// GetBundles retrieves the bundles on sale
+// @Summary Get bundles
+// @Description Returns the bundles on sale with their games
+// @Tags Bundles
+// @Accept json
+// @Produce json
+// @Param limit query int false "Limit" default(10)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} dto.BundleDTO "List of bundles"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/bundles [get]
+func (h *BundleHandler) GetBundles(c *gin.Context) {
+	// Parse pagination parameters
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+		return
+	}
+
+	bundles, err := h.bundleService.GetBundles(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bundles)
+}
+
+// UpdateBundle updates a bundle
+// @Summary Update a bundle
+// @Description Updates a bundle's details, price, games or availability. Orders already placed keep their price (admin only)
+// @Tags Bundles
+// @Accept json
+// @Produce json
+// @Param bundle_id path int true "Bundle ID"
+// @Param bundle body dto.BundleUpdateDTO true "Bundle data"
+// @Success 200 {object} dto.BundleDTO "Bundle updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Bundle or game not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/bundles/{bundle_id} [put]
+func (h *BundleHandler) UpdateBundle(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("bundle_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bundle ID"})
+		return
+	}
+
+	var bundleDTO dto.BundleUpdateDTO
+	if err := c.BindJSON(&bundleDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bundle, err := h.bundleService.UpdateBundle(c.Request.Context(), id, &bundleDTO)
+	if err != nil {
+		writeBundleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bundle)
+}
+
+// DeleteBundle deletes a bundle
+// @Summary Delete a bundle
+// @Description Deletes a bundle (admin only)
+// @Tags Bundles
+// @Accept json
+// @Produce json
+// @Param bundle_id path int true "Bundle ID"
+// @Success 200 {object} map[string]interface{} "Bundle deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid bundle ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Bundle not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/bundles/{bundle_id} [delete]
+func (h *BundleHandler) DeleteBundle(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("bundle_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bundle ID"})
+		return
+	}
+
+	if err := h.bundleService.DeleteBundle(c.Request.Context(), id); err != nil {
+		writeBundleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bundle deleted successfully"})
+}
+
+// writeBundleError maps bundle errors to HTTP responses
+func writeBundleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "bundle not found", "game not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "a bundle cannot contain the same game twice", "unsupported currency":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -13,23 +13,26 @@ import (
 // CartHandler handles HTTP requests related to shopping carts
 type CartHandler struct {
 	cartService services.CartService
+	guestCarts  *GuestCartTokens
 }
 
 // NewCartHandler creates a new cart handler
-func NewCartHandler(cartService services.CartService) *CartHandler {
+func NewCartHandler(cartService services.CartService, guestCarts *GuestCartTokens) *CartHandler {
 	return &CartHandler{
 		cartService: cartService,
+		guestCarts:  guestCarts,
 	}
 }
 
 // GetCart handles getting a user's shopping cart
 // @Summary Get a user's shopping cart
-// @Description Returns a user's shopping cart and its items
+// @Description Returns a user's shopping cart and its items. Items whose price changed since they were added, that are no longer sold or that are restricted in the user's region carry warnings. The cart version, also sent as the ETag header, must be sent back to check out.
 // @Tags Cart
 // @Accept json
 // @Produce json
 // @Param user_id path int true "User ID"
 // @Success 200 {object} dto.CartResponseDTO "User's shopping cart"
+// @Header 200 {string} ETag "Cart version"
 // @Failure 400 {object} map[string]interface{} "Invalid input"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden"
@@ -58,14 +61,14 @@ func (h *CartHandler) GetCart(c *gin.Context) {
 	}
 
 	// Get cart using the service
-	cartResponseDTO, err := h.cartService.GetCart(id)
+	cartResponseDTO, err := h.cartService.GetCart(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Calculate total cost
-	totalCost, err := h.cartService.CalculateCartTotal(id)
+	totalCost, err := h.cartService.CalculateCartTotal(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -74,12 +77,13 @@ func (h *CartHandler) GetCart(c *gin.Context) {
 	// Update total cost in response
 	cartResponseDTO.TotalCost = totalCost
 
+	writeCartVersion(c, cartResponseDTO.Version)
 	c.JSON(http.StatusOK, cartResponseDTO)
 }
 
 // AddGameToCart handles adding a game to a user's shopping cart
 // @Summary Add a game to cart
-// @Description Adds a game to a user's shopping cart
+// @Description Adds a single copy of a game to a user's shopping cart. Games the user already owns or has in a pending order are refused.
 // @Tags Cart
 // @Accept json
 // @Produce json
@@ -90,6 +94,7 @@ func (h *CartHandler) GetCart(c *gin.Context) {
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden"
 // @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 409 {object} map[string]interface{} "Game already owned or in a pending order"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /api/v1/cart/{user_id}/add/{game_id} [post]
@@ -129,8 +134,8 @@ func (h *CartHandler) AddGameToCart(c *gin.Context) {
 	}
 
 	// Add game to cart
-	if err := h.cartService.AddGameToCart(uid, cartItemDTO); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.cartService.AddGameToCart(c.Request.Context(), uid, cartItemDTO); err != nil {
+		writeCartItemError(c, err)
 		return
 	}
 
@@ -182,7 +187,7 @@ func (h *CartHandler) RemoveGameFromCart(c *gin.Context) {
 	}
 
 	// Remove game from cart
-	if err := h.cartService.RemoveGameFromCart(uid, gid); err != nil {
+	if err := h.cartService.RemoveGameFromCart(c.Request.Context(), uid, gid); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -227,7 +232,7 @@ func (h *CartHandler) ClearCart(c *gin.Context) {
 	}
 
 	// Clear cart
-	if err := h.cartService.ClearCart(uid); err != nil {
+	if err := h.cartService.ClearCart(c.Request.Context(), uid); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -237,17 +242,19 @@ func (h *CartHandler) ClearCart(c *gin.Context) {
 
 // UpdateCartItemQuantity handles updating the quantity of a game in a user's shopping cart
 // @Summary Update cart item quantity
-// @Description Updates the quantity of a game in a user's shopping cart
+// @Description Updates the quantity of a game in a user's shopping cart. A game can only be bought once for the user; further copies must be added as gift copies.
 // @Tags Cart
 // @Accept json
 // @Produce json
 // @Param user_id path int true "User ID"
 // @Param game_id path int true "Game ID"
-// @Param quantity query int true "New quantity"
+// @Param quantity query int true "New quantity, at most 1"
 // @Success 200 {object} map[string]interface{} "Cart item quantity updated successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid input"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 409 {object} map[string]interface{} "Game already owned or in a pending order"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /api/v1/cart/{user_id}/update/{game_id} [patch]
@@ -293,10 +300,490 @@ func (h *CartHandler) UpdateCartItemQuantity(c *gin.Context) {
 	}
 
 	// Update cart item quantity
-	if err := h.cartService.UpdateCartItemQuantity(uid, gid, quantityDTO); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.cartService.UpdateCartItemQuantity(c.Request.Context(), uid, gid, quantityDTO); err != nil {
+		writeCartItemError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Cart item quantity updated successfully"})
 }
+
+// SetGift handles marking a game in a user's shopping cart as a gift
+// @Summary Mark a cart item as a gift
+// @Description Marks a game in the cart as a gift for another user, found by nickname or email. Games the recipient already owns cannot be gifted.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param game_id path int true "Game ID"
+// @Param gift body dto.CartGiftCreateDTO true "Gift recipient and message"
+// @Success 200 {object} map[string]interface{} "Cart item marked as gift successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input or recipient"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Recipient or cart item not found"
+// @Failure 409 {object} map[string]interface{} "Recipient already owns the game"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/cart/{user_id}/gift/{game_id} [put]
+func (h *CartHandler) SetGift(c *gin.Context) {
+	uid, gid, ok := authorizeCartItem(c)
+	if !ok {
+		return
+	}
+
+	var giftDTO dto.CartGiftCreateDTO
+	if err := c.BindJSON(&giftDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.cartService.SetGift(c.Request.Context(), uid, gid, &giftDTO); err != nil {
+		writeCartGiftError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Cart item marked as gift successfully"})
+}
+
+// AddGiftCopy handles adding another copy of a game to a user's shopping cart as a gift
+// @Summary Add a gift copy to the cart
+// @Description Adds a copy of a game to the cart as a gift for another user, found by nickname or email. This is the only way to buy several copies of a game.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param game_id path int true "Game ID"
+// @Param gift body dto.CartGiftCreateDTO true "Gift recipient and message"
+// @Success 200 {object} map[string]interface{} "Gift copy added to cart successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input or recipient"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Game or recipient not found"
+// @Failure 409 {object} map[string]interface{} "Recipient already owns the game or it is already in the cart for them"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/cart/{user_id}/gift/{game_id} [post]
+func (h *CartHandler) AddGiftCopy(c *gin.Context) {
+	uid, gid, ok := authorizeCartItem(c)
+	if !ok {
+		return
+	}
+
+	var giftDTO dto.CartGiftCreateDTO
+	if err := c.BindJSON(&giftDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.cartService.AddGiftCopy(c.Request.Context(), uid, gid, &giftDTO); err != nil {
+		writeCartGiftError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Gift copy added to cart successfully"})
+}
+
+// RemoveGift handles turning a gift in a user's shopping cart back into a regular item
+// @Summary Unmark a cart item as a gift
+// @Description Turns a gift copy in the cart back into a purchase for the cart owner. If the owner's own copy is already in the cart, the gift copy is removed instead.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param game_id path int true "Game ID"
+// @Param recipient_id query int false "Recipient of the gift copy, required when the cart holds several"
+// @Success 200 {object} map[string]interface{} "Gift removed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Cart item not found"
+// @Failure 409 {object} map[string]interface{} "Game already owned or in a pending order"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/cart/{user_id}/gift/{game_id} [delete]
+func (h *CartHandler) RemoveGift(c *gin.Context) {
+	uid, gid, ok := authorizeCartItem(c)
+	if !ok {
+		return
+	}
+
+	var recipientID *int
+	if value := c.Query("recipient_id"); value != "" {
+		rid, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipient ID"})
+			return
+		}
+		recipientID = &rid
+	}
+
+	if err := h.cartService.RemoveGift(c.Request.Context(), uid, gid, recipientID); err != nil {
+		writeCartGiftError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Gift removed successfully"})
+}
+
+// ApplyCoupon handles applying a coupon code to a user's shopping cart
+// @Summary Apply a coupon to the cart
+// @Description Applies a coupon code to the cart. The cart total is recalculated with the discount whenever the cart changes.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param coupon body dto.CartCouponApplyDTO true "Coupon code"
+// @Success 200 {object} dto.CartResponseDTO "Cart with the coupon applied"
+// @Header 200 {string} ETag "Cart version"
+// @Failure 400 {object} map[string]interface{} "Invalid input, empty cart or coupon not applicable"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Coupon not found"
+// @Failure 409 {object} map[string]interface{} "Coupon used up or already used"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/cart/{user_id}/coupon [put]
+func (h *CartHandler) ApplyCoupon(c *gin.Context) {
+	uid, ok := authorizeCart(c)
+	if !ok {
+		return
+	}
+
+	var couponDTO dto.CartCouponApplyDTO
+	if err := c.BindJSON(&couponDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cart, err := h.cartService.ApplyCoupon(c.Request.Context(), uid, &couponDTO)
+	if err != nil {
+		switch err.Error() {
+		case "coupon not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Coupon not found"})
+		case "cart is empty", "coupon is not active", "coupon is not valid yet", "coupon has expired",
+			"cart total is below the coupon minimum", "coupon does not apply to any game in the cart",
+			"coupon is not valid in your currency":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "coupon usage limit reached", "you have already used this coupon":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	writeCartVersion(c, cart.Version)
+	c.JSON(http.StatusOK, cart)
+}
+
+// RemoveCoupon handles removing the coupon from a user's shopping cart
+// @Summary Remove the coupon from the cart
+// @Description Removes the coupon applied to the cart
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Success 200 {object} map[string]interface{} "Coupon removed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Cart not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/cart/{user_id}/coupon [delete]
+func (h *CartHandler) RemoveCoupon(c *gin.Context) {
+	uid, ok := authorizeCart(c)
+	if !ok {
+		return
+	}
+
+	if err := h.cartService.RemoveCoupon(c.Request.Context(), uid); err != nil {
+		if err.Error() == "cart not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cart not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Coupon removed successfully"})
+}
+
+// AddBundleToCart handles adding a bundle to a user's shopping cart
+// @Summary Add a bundle to cart
+// @Description Adds a bundle to the cart. Games of the bundle the user already owns are deducted from its price.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param bundle_id path int true "Bundle ID"
+// @Success 200 {object} map[string]interface{} "Bundle added to cart successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input or bundle not available"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Bundle not found"
+// @Failure 409 {object} map[string]interface{} "Every game of the bundle already owned"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/cart/{user_id}/bundles/{bundle_id} [post]
+func (h *CartHandler) AddBundleToCart(c *gin.Context) {
+	uid, ok := authorizeCart(c)
+	if !ok {
+		return
+	}
+
+	bid, err := strconv.Atoi(c.Param("bundle_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bundle ID"})
+		return
+	}
+
+	if err := h.cartService.AddBundleToCart(c.Request.Context(), uid, bid); err != nil {
+		switch err.Error() {
+		case "bundle not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Bundle not found"})
+		case "bundle is not available", "bundle is not sold in your currency":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "you already own every game in this bundle":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bundle added to cart successfully"})
+}
+
+// RemoveBundleFromCart handles removing a bundle from a user's shopping cart
+// @Summary Remove a bundle from cart
+// @Description Removes a bundle from the user's shopping cart
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param bundle_id path int true "Bundle ID"
+// @Success 200 {object} map[string]interface{} "Bundle removed from cart successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Bundle not in cart"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/cart/{user_id}/bundles/{bundle_id} [delete]
+func (h *CartHandler) RemoveBundleFromCart(c *gin.Context) {
+	uid, ok := authorizeCart(c)
+	if !ok {
+		return
+	}
+
+	bid, err := strconv.Atoi(c.Param("bundle_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bundle ID"})
+		return
+	}
+
+	if err := h.cartService.RemoveBundleFromCart(c.Request.Context(), uid, bid); err != nil {
+		if err.Error() == "bundle not in cart" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bundle removed from cart successfully"})
+}
+
+// GetGuestCart handles getting the cart of a visitor who is not logged in
+// @Summary Get the guest cart
+// @Description Returns the guest cart identified by the signed guest_cart cookie or X-Cart-Token header. Without one, the cart is empty.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.GuestCartDTO "Guest cart"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/guest-cart [get]
+func (h *CartHandler) GetGuestCart(c *gin.Context) {
+	cart, err := h.cartService.GetGuestCart(c.Request.Context(), h.guestCarts.Read(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// AddGameToGuestCart handles adding a game to the cart of a visitor who is not logged in
+// @Summary Add a game to the guest cart
+// @Description Adds a game to the guest cart, starting one if needed. The signed cart token is returned in the guest_cart cookie and the X-Cart-Token header; the cart is merged into the user's cart on login or signup.
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param game_id path int true "Game ID"
+// @Success 200 {object} dto.GuestCartDTO "Guest cart"
+// @Failure 400 {object} map[string]interface{} "Invalid game ID"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/guest-cart/add/{game_id} [post]
+func (h *CartHandler) AddGameToGuestCart(c *gin.Context) {
+	gid, err := strconv.Atoi(c.Param("game_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	cart, err := h.cartService.AddGameToGuestCart(c.Request.Context(), h.guestCarts.Read(c), gid)
+	if err != nil {
+		if err.Error() == "game not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.guestCarts.Write(c, cart.Token)
+	c.JSON(http.StatusOK, cart)
+}
+
+// RemoveGameFromGuestCart handles removing a game from the cart of a visitor who is not logged in
+// @Summary Remove a game from the guest cart
+// @Description Removes a game from the guest cart
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Param game_id path int true "Game ID"
+// @Success 200 {object} dto.GuestCartDTO "Guest cart"
+// @Failure 400 {object} map[string]interface{} "Invalid game ID"
+// @Failure 404 {object} map[string]interface{} "Cart not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/guest-cart/remove/{game_id} [delete]
+func (h *CartHandler) RemoveGameFromGuestCart(c *gin.Context) {
+	gid, err := strconv.Atoi(c.Param("game_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	cart, err := h.cartService.RemoveGameFromGuestCart(c.Request.Context(), h.guestCarts.Read(c), gid)
+	if err != nil {
+		if err.Error() == "cart not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cart not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// ClearGuestCart handles clearing the cart of a visitor who is not logged in
+// @Summary Clear the guest cart
+// @Description Removes all games from the guest cart
+// @Tags Cart
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Cart cleared successfully"
+// @Failure 404 {object} map[string]interface{} "Cart not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/guest-cart/clear [delete]
+func (h *CartHandler) ClearGuestCart(c *gin.Context) {
+	if err := h.cartService.ClearGuestCart(c.Request.Context(), h.guestCarts.Read(c)); err != nil {
+		if err.Error() == "cart not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cart not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Cart cleared successfully"})
+}
+
+// authorizeCart reads the user_id path parameter and checks the cart belongs to the caller
+func authorizeCart(c *gin.Context) (int, bool) {
+	uid, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return 0, false
+	}
+
+	// Check if the current user can modify this cart
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, false
+	}
+
+	// Only allow users to modify their own cart
+	if tokenUserID.(int) != uid {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only modify your own cart"})
+		return 0, false
+	}
+
+	return uid, true
+}
+
+// authorizeCartItem reads the user_id and game_id path parameters and checks the cart belongs to the caller
+func authorizeCartItem(c *gin.Context) (int, int, bool) {
+	uid, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return 0, 0, false
+	}
+
+	gid, err := strconv.Atoi(c.Param("game_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return 0, 0, false
+	}
+
+	// Check if the current user can modify this cart
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, 0, false
+	}
+
+	// Only allow users to modify their own cart
+	if tokenUserID.(int) != uid {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only modify your own cart"})
+		return 0, 0, false
+	}
+
+	return uid, gid, true
+}
+
+// writeCartItemError maps errors of adding games to a cart to HTTP responses
+func writeCartItemError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "game not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+	case "quantity must be greater than 0", "a game can only be bought once for yourself, add gift copies to buy it for others",
+		"game is not sold in your currency":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "you already own this game", "game is already in a pending order":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// writeCartGiftError maps cart gift errors to HTTP responses
+func writeCartGiftError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "recipient not found", "game not in cart", "game not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "you cannot gift a game to yourself", "several gift copies of this game are in the cart, choose one by recipient",
+		"game is not sold in your currency":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "recipient already owns this game", "recipient already has this game as a pending gift",
+		"game is already in the cart as a gift for this recipient", "you already own this game", "game is already in a pending order":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeCartVersion sends the cart version as the response's ETag
+func writeCartVersion(c *gin.Context, version string) {
+	c.Header("ETag", `"`+version+`"`)
+}
+
+// readCartVersion returns the cart version the client acknowledged in the If-Match header, or ""
+func readCartVersion(c *gin.Context) string {
+	version := strings.TrimSpace(c.GetHeader("If-Match"))
+	version = strings.TrimPrefix(version, "W/")
+	return strings.Trim(version, `"`)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"uniStore/Backend/internal/domain/services"
+	"uniStore/Backend/internal/interfaces/dto"
+)
+
+// CouponHandler handles HTTP requests related to coupon management
+type CouponHandler struct {
+	couponService services.CouponService
+}
+
+// NewCouponHandler creates a new coupon handler
+func NewCouponHandler(couponService services.CouponService) *CouponHandler {
+	return &CouponHandler{
+		couponService: couponService,
+	}
+}
+
+// CreateCoupon creates a coupon
+// @Summary Create a coupon
+// @Description Creates a percent or fixed amount coupon with optional validity window, minimum total, usage limits and category or developer restrictions (admin only)
+// @Tags Coupons
+// @Accept json
+// @Produce json
+// @Param coupon body dto.CouponCreateDTO true "Coupon settings"
+// @Success 201 {object} dto.CouponDTO "Coupon created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Category or developer not found"
+// @Failure 409 {object} map[string]interface{} "Coupon code already exists"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/coupons [post]
+func (h *CouponHandler) CreateCoupon(c *gin.Context) {
+	var couponDTO dto.CouponCreateDTO
+	if err := c.BindJSON(&couponDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	coupon, err := h.couponService.CreateCoupon(c.Request.Context(), &couponDTO)
+	if err != nil {
+		switch err.Error() {
+		case "category not found", "developer not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "percent coupons cannot exceed 100", "percent coupons must be a whole percentage", "coupon must end after it starts", "coupon code is required", "unsupported currency":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "coupon code already exists":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, coupon)
+}
+
+// GetCoupons retrieves coupons
+// @Summary Get coupons
+// @Description Returns coupons with their usage, newest first (admin only)
+// @Tags Coupons
+// @Accept json
+// @Produce json
+// @Param limit query int false "Limit" default(100)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} dto.CouponDTO "List of coupons"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/coupons [get]
+func (h *CouponHandler) GetCoupons(c *gin.Context) {
+	// Parse pagination parameters
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+		return
+	}
+
+	coupons, err := h.couponService.GetCoupons(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, coupons)
+}
+
+// DeactivateCoupon deactivates a coupon
+// @Summary Deactivate a coupon
+// @Description Stops a coupon from being applied to carts and orders. Orders already placed keep their discount (admin only)
+// @Tags Coupons
+// @Accept json
+// @Produce json
+// @Param coupon_id path int true "Coupon ID"
+// @Success 200 {object} map[string]interface{} "Coupon deactivated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid coupon ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Coupon not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/coupons/{coupon_id} [delete]
+func (h *CouponHandler) DeactivateCoupon(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("coupon_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid coupon ID"})
+		return
+	}
+
+	if err := h.couponService.DeactivateCoupon(c.Request.Context(), id); err != nil {
+		if err.Error() == "coupon not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Coupon not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Coupon deactivated successfully"})
+}
@@ -54,9 +54,14 @@ func (h *GameHandler) CreateGame(c *gin.Context) {
 	}
 
 	// Create game
-	createdGame, err := h.gameService.CreateGame(&gameDTO)
+	createdGame, err := h.gameService.CreateGame(c.Request.Context(), &gameDTO)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch err.Error() {
+		case "unsupported currency", "regional price cannot be in the base currency", "a game cannot have two prices in the same currency":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -84,7 +89,7 @@ func (h *GameHandler) GetGameByID(c *gin.Context) {
 	}
 
 	// Get game by ID
-	game, err := h.gameService.GetGameByID(id)
+	game, err := h.gameService.GetGameByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
 		return
@@ -122,7 +127,7 @@ func (h *GameHandler) GetAllGames(c *gin.Context) {
 	}
 
 	// Get all games with pagination
-	games, err := h.gameService.GetAllGames(limit, offset)
+	games, err := h.gameService.GetAllGames(c.Request.Context(), limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -161,9 +166,14 @@ func (h *GameHandler) UpdateGame(c *gin.Context) {
 	}
 
 	// Update game
-	updatedGame, err := h.gameService.UpdateGame(id, &gameDTO)
+	updatedGame, err := h.gameService.UpdateGame(c.Request.Context(), id, &gameDTO)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch err.Error() {
+		case "unsupported currency", "regional price cannot be in the base currency", "a game cannot have two prices in the same currency":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -192,7 +202,7 @@ func (h *GameHandler) DeleteGame(c *gin.Context) {
 	}
 
 	// Delete game
-	if err := h.gameService.DeleteGame(id); err != nil {
+	if err := h.gameService.DeleteGame(c.Request.Context(), id); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -237,7 +247,7 @@ func (h *GameHandler) SearchGamesByTitle(c *gin.Context) {
 	}
 
 	// Search games by title
-	games, err := h.gameService.SearchGamesByTitle(title, limit, offset)
+	games, err := h.gameService.SearchGamesByTitle(c.Request.Context(), title, limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -266,7 +276,7 @@ func (h *GameHandler) GetGamesByCategory(c *gin.Context) {
 	}
 
 	// Get games by category
-	games, err := h.gameService.GetGamesByCategory(id)
+	games, err := h.gameService.GetGamesByCategory(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -304,7 +314,7 @@ func (h *GameHandler) GetAllCategories(c *gin.Context) {
 	}
 
 	// Get all categories
-	categories, err := h.categoryService.GetAllCategories(limit, offset)
+	categories, err := h.categoryService.GetAllCategories(c.Request.Context(), limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -342,7 +352,7 @@ func (h *GameHandler) GetAllDevelopers(c *gin.Context) {
 	}
 
 	// Get all developers
-	developers, err := h.developerService.GetAllDevelopers(limit, offset)
+	developers, err := h.developerService.GetAllDevelopers(c.Request.Context(), limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -351,6 +361,124 @@ func (h *GameHandler) GetAllDevelopers(c *gin.Context) {
 	c.JSON(http.StatusOK, developers)
 }
 
+// GetDeveloperMembers handles listing the accounts linked to a developer
+// @Summary Get developer members
+// @Description Returns the user accounts allowed to act on behalf of a developer (admin only)
+// @Tags Developers
+// @Accept json
+// @Produce json
+// @Param developer_id path int true "Developer ID"
+// @Success 200 {array} dto.DeveloperMemberDTO "List of members"
+// @Failure 400 {object} map[string]interface{} "Invalid developer ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Developer not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/developers/{developer_id}/members [get]
+func (h *GameHandler) GetDeveloperMembers(c *gin.Context) {
+	developerID, err := strconv.Atoi(c.Param("developer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid developer ID"})
+		return
+	}
+
+	members, err := h.developerService.GetDeveloperMembers(c.Request.Context(), developerID)
+	if err != nil {
+		if err.Error() == "developer not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Developer not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// AddDeveloperMember handles linking a user account to a developer
+// @Summary Add developer member
+// @Description Links a user account to a developer so it can reply to reviews of the developer's games (admin only)
+// @Tags Developers
+// @Accept json
+// @Produce json
+// @Param developer_id path int true "Developer ID"
+// @Param user_id path int true "User ID"
+// @Success 200 {object} map[string]interface{} "Member added successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Developer or user not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/developers/{developer_id}/members/{user_id} [post]
+func (h *GameHandler) AddDeveloperMember(c *gin.Context) {
+	developerID, userID, ok := parseDeveloperMemberParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.developerService.AddDeveloperMember(c.Request.Context(), developerID, userID); err != nil {
+		switch err.Error() {
+		case "developer not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Developer not found"})
+		case "user not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member added successfully"})
+}
+
+// RemoveDeveloperMember handles unlinking a user account from a developer
+// @Summary Remove developer member
+// @Description Unlinks a user account from a developer (admin only)
+// @Tags Developers
+// @Accept json
+// @Produce json
+// @Param developer_id path int true "Developer ID"
+// @Param user_id path int true "User ID"
+// @Success 200 {object} map[string]interface{} "Member removed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/developers/{developer_id}/members/{user_id} [delete]
+func (h *GameHandler) RemoveDeveloperMember(c *gin.Context) {
+	developerID, userID, ok := parseDeveloperMemberParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.developerService.RemoveDeveloperMember(c.Request.Context(), developerID, userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+// parseDeveloperMemberParams reads the developer_id and user_id path parameters
+func parseDeveloperMemberParams(c *gin.Context) (int, int, bool) {
+	developerID, err := strconv.Atoi(c.Param("developer_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid developer ID"})
+		return 0, 0, false
+	}
+
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return 0, 0, false
+	}
+
+	return developerID, userID, true
+}
+
 // GetTopSellingGames handles getting top selling games
 // @Summary Get top selling games
 // @Description Returns a list of top selling games
@@ -372,7 +500,7 @@ func (h *GameHandler) GetTopSellingGames(c *gin.Context) {
 	}
 
 	// Get top selling games
-	games, err := h.gameService.GetTopSellingGames(limit)
+	games, err := h.gameService.GetTopSellingGames(c.Request.Context(), limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -402,7 +530,7 @@ func (h *GameHandler) GetDiscountedGames(c *gin.Context) {
 	}
 
 	// Get discounted games
-	games, err := h.gameService.GetDiscountedGames(limit)
+	games, err := h.gameService.GetDiscountedGames(c.Request.Context(), limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"uniStore/Backend/internal/domain/services"
+	"uniStore/Backend/internal/interfaces/dto"
+)
+
+// GiftHandler handles HTTP requests related to gifts
+type GiftHandler struct {
+	giftService services.GiftService
+}
+
+// NewGiftHandler creates a new gift handler
+func NewGiftHandler(giftService services.GiftService) *GiftHandler {
+	return &GiftHandler{
+		giftService: giftService,
+	}
+}
+
+// GetReceivedGifts retrieves the gifts sent to the authenticated user
+// @Summary Get received gifts
+// @Description Returns the gifts sent to the authenticated user, newest first
+// @Tags Gifts
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.GiftDTO "List of gifts"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/gifts [get]
+func (h *GiftHandler) GetReceivedGifts(c *gin.Context) {
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	gifts, err := h.giftService.GetReceivedGifts(c.Request.Context(), tokenUserID.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gifts)
+}
+
+// AcceptGift accepts a pending gift
+// @Summary Accept a gift
+// @Description Adds a pending gift to the authenticated user's library
+// @Tags Gifts
+// @Accept json
+// @Produce json
+// @Param gift_id path int true "Gift ID"
+// @Success 200 {object} dto.GiftDTO "Gift accepted"
+// @Failure 400 {object} map[string]interface{} "Invalid gift ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Gift not found"
+// @Failure 409 {object} map[string]interface{} "Gift already answered"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/gifts/{gift_id}/accept [post]
+func (h *GiftHandler) AcceptGift(c *gin.Context) {
+	h.respondToGift(c, h.giftService.AcceptGift)
+}
+
+// DeclineGift declines a pending gift
+// @Summary Decline a gift
+// @Description Turns a pending gift down and refunds it to the sender
+// @Tags Gifts
+// @Accept json
+// @Produce json
+// @Param gift_id path int true "Gift ID"
+// @Success 200 {object} dto.GiftDTO "Gift declined"
+// @Failure 400 {object} map[string]interface{} "Invalid gift ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Gift not found"
+// @Failure 409 {object} map[string]interface{} "Gift already answered"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/gifts/{gift_id}/decline [post]
+func (h *GiftHandler) DeclineGift(c *gin.Context) {
+	h.respondToGift(c, h.giftService.DeclineGift)
+}
+
+// respondToGift applies the recipient's answer to the gift from the path
+func (h *GiftHandler) respondToGift(c *gin.Context, answer func(ctx context.Context, giftID, userID int) (*dto.GiftDTO, error)) {
+	id, err := strconv.Atoi(c.Param("gift_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid gift ID"})
+		return
+	}
+
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	gift, err := answer(c.Request.Context(), id, tokenUserID.(int))
+	if err != nil {
+		switch err.Error() {
+		case "gift not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Gift not found"})
+		case "gift has already been answered":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gift)
+}
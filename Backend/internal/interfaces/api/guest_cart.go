@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"uniStore/Backend/internal/utils"
+)
+
+const (
+	// guestCartCookie is the cookie holding the signed guest cart token
+	guestCartCookie = "guest_cart"
+	// guestCartHeader carries the signed guest cart token for clients that do not keep cookies
+	guestCartHeader = "X-Cart-Token"
+	// guestCartMaxAge is how long a browser keeps the guest cart cookie, in seconds
+	guestCartMaxAge = 30 * 24 * 60 * 60
+)
+
+// GuestCartTokens hands guest cart tokens to clients and reads them back, signed so
+// clients cannot pick another guest's cart
+type GuestCartTokens struct {
+	signer *utils.TokenSigner
+	secure bool
+}
+
+// NewGuestCartTokens creates guest cart tokens signed with signer. Secure cookies are only
+// sent over HTTPS.
+func NewGuestCartTokens(signer *utils.TokenSigner, secure bool) *GuestCartTokens {
+	return &GuestCartTokens{signer: signer, secure: secure}
+}
+
+// Read returns the verified guest cart token of the request, or "" if there is none
+func (g *GuestCartTokens) Read(c *gin.Context) string {
+	signed := c.GetHeader(guestCartHeader)
+	if signed == "" {
+		signed, _ = c.Cookie(guestCartCookie)
+	}
+	if signed == "" {
+		return ""
+	}
+
+	token, ok := g.signer.Verify(signed)
+	if !ok {
+		return ""
+	}
+	return token
+}
+
+// Write hands the signed guest cart token to the client in a cookie and a header
+func (g *GuestCartTokens) Write(c *gin.Context, token string) {
+	signed := g.signer.Sign(token)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(guestCartCookie, signed, guestCartMaxAge, "/", "", g.secure, true)
+	c.Header(guestCartHeader, signed)
+}
+
+// Clear removes the guest cart cookie
+func (g *GuestCartTokens) Clear(c *gin.Context) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(guestCartCookie, "", -1, "/", "", g.secure, true)
+}
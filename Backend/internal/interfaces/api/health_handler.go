@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"uniStore/Backend/internal/infrastructure/database"
+)
+
+// readinessTimeout bounds the database checks of a readiness probe
+const readinessTimeout = 2 * time.Second
+
+// Lifecycle states of an instance
+const (
+	stateStarting int32 = iota
+	stateReady
+	stateDraining
+)
+
+// HealthHandler reports whether the instance is alive and ready to serve traffic
+type HealthHandler struct {
+	db    *database.Database
+	state atomic.Int32
+}
+
+// NewHealthHandler creates a new health handler. The instance starts out not ready.
+func NewHealthHandler(db *database.Database) *HealthHandler {
+	return &HealthHandler{
+		db: db,
+	}
+}
+
+// SetReady marks the instance as ready to serve traffic, e.g. once migrations have run
+func (h *HealthHandler) SetReady() {
+	h.state.Store(stateReady)
+}
+
+// SetDraining marks the instance as shutting down, so it stops taking new traffic
+func (h *HealthHandler) SetDraining() {
+	h.state.Store(stateDraining)
+}
+
+// IsReady reports whether the instance takes traffic
+func (h *HealthHandler) IsReady() bool {
+	return h.state.Load() == stateReady
+}
+
+// Liveness reports that the process is running
+// @Summary Liveness probe
+// @Description Returns 200 while the process is running
+// @Tags Health
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Alive"
+// @Router /healthz [get]
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readiness reports whether the instance can serve traffic
+// @Summary Readiness probe
+// @Description Returns 200 once the instance has started, the database can be reached and every migration is applied, and 503 otherwise, including while the instance shuts down
+// @Tags Health
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Ready"
+// @Failure 503 {object} map[string]interface{} "Not ready"
+// @Router /readyz [get]
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	switch h.state.Load() {
+	case stateStarting:
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "starting"})
+		return
+	case stateDraining:
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "draining"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	if err := h.db.Ping(ctx); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "error": "database unreachable"})
+		return
+	}
+
+	pending, err := h.db.PendingMigrations(ctx)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "error": "failed to read migrations"})
+		return
+	}
+	if pending > 0 {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "error": "migrations pending", "pending": pending})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ready"})
+}
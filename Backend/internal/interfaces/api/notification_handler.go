@@ -0,0 +1,187 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"uniStore/Backend/internal/domain/services"
+	"uniStore/Backend/internal/interfaces/dto"
+)
+
+// NotificationHandler handles HTTP requests related to notifications
+type NotificationHandler struct {
+	notificationService services.NotificationService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationService services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetNotifications retrieves the authenticated user's notifications
+// @Summary Get notifications
+// @Description Returns the authenticated user's notifications, newest first, with the number of unread ones
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Offset (default 0)"
+// @Success 200 {object} dto.NotificationPageDTO "Page of notifications"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var queryDTO dto.NotificationListQueryDTO
+	if err := c.ShouldBindQuery(&queryDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	notifications, err := h.notificationService.GetNotifications(c.Request.Context(), tokenUserID.(int), &queryDTO)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// MarkRead marks a notification as read
+// @Summary Mark a notification as read
+// @Description Marks one of the authenticated user's notifications as read
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param notification_id path int true "Notification ID"
+// @Success 200 {object} map[string]interface{} "Notification marked as read"
+// @Failure 400 {object} map[string]interface{} "Invalid notification ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Notification not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/notifications/{notification_id}/read [put]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	notificationID, err := strconv.Atoi(c.Param("notification_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	if err := h.notificationService.MarkRead(c.Request.Context(), tokenUserID.(int), notificationID); err != nil {
+		if err.Error() == "notification not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+// MarkAllRead marks all notifications as read
+// @Summary Mark all notifications as read
+// @Description Marks all of the authenticated user's notifications as read
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Notifications marked as read"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/notifications/read-all [put]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.notificationService.MarkAllRead(c.Request.Context(), tokenUserID.(int)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notifications marked as read"})
+}
+
+// GetPreferences retrieves the authenticated user's notification preferences
+// @Summary Get notification preferences
+// @Description Returns which wishlist notifications the authenticated user gets and whether they are emailed
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.NotificationPreferenceDTO "Notification preferences"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/notifications/preferences [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	preferences, err := h.notificationService.GetPreferences(c.Request.Context(), tokenUserID.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+// UpdatePreferences changes the authenticated user's notification preferences
+// @Summary Update notification preferences
+// @Description Changes which wishlist notifications the authenticated user gets and whether they are emailed. Omitted fields are left unchanged.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param preferences body dto.NotificationPreferenceUpdateDTO true "Notification preferences"
+// @Success 200 {object} dto.NotificationPreferenceDTO "Updated notification preferences"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/notifications/preferences [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var preferenceDTO dto.NotificationPreferenceUpdateDTO
+	if err := c.ShouldBindJSON(&preferenceDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preferences, err := h.notificationService.UpdatePreferences(c.Request.Context(), tokenUserID.(int), &preferenceDTO)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
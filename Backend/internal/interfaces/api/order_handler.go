@@ -1,7 +1,13 @@
 package api
 
 import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
 	"uniStore/Backend/internal/domain/services"
+	"uniStore/Backend/internal/interfaces/dto"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,21 +26,67 @@ func NewOrderHandler(orderService services.OrderService) *OrderHandler {
 
 // CreateOrderFromCart creates an order from a user's cart
 // @Summary Create order from cart
-// @Description Creates a new order from the items in a user's shopping cart
+// @Description Creates a new order from the items in a user's shopping cart, applying the cart's coupon. Loyalty points can be redeemed as a further discount, and store credit in the order currency spent on the rest with use_credit. The cart version returned by the cart must be sent back in the If-Match header or the cart_version field; checkout is refused if the cart has changed since.
 // @Tags Orders
 // @Accept json
 // @Produce json
 // @Param user_id path int true "User ID"
-// @Success 201 {object} dto.OrderCreateDTO "Order created successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Param If-Match header string false "Cart version"
+// @Param checkout body dto.OrderCheckoutDTO false "Checkout options"
+// @Success 201 {object} dto.OrderResponseDTO "Order created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input, empty cart, invalid coupon or too many points, or no region to tax the order by"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden"
-// @Failure 404 {object} map[string]interface{} "Cart not found or empty"
+// @Failure 404 {object} map[string]interface{} "Cart or coupon not found"
+// @Failure 409 {object} map[string]interface{} "Insufficient points or store credit, coupon used up, gift recipient already owns a game or items no longer for sale"
+// @Failure 412 {object} map[string]interface{} "Cart changed since it was reviewed"
+// @Failure 428 {object} map[string]interface{} "Cart version missing"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /api/v1/orders/{user_id}/create [post]
 func (h *OrderHandler) CreateOrderFromCart(c *gin.Context) {
-	// Implementation to be added
+	uid, ok := authorizeOrderUser(c)
+	if !ok {
+		return
+	}
+
+	// The checkout body is optional
+	var checkoutDTO dto.OrderCheckoutDTO
+	if err := c.ShouldBindJSON(&checkoutDTO); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if checkoutDTO.CartVersion == "" {
+		checkoutDTO.CartVersion = readCartVersion(c)
+	}
+
+	order, err := h.orderService.CreateOrderFromCart(c.Request.Context(), uid, &checkoutDTO)
+	if err != nil {
+		switch err.Error() {
+		case "cart version is required":
+			c.JSON(http.StatusPreconditionRequired, gin.H{"error": err.Error()})
+		case "cart has changed since it was reviewed":
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		case "cart not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cart not found"})
+		case "coupon not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Coupon not found"})
+		case "cart is empty", "too many points redeemed for this order", "you cannot gift a game to yourself",
+			"coupon is not active", "coupon is not valid yet", "coupon has expired",
+			"cart total is below the coupon minimum", "coupon does not apply to any game in the cart",
+			"coupon is not valid in your currency", "region is required to calculate tax":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "insufficient points", "insufficient store credit", "recipient already owns this game", "recipient already has this game as a pending gift",
+			"coupon usage limit reached", "you have already used this coupon", "you already own every game in this bundle",
+			"you already own this game", "game is already in a pending order", "cart contains items that can no longer be bought":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
 }
 
 // GetOrderByID retrieves an order by ID
@@ -53,7 +105,148 @@ func (h *OrderHandler) CreateOrderFromCart(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/orders/{order_id} [get]
 func (h *OrderHandler) GetOrderByID(c *gin.Context) {
-	// Implementation to be added
+	id, err := strconv.Atoi(c.Param("order_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	order, err := h.orderService.GetOrderByID(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "order not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Only allow users to see their own orders or admin to see any order
+	if order.UserID != tokenUserID.(int) && c.GetString("role") != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only view your own orders"})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// CancelPreOrder cancels a pre-ordered game before its release
+// @Summary Cancel a pre-order
+// @Description Cancels one of the authenticated user's pre-ordered games before its release. The game's share of the paid order is refunded and its locked copy, or unanswered gift, is withdrawn.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param item_id path int true "Order item ID"
+// @Success 200 {object} dto.OrderResponseDTO "Order with the pre-order cancelled"
+// @Failure 400 {object} map[string]interface{} "Invalid item ID, item is not a pre-order or order not paid"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Order item not found"
+// @Failure 409 {object} map[string]interface{} "Pre-order already cancelled or game already released"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/orders/items/{item_id}/cancel-preorder [post]
+func (h *OrderHandler) CancelPreOrder(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	order, err := h.orderService.CancelPreOrder(c.Request.Context(), tokenUserID.(int), itemID)
+	if err != nil {
+		switch err.Error() {
+		case "order item not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order item not found"})
+		case "item is not a pre-order", "only paid pre-orders can be cancelled":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "pre-order is already cancelled", "game has already been released":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// GetInvoice downloads the invoice of a paid order
+// @Summary Download an order invoice
+// @Description Returns the invoice issued when the order was paid, as a PDF document or an HTML page. Orders paid before invoicing was introduced get their invoice on first download.
+// @Tags Orders
+// @Produce application/pdf
+// @Produce text/html
+// @Param order_id path int true "Order ID"
+// @Param format query string false "Document format: pdf or html" default(pdf)
+// @Success 200 {file} file "Invoice document"
+// @Failure 400 {object} map[string]interface{} "Invalid order ID or unsupported format"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Order not found"
+// @Failure 409 {object} map[string]interface{} "Order has not been paid"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/orders/{order_id}/invoice [get]
+func (h *OrderHandler) GetInvoice(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("order_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	order, err := h.orderService.GetOrderByID(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "order not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Only allow users to download their own invoices or admin to download any invoice
+	if order.UserID != tokenUserID.(int) && c.GetString("role") != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only view your own invoices"})
+		return
+	}
+
+	document, err := h.orderService.GetInvoice(c.Request.Context(), id, c.DefaultQuery("format", dto.InvoiceFormatPDF))
+	if err != nil {
+		switch err.Error() {
+		case "order not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		case "unsupported invoice format":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "order has not been paid":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if document.ContentType == "application/pdf" {
+		c.Header("Content-Disposition", `attachment; filename="`+document.Number+`.pdf"`)
+	}
+	c.Data(http.StatusOK, document.ContentType, document.Content)
 }
 
 // GetUserOrders retrieves all orders for a user
@@ -71,7 +264,18 @@ func (h *OrderHandler) GetOrderByID(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/orders/user/{user_id} [get]
 func (h *OrderHandler) GetUserOrders(c *gin.Context) {
-	// Implementation to be added
+	uid, ok := authorizeOrderUser(c)
+	if !ok {
+		return
+	}
+
+	orders, err := h.orderService.GetUserOrders(c.Request.Context(), uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
 }
 
 // GetAllOrders retrieves all orders
@@ -83,11 +287,101 @@ func (h *OrderHandler) GetUserOrders(c *gin.Context) {
 // @Param limit query int false "Limit" default(10)
 // @Param offset query int false "Offset" default(0)
 // @Success 200 {array} dto.OrderResponseDTO "List of all orders"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /api/v1/orders [get]
 func (h *OrderHandler) GetAllOrders(c *gin.Context) {
-	// Implementation to be added
+	// Parse pagination parameters
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+		return
+	}
+
+	orders, err := h.orderService.GetAllOrders(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// UpdateOrderStatus changes the status of an order
+// @Summary Update order status
+// @Description Marks an order as paid, cancelled or refunded and settles its loyalty points (admin only). A refund takes back every copy the order granted, including accepted gifts.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param order_id path int true "Order ID"
+// @Param status body dto.OrderUpdateDTO true "New status"
+// @Success 200 {object} dto.OrderResponseDTO "Order updated"
+// @Failure 400 {object} map[string]interface{} "Invalid input or status transition"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Order not found"
+// @Failure 409 {object} map[string]interface{} "Order status changed concurrently"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/orders/{order_id}/status [patch]
+func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("order_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+
+	var statusDTO dto.OrderUpdateDTO
+	if err := c.BindJSON(&statusDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.orderService.UpdateOrderStatus(c.Request.Context(), id, &statusDTO)
+	if err != nil {
+		switch err.Error() {
+		case "order not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		case "order is already closed", "paid orders must be refunded", "only paid orders can be refunded":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "order status has changed":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// authorizeOrderUser checks that the user_id path parameter belongs to the caller (or the caller is an admin)
+func authorizeOrderUser(c *gin.Context) (int, bool) {
+	uid, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return 0, false
+	}
+
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, false
+	}
+
+	if tokenUserID.(int) != uid && c.GetString("role") != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only manage your own orders"})
+		return 0, false
+	}
+
+	return uid, true
 }
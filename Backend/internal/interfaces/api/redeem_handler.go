@@ -0,0 +1,145 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"uniStore/Backend/internal/domain/services"
+	"uniStore/Backend/internal/interfaces/dto"
+)
+
+// RedeemHandler handles HTTP requests related to redeem codes
+type RedeemHandler struct {
+	redeemService services.RedeemService
+}
+
+// NewRedeemHandler creates a new redeem handler
+func NewRedeemHandler(redeemService services.RedeemService) *RedeemHandler {
+	return &RedeemHandler{
+		redeemService: redeemService,
+	}
+}
+
+// GenerateCodes generates a batch of redeem codes
+// @Summary Generate redeem codes
+// @Description Generates unique codes granting a game or store credit, with optional expiry and a number of uses per code (admin only)
+// @Tags Redeem
+// @Accept json
+// @Produce json
+// @Param codes body dto.RedeemCodeGenerateDTO true "Batch settings"
+// @Success 201 {array} dto.RedeemCodeDTO "Generated codes"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/redeem-codes [post]
+func (h *RedeemHandler) GenerateCodes(c *gin.Context) {
+	var generateDTO dto.RedeemCodeGenerateDTO
+	if err := c.BindJSON(&generateDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.redeemService.GenerateCodes(c.Request.Context(), &generateDTO)
+	if err != nil {
+		switch err.Error() {
+		case "game not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		case "a code must grant either a game or store credit", "expiry must be in the future", "unsupported currency":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, codes)
+}
+
+// GetCodes retrieves generated redeem codes
+// @Summary Get redeem codes
+// @Description Returns generated codes and who redeemed them, newest first (admin only)
+// @Tags Redeem
+// @Accept json
+// @Produce json
+// @Param limit query int false "Limit" default(100)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} dto.RedeemCodeDTO "List of codes"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/redeem-codes [get]
+func (h *RedeemHandler) GetCodes(c *gin.Context) {
+	// Parse pagination parameters
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+		return
+	}
+
+	codes, err := h.redeemService.GetCodes(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+// Redeem redeems a code for the authenticated user
+// @Summary Redeem a code
+// @Description Adds the code's game to the user's library or credits its store credit
+// @Tags Redeem
+// @Accept json
+// @Produce json
+// @Param code body dto.RedeemDTO true "Code"
+// @Success 200 {object} dto.RedeemResultDTO "Code redeemed"
+// @Failure 400 {object} map[string]interface{} "Invalid input or expired code"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Code not found"
+// @Failure 409 {object} map[string]interface{} "Code used up, already redeemed or game already owned"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/redeem [post]
+func (h *RedeemHandler) Redeem(c *gin.Context) {
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var redeemDTO dto.RedeemDTO
+	if err := c.BindJSON(&redeemDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.redeemService.Redeem(c.Request.Context(), tokenUserID.(int), &redeemDTO)
+	if err != nil {
+		switch err.Error() {
+		case "code not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Code not found"})
+		case "code has expired":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "code has already been used", "you have already redeemed this code", "you already own this game":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -1,9 +1,14 @@
 package api
 
 import (
+	"context"
+	"net/http"
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 
 	"uniStore/Backend/internal/domain/services"
+	"uniStore/Backend/internal/interfaces/dto"
 )
 
 // ReviewHandler handles HTTP requests related to reviews
@@ -20,20 +25,50 @@ func NewReviewHandler(reviewService services.ReviewService) *ReviewHandler {
 
 // CreateReview creates a new review
 // @Summary Create a new review
-// @Description Creates a new review for a game
+// @Description Creates a new review for a game on behalf of the authenticated user. A user can review a game only once.
 // @Tags Reviews
 // @Accept json
 // @Produce json
 // @Param review body dto.ReviewCreateDTO true "Review data"
-// @Success 201 {object} dto.ReviewCreateDTO "Review created successfully"
+// @Success 201 {object} dto.ReviewResponseDTO "Review created successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid input"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Only owners of the game can review it"
 // @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 409 {object} map[string]interface{} "Game already reviewed by the user"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security ApiKeyAuth
 // @Router /api/v1/reviews [post]
 func (h *ReviewHandler) CreateReview(c *gin.Context) {
-	// Implementation to be added
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var reviewDTO dto.ReviewCreateDTO
+	if err := c.BindJSON(&reviewDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The author always comes from the token
+	review, err := h.reviewService.CreateReview(c.Request.Context(), tokenUserID.(int), &reviewDTO)
+	if err != nil {
+		switch err.Error() {
+		case "game not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "you have already reviewed this game":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case "only owners of the game can review it":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, review)
 }
 
 // GetReviewByID retrieves a review by ID
@@ -49,23 +84,68 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/reviews/{review_id} [get]
 func (h *ReviewHandler) GetReviewByID(c *gin.Context) {
-	// Implementation to be added
+	id, err := strconv.Atoi(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	review, err := h.reviewService.GetReviewByID(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "review not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
 }
 
-// GetReviewsByGameID retrieves all reviews for a game
+// GetReviewsByGameID retrieves a page of reviews for a game
 // @Summary Get reviews by game ID
-// @Description Returns all reviews for a specific game
+// @Description Returns a sorted, filtered and paginated list of visible reviews for a specific game
 // @Tags Reviews
 // @Accept json
 // @Produce json
 // @Param game_id path int true "Game ID"
-// @Success 200 {array} dto.ReviewResponseDTO "List of reviews"
-// @Failure 400 {object} map[string]interface{} "Invalid game ID"
+// @Param sort query string false "Sort mode" Enums(helpful, newest, highest, lowest) default(helpful)
+// @Param rating query int false "Only reviews with this rating (1-5)"
+// @Param limit query int false "Limit" default(10)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} dto.ReviewPageDTO "Page of reviews"
+// @Failure 400 {object} map[string]interface{} "Invalid game ID or query parameters"
 // @Failure 404 {object} map[string]interface{} "Game not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/reviews/game/{game_id} [get]
 func (h *ReviewHandler) GetReviewsByGameID(c *gin.Context) {
-	// Implementation to be added
+	gameID, err := strconv.Atoi(c.Param("game_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	var queryDTO dto.ReviewListQueryDTO
+	if err := c.ShouldBindQuery(&queryDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reviews, err := h.reviewService.GetReviewsByGameID(c.Request.Context(), gameID, &queryDTO)
+	if err != nil {
+		switch err.Error() {
+		case "invalid sort mode":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "game not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
 }
 
 // UpdateReview updates a review
@@ -77,7 +157,7 @@ func (h *ReviewHandler) GetReviewsByGameID(c *gin.Context) {
 // @Param review_id path int true "Review ID"
 // @Param user_id path int true "User ID"
 // @Param review body dto.ReviewUpdateDTO true "Updated review data"
-// @Success 200 {object} dto.ReviewUpdateDTO "Review updated successfully"
+// @Success 200 {object} dto.ReviewResponseDTO "Review updated successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid input"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden"
@@ -86,7 +166,30 @@ func (h *ReviewHandler) GetReviewsByGameID(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/reviews/{review_id}/user/{user_id} [patch]
 func (h *ReviewHandler) UpdateReview(c *gin.Context) {
-	// Implementation to be added
+	id, err := strconv.Atoi(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	uid, ok := h.authorizeReviewAuthor(c)
+	if !ok {
+		return
+	}
+
+	var reviewDTO dto.ReviewUpdateDTO
+	if err := c.BindJSON(&reviewDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.reviewService.UpdateReview(c.Request.Context(), id, uid, &reviewDTO)
+	if err != nil {
+		writeReviewOwnershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
 }
 
 // DeleteReview deletes a review
@@ -106,5 +209,343 @@ func (h *ReviewHandler) UpdateReview(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/reviews/{review_id}/user/{user_id} [delete]
 func (h *ReviewHandler) DeleteReview(c *gin.Context) {
-	// Implementation to be added
+	id, err := strconv.Atoi(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	uid, ok := h.authorizeReviewAuthor(c)
+	if !ok {
+		return
+	}
+
+	if err := h.reviewService.DeleteReview(c.Request.Context(), id, uid); err != nil {
+		writeReviewOwnershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Review deleted successfully"})
+}
+
+// ReportReview reports an abusive review
+// @Summary Report a review
+// @Description Reports a review to the moderators. A user can report a review only once.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param review_id path int true "Review ID"
+// @Param report body dto.ReviewReportCreateDTO true "Report reason"
+// @Success 201 {object} dto.ReviewReportDTO "Review reported successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Review not found"
+// @Failure 409 {object} map[string]interface{} "Review already reported by the user"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/{review_id}/report [post]
+func (h *ReviewHandler) ReportReview(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var reportDTO dto.ReviewReportCreateDTO
+	if err := c.BindJSON(&reportDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.reviewService.ReportReview(c.Request.Context(), id, tokenUserID.(int), &reportDTO)
+	if err != nil {
+		switch err.Error() {
+		case "review not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		case "you cannot report your own review":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "you have already reported this review":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// VoteReview records a helpfulness vote on a review
+// @Summary Vote on a review
+// @Description Marks a review as helpful or unhelpful. Each user has one vote per review and may change it.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param review_id path int true "Review ID"
+// @Param vote body dto.ReviewVoteDTO true "Vote"
+// @Success 200 {object} dto.ReviewResponseDTO "Vote recorded"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Review not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/{review_id}/vote [post]
+func (h *ReviewHandler) VoteReview(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var voteDTO dto.ReviewVoteDTO
+	if err := c.BindJSON(&voteDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.reviewService.VoteReview(c.Request.Context(), id, tokenUserID.(int), &voteDTO)
+	if err != nil {
+		switch err.Error() {
+		case "review not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+		case "you cannot vote on your own review":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// ReplyToReview posts or edits the developer reply to a review
+// @Summary Reply to a review
+// @Description Posts the developer's public reply to a review, replacing any earlier reply. Only accounts linked to the game's developer and admins may reply.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param review_id path int true "Review ID"
+// @Param reply body dto.ReviewReplyCreateDTO true "Reply"
+// @Success 200 {object} dto.ReviewReplyDTO "Reply saved"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Not a member of the game's developer"
+// @Failure 404 {object} map[string]interface{} "Review not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/{review_id}/reply [put]
+func (h *ReviewHandler) ReplyToReview(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var replyDTO dto.ReviewReplyCreateDTO
+	if err := c.BindJSON(&replyDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reply, err := h.reviewService.ReplyToReview(c.Request.Context(), id, tokenUserID.(int), c.GetString("role") == "admin", &replyDTO)
+	if err != nil {
+		writeReviewReplyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reply)
+}
+
+// DeleteReply removes the developer reply from a review
+// @Summary Delete a review reply
+// @Description Removes the developer's reply from a review. Only accounts linked to the game's developer and admins may delete it.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param review_id path int true "Review ID"
+// @Success 200 {object} map[string]interface{} "Reply deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid review ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Not a member of the game's developer"
+// @Failure 404 {object} map[string]interface{} "Review or reply not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/{review_id}/reply [delete]
+func (h *ReviewHandler) DeleteReply(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.reviewService.DeleteReply(c.Request.Context(), id, tokenUserID.(int), c.GetString("role") == "admin"); err != nil {
+		writeReviewReplyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reply deleted successfully"})
+}
+
+// GetModerationQueue retrieves reviews awaiting moderation
+// @Summary Get review moderation queue
+// @Description Returns held reviews and reviews with open reports (admin only)
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} dto.ReviewModerationDTO "Moderation queue"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/moderation [get]
+func (h *ReviewHandler) GetModerationQueue(c *gin.Context) {
+	var queryDTO dto.ReviewModerationQueryDTO
+	if err := c.ShouldBindQuery(&queryDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	queue, err := h.reviewService.GetModerationQueue(c.Request.Context(), queryDTO.Limit, queryDTO.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// ApproveReview approves a review
+// @Summary Approve a review
+// @Description Makes a review visible and resolves its reports (admin only)
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param review_id path int true "Review ID"
+// @Success 200 {object} dto.ReviewResponseDTO "Review approved"
+// @Failure 400 {object} map[string]interface{} "Invalid review ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Review not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/{review_id}/approve [post]
+func (h *ReviewHandler) ApproveReview(c *gin.Context) {
+	h.moderateReview(c, h.reviewService.ApproveReview)
+}
+
+// HideReview takes a review down
+// @Summary Hide a review
+// @Description Hides a review from the public and resolves its reports (admin only)
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param review_id path int true "Review ID"
+// @Success 200 {object} dto.ReviewResponseDTO "Review hidden"
+// @Failure 400 {object} map[string]interface{} "Invalid review ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Review not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/reviews/{review_id}/hide [post]
+func (h *ReviewHandler) HideReview(c *gin.Context) {
+	h.moderateReview(c, h.reviewService.HideReview)
+}
+
+// moderateReview applies a moderation action to the review from the path
+func (h *ReviewHandler) moderateReview(c *gin.Context, action func(ctx context.Context, id int) (*dto.ReviewResponseDTO, error)) {
+	id, err := strconv.Atoi(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	review, err := action(c.Request.Context(), id)
+	if err != nil {
+		if err.Error() == "review not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// authorizeReviewAuthor checks that the user_id path parameter belongs to the caller (or the caller is an admin)
+func (h *ReviewHandler) authorizeReviewAuthor(c *gin.Context) (int, bool) {
+	uid, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return 0, false
+	}
+
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, false
+	}
+
+	// Only allow users to manage their own reviews or admin to manage any review
+	if tokenUserID.(int) != uid && c.GetString("role") != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only manage your own reviews"})
+		return 0, false
+	}
+
+	return uid, true
+}
+
+// writeReviewOwnershipError maps review update/delete errors to HTTP responses
+func writeReviewOwnershipError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "review not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+	case "review belongs to another user":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// writeReviewReplyError maps review reply errors to HTTP responses
+func writeReviewReplyError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "review not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+	case "reply not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reply not found"})
+	case "only the game's developer can reply to its reviews":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
 }
@@ -1,32 +1,55 @@
 package api
 
 import (
+	"log/slog"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
+	"uniStore/Backend/internal/config"
 	"uniStore/Backend/internal/domain/services"
 	"uniStore/Backend/internal/infrastructure/database"
+	"uniStore/Backend/internal/infrastructure/invoice"
+	"uniStore/Backend/internal/infrastructure/mail"
+	"uniStore/Backend/internal/infrastructure/metrics"
 	"uniStore/Backend/internal/infrastructure/repositories"
+	"uniStore/Backend/internal/infrastructure/scheduler"
 	"uniStore/Backend/internal/interfaces/middleware"
 	"uniStore/Backend/internal/utils"
 )
 
 // Server represents the API server
 type Server struct {
-	DB              *database.Database
-	Router          *gin.Engine
-	UserHandler     *UserHandler
-	GameHandler     *GameHandler
-	CartHandler     *CartHandler
-	OrderHandler    *OrderHandler
-	ReviewHandler   *ReviewHandler
-	FavoriteHandler *FavoriteHandler
-	LibraryHandler  *LibraryHandler
+	Config              *config.Config
+	DB                  *database.Database
+	Router              *gin.Engine
+	AuthUtils           *utils.AuthUtils
+	Logger              *slog.Logger
+	Metrics             *metrics.Metrics
+	UserHandler         *UserHandler
+	GameHandler         *GameHandler
+	CartHandler         *CartHandler
+	OrderHandler        *OrderHandler
+	ReviewHandler       *ReviewHandler
+	GiftHandler         *GiftHandler
+	RedeemHandler       *RedeemHandler
+	CouponHandler       *CouponHandler
+	BundleHandler       *BundleHandler
+	FavoriteHandler     *FavoriteHandler
+	LibraryHandler      *LibraryHandler
+	NotificationHandler *NotificationHandler
+	TaxHandler          *TaxHandler
+	HealthHandler       *HealthHandler
+	Scheduler           *scheduler.Scheduler // Background jobs, started by the caller
 }
 
-// NewServer creates a new API server
-func NewServer(db *database.Database, router *gin.Engine) *Server {
+// NewServer creates a new API server; its services count business events in stats
+func NewServer(cfg *config.Config, db *database.Database, router *gin.Engine, logger *slog.Logger, stats *metrics.Metrics) *Server {
 	// Initialize auth utils
-	authUtils := utils.NewAuthUtils()
+	authUtils := utils.NewAuthUtils(cfg.Auth.JWTSecret, cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL)
+
+	// Guest cart tokens must verify on every backend instance, so all instances share the secret
+	guestCarts := NewGuestCartTokens(utils.NewTokenSigner(cfg.Auth.GuestCartSecret), cfg.IsProduction())
 
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(db)
@@ -40,47 +63,140 @@ func NewServer(db *database.Database, router *gin.Engine) *Server {
 	orderRepo := repositories.NewOrderRepository(db)
 	reviewRepo := repositories.NewReviewRepository(db)
 	restrictRepo := repositories.NewRestrictRepository(db)
+	pointsRepo := repositories.NewPointsRepository(db)
+	creditRepo := repositories.NewStoreCreditRepository(db)
+	giftRepo := repositories.NewGiftRepository(db)
+	redeemCodeRepo := repositories.NewRedeemCodeRepository(db)
+	couponRepo := repositories.NewCouponRepository(db)
+	bundleRepo := repositories.NewBundleRepository(db)
+	guestCartRepo := repositories.NewGuestCartRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	taxRuleRepo := repositories.NewTaxRuleRepository(db)
+	invoiceRepo := repositories.NewInvoiceRepository(db)
+
+	// Loyalty points rules
+	pointsRules := services.PointsRules{
+		OrderPercent:     cfg.Points.OrderPercent,
+		FirstReviewBonus: cfg.Points.FirstReviewBonus,
+		PointValues:      cfg.PointValues(),
+		MaxRedeemPercent: cfg.Points.MaxRedeemPercent,
+	}
+
+	// Currencies users pay in, by region
+	currencies := services.CurrencyRules{
+		Default: cfg.Store.Currency,
+		Regions: cfg.Store.RegionCurrencies,
+	}
+
+	// Taxes charged by the buyer's region
+	taxes := services.NewTaxCalculator(taxRuleRepo)
+
+	// Seller details printed on invoices
+	invoices := invoice.NewRenderer(invoice.Seller{
+		Name:    cfg.Invoice.SellerName,
+		Address: cfg.Invoice.SellerAddress,
+		TaxID:   cfg.Invoice.SellerTaxID,
+	})
+
+	// Emails are only sent when an SMTP server is configured; otherwise they are logged
+	var mailer services.Mailer = mail.LogMailer{Logger: logger}
+	if cfg.SMTP.Host != "" {
+		mailer = mail.NewSMTPMailer(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From)
+	}
 
 	// Initialize services
 	authService := services.NewAuthService(userRepo, roleRepo, authUtils)
-	userService := services.NewUserService(userRepo, cartRepo, favoriteRepo, libraryRepo, authUtils)
+	userService := services.NewUserService(userRepo, roleRepo, cartRepo, favoriteRepo, libraryRepo, pointsRepo, creditRepo, authUtils, stats)
 	roleService := services.NewRoleService(roleRepo)
-	gameService := services.NewGameService(gameRepo, categoryRepo, developerRepo, restrictRepo)
+	gameService := services.NewGameService(gameRepo, categoryRepo, developerRepo, restrictRepo, currencies)
 	categoryService := services.NewCategoryService(categoryRepo)
-	developerService := services.NewDeveloperService(developerRepo)
+	developerService := services.NewDeveloperService(developerRepo, userRepo)
 	restrictService := services.NewRestrictService(restrictRepo)
-	cartService := services.NewCartService(cartRepo, gameRepo)
-	favoriteService := services.NewFavoriteService(favoriteRepo, gameRepo)
-	libraryService := services.NewLibraryService(libraryRepo, gameRepo)
-	orderService := services.NewOrderService(orderRepo, cartRepo, gameRepo)
-	reviewService := services.NewReviewService(reviewRepo, gameRepo, userRepo)
+	cartService := services.NewCartService(cartRepo, gameRepo, userRepo, libraryRepo, giftRepo, couponRepo, bundleRepo, orderRepo, guestCartRepo, cfg.Jobs.GuestCartTTL, currencies, taxes, logger)
+	favoriteService := services.NewFavoriteService(favoriteRepo, gameRepo, userRepo, currencies)
+	libraryService := services.NewLibraryService(libraryRepo, gameRepo, logger)
+	orderService := services.NewOrderService(orderRepo, cartRepo, gameRepo, userRepo, libraryRepo, giftRepo, couponRepo, creditRepo, invoiceRepo, invoices, pointsRules, currencies, taxes, stats)
+	giftService := services.NewGiftService(giftRepo)
+	redeemService := services.NewRedeemService(redeemCodeRepo, gameRepo, currencies)
+	couponService := services.NewCouponService(couponRepo, categoryRepo, developerRepo, currencies)
+	bundleService := services.NewBundleService(bundleRepo, gameRepo, currencies)
+	reviewService := services.NewReviewService(reviewRepo, gameRepo, userRepo, libraryRepo, developerRepo, services.ReviewOptions{
+		RequirePurchase: cfg.Reviews.RequirePurchase,
+		BannedWords:     cfg.Reviews.BannedWords,
+	}, pointsRules)
+	notificationService := services.NewNotificationService(notificationRepo, favoriteRepo, mailer, currencies, logger)
+	taxService := services.NewTaxService(taxRuleRepo)
+
+	// Background jobs
+	jobs := scheduler.New(logger)
+	jobs.Every(time.Duration(cfg.Jobs.WishlistCheckMinutes)*time.Minute, "wishlist notifications", notificationService.CheckWishlists)
+	jobs.Every(time.Duration(cfg.Jobs.PreorderUnlockMinutes)*time.Minute, "pre-order unlocks", libraryService.UnlockReleasedGames)
+	jobs.Every(time.Duration(cfg.Jobs.GuestCartCleanupMinutes)*time.Minute, "guest cart cleanup", cartService.DeleteStaleGuestCarts)
 
 	// Initialize handlers
-	userHandler := NewUserHandler(userService, roleService, authService)
+	userHandler := NewUserHandler(userService, roleService, authService, cartService, guestCarts, logger)
 	gameHandler := NewGameHandler(gameService, categoryService, developerService, restrictService)
-	cartHandler := NewCartHandler(cartService)
+	cartHandler := NewCartHandler(cartService, guestCarts)
 	orderHandler := NewOrderHandler(orderService)
 	reviewHandler := NewReviewHandler(reviewService)
+	giftHandler := NewGiftHandler(giftService)
+	redeemHandler := NewRedeemHandler(redeemService)
+	couponHandler := NewCouponHandler(couponService)
+	bundleHandler := NewBundleHandler(bundleService)
 	favoriteHandler := NewFavoriteHandler(favoriteService)
 	libraryHandler := NewLibraryHandler(libraryService)
+	notificationHandler := NewNotificationHandler(notificationService)
+	taxHandler := NewTaxHandler(taxService)
+	healthHandler := NewHealthHandler(db)
 
 	return &Server{
-		DB:              db,
-		Router:          router,
-		UserHandler:     userHandler,
-		GameHandler:     gameHandler,
-		CartHandler:     cartHandler,
-		OrderHandler:    orderHandler,
-		ReviewHandler:   reviewHandler,
-		FavoriteHandler: favoriteHandler,
-		LibraryHandler:  libraryHandler,
+		Config:              cfg,
+		DB:                  db,
+		Router:              router,
+		AuthUtils:           authUtils,
+		Logger:              logger,
+		Metrics:             stats,
+		UserHandler:         userHandler,
+		GameHandler:         gameHandler,
+		CartHandler:         cartHandler,
+		OrderHandler:        orderHandler,
+		ReviewHandler:       reviewHandler,
+		GiftHandler:         giftHandler,
+		RedeemHandler:       redeemHandler,
+		CouponHandler:       couponHandler,
+		BundleHandler:       bundleHandler,
+		FavoriteHandler:     favoriteHandler,
+		LibraryHandler:      libraryHandler,
+		NotificationHandler: notificationHandler,
+		TaxHandler:          taxHandler,
+		HealthHandler:       healthHandler,
+		Scheduler:           jobs,
 	}
 }
 
 // SetupRoutes sets up all API routes
 func (s *Server) SetupRoutes() {
+	// Tag every request with an ID and turn panics into 500s
+	s.Router.Use(middleware.RequestID(), middleware.Recovery(s.Logger))
+
+	// Health probes, registered before the remaining middleware so they answer while the
+	// instance is not ready and do not flood the request log
+	s.Router.GET("/healthz", s.HealthHandler.Liveness)
+	s.Router.GET("/readyz", s.HealthHandler.Readiness)
+
+	// Prometheus metrics, scraped from each instance directly rather than through nginx
+	if s.Config.Features.Metrics {
+		s.Router.GET("/metrics", gin.WrapH(s.Metrics.Handler()))
+	}
+
+	// Trace, log, count and time every request
+	s.Router.Use(middleware.Tracing(), middleware.RequestLogger(s.Logger), middleware.Metrics(s.Metrics))
+
 	// Apply CORS middleware
-	s.Router.Use(middleware.CORSMiddleware())
+	s.Router.Use(middleware.CORSMiddleware(s.Config.CORS.AllowedOrigins))
+
+	// Turn requests away until the instance is ready and once it starts shutting down
+	s.Router.Use(middleware.RequireReady(s.HealthHandler.IsReady))
 
 	// API v1 routes
 	v1 := s.Router.Group("/api/v1")
@@ -98,14 +214,16 @@ func (s *Server) SetupRoutes() {
 		{
 			// Admin-only routes
 			adminRoutes := users.Group("/")
-			adminRoutes.Use(middleware.Authenticate(), middleware.AuthorizeAdmin())
+			adminRoutes.Use(middleware.Authenticate(s.AuthUtils), middleware.AuthorizeAdmin())
 			adminRoutes.GET("/", s.UserHandler.GetAllUsers) // Admin only - get all users
 
 			// User-specific routes (require authentication)
 			userRoutes := users.Group("/")
-			userRoutes.Use(middleware.Authenticate())
+			userRoutes.Use(middleware.Authenticate(s.AuthUtils))
 			userRoutes.GET("/:user_id", s.UserHandler.GetUserByID)
 			userRoutes.PATCH("/:user_id", s.UserHandler.UpdateUser)
+			userRoutes.GET("/:user_id/points", s.UserHandler.GetPointsHistory)
+			userRoutes.GET("/:user_id/credit", s.UserHandler.GetStoreCredit)
 		}
 
 		// Game routes (public)
@@ -121,7 +239,7 @@ func (s *Server) SetupRoutes() {
 
 			// Admin-only routes
 			adminRoutes := games.Group("/")
-			adminRoutes.Use(middleware.Authenticate(), middleware.AuthorizeAdmin())
+			adminRoutes.Use(middleware.Authenticate(s.AuthUtils), middleware.AuthorizeAdmin())
 			adminRoutes.POST("/", s.GameHandler.CreateGame)
 			adminRoutes.PATCH("/:game_id", s.GameHandler.UpdateGame)
 			adminRoutes.DELETE("/:game_id", s.GameHandler.DeleteGame)
@@ -133,50 +251,140 @@ func (s *Server) SetupRoutes() {
 		// Developers routes (public)
 		v1.GET("/developers", s.GameHandler.GetAllDevelopers)
 
-		// Cart routes (public for browsing, protected for saving)
-		cart := v1.Group("/cart")
+		// Developer member routes (admin only)
+		developers := v1.Group("/developers")
 		{
-			// Public cart routes (not tied to a user)
-			// These would require session-based cart storage in a real implementation
+			developers.Use(middleware.Authenticate(s.AuthUtils), middleware.AuthorizeAdmin())
+			developers.GET("/:developer_id/members", s.GameHandler.GetDeveloperMembers)
+			developers.POST("/:developer_id/members/:user_id", s.GameHandler.AddDeveloperMember)
+			developers.DELETE("/:developer_id/members/:user_id", s.GameHandler.RemoveDeveloperMember)
+		}
+
+		// Bundle routes
+		bundles := v1.Group("/bundles")
+		{
+			// Public routes
+			bundles.GET("/", s.BundleHandler.GetBundles)
+			bundles.GET("/:bundle_id", s.BundleHandler.GetBundleByID)
 
+			// Admin-only routes
+			adminBundles := bundles.Group("/")
+			adminBundles.Use(middleware.Authenticate(s.AuthUtils), middleware.AuthorizeAdmin())
+			adminBundles.POST("/", s.BundleHandler.CreateBundle)
+			adminBundles.PUT("/:bundle_id", s.BundleHandler.UpdateBundle)
+			adminBundles.DELETE("/:bundle_id", s.BundleHandler.DeleteBundle)
+		}
+
+		// Guest cart routes (public, identified by a signed cart token)
+		guestCart := v1.Group("/guest-cart")
+		{
+			guestCart.GET("/", s.CartHandler.GetGuestCart)
+			guestCart.POST("/add/:game_id", s.CartHandler.AddGameToGuestCart)
+			guestCart.DELETE("/remove/:game_id", s.CartHandler.RemoveGameFromGuestCart)
+			guestCart.DELETE("/clear", s.CartHandler.ClearGuestCart)
+		}
+
+		// Cart routes (protected)
+		cart := v1.Group("/cart")
+		{
 			// Protected cart routes (require login)
 			authenticatedCart := cart.Group("/")
-			authenticatedCart.Use(middleware.Authenticate())
+			authenticatedCart.Use(middleware.Authenticate(s.AuthUtils))
 			authenticatedCart.GET("/:user_id", s.CartHandler.GetCart)
 			authenticatedCart.POST("/:user_id/add/:game_id", s.CartHandler.AddGameToCart)
 			authenticatedCart.DELETE("/:user_id/remove/:game_id", s.CartHandler.RemoveGameFromCart)
 			authenticatedCart.DELETE("/:user_id/clear", s.CartHandler.ClearCart)
 			authenticatedCart.PATCH("/:user_id/update/:game_id", s.CartHandler.UpdateCartItemQuantity)
+			authenticatedCart.PUT("/:user_id/gift/:game_id", s.CartHandler.SetGift)
+			authenticatedCart.POST("/:user_id/gift/:game_id", s.CartHandler.AddGiftCopy)
+			authenticatedCart.DELETE("/:user_id/gift/:game_id", s.CartHandler.RemoveGift)
+			authenticatedCart.PUT("/:user_id/coupon", s.CartHandler.ApplyCoupon)
+			authenticatedCart.DELETE("/:user_id/coupon", s.CartHandler.RemoveCoupon)
+			authenticatedCart.POST("/:user_id/bundles/:bundle_id", s.CartHandler.AddBundleToCart)
+			authenticatedCart.DELETE("/:user_id/bundles/:bundle_id", s.CartHandler.RemoveBundleFromCart)
 		}
 
 		// Order routes (protected)
 		orders := v1.Group("/orders")
 		{
-			orders.Use(middleware.Authenticate())
+			orders.Use(middleware.Authenticate(s.AuthUtils))
 			orders.POST("/:user_id/create", s.OrderHandler.CreateOrderFromCart)
 			orders.GET("/:order_id", s.OrderHandler.GetOrderByID)
+			orders.GET("/:order_id/invoice", s.OrderHandler.GetInvoice)
 			orders.GET("/user/:user_id", s.OrderHandler.GetUserOrders)
+			orders.POST("/items/:item_id/cancel-preorder", s.OrderHandler.CancelPreOrder)
 
 			// Admin-only routes
 			adminRoutes := orders.Group("/")
 			adminRoutes.Use(middleware.AuthorizeAdmin())
 			adminRoutes.GET("/", s.OrderHandler.GetAllOrders) // Admin only
+			adminRoutes.PATCH("/:order_id/status", s.OrderHandler.UpdateOrderStatus)
 		}
 
 		// Favorite routes (protected - requires login)
 		favorite := v1.Group("/favorite")
 		{
-			favorite.Use(middleware.Authenticate())
+			favorite.Use(middleware.Authenticate(s.AuthUtils))
 			favorite.GET("/:user_id", s.FavoriteHandler.GetFavorite)
 			favorite.POST("/:user_id/add/:game_id", s.FavoriteHandler.AddGameToFavorite)
 			favorite.DELETE("/:user_id/remove/:game_id", s.FavoriteHandler.RemoveGameFromFavorite)
 			favorite.DELETE("/:user_id/clear", s.FavoriteHandler.ClearFavorite)
 		}
 
+		// Gift routes (protected)
+		gifts := v1.Group("/gifts")
+		{
+			gifts.Use(middleware.Authenticate(s.AuthUtils))
+			gifts.GET("/", s.GiftHandler.GetReceivedGifts)
+			gifts.POST("/:gift_id/accept", s.GiftHandler.AcceptGift)
+			gifts.POST("/:gift_id/decline", s.GiftHandler.DeclineGift)
+		}
+
+		// Notification routes (protected)
+		notifications := v1.Group("/notifications")
+		{
+			notifications.Use(middleware.Authenticate(s.AuthUtils))
+			notifications.GET("/", s.NotificationHandler.GetNotifications)
+			notifications.PUT("/read-all", s.NotificationHandler.MarkAllRead)
+			notifications.PUT("/:notification_id/read", s.NotificationHandler.MarkRead)
+			notifications.GET("/preferences", s.NotificationHandler.GetPreferences)
+			notifications.PUT("/preferences", s.NotificationHandler.UpdatePreferences)
+		}
+
+		// Redeem routes (protected)
+		v1.POST("/redeem", middleware.Authenticate(s.AuthUtils), s.RedeemHandler.Redeem)
+
+		// Redeem code management routes (admin only)
+		redeemCodes := v1.Group("/redeem-codes")
+		{
+			redeemCodes.Use(middleware.Authenticate(s.AuthUtils), middleware.AuthorizeAdmin())
+			redeemCodes.GET("/", s.RedeemHandler.GetCodes)
+			redeemCodes.POST("/", s.RedeemHandler.GenerateCodes)
+		}
+
+		// Coupon management routes (admin only)
+		coupons := v1.Group("/coupons")
+		{
+			coupons.Use(middleware.Authenticate(s.AuthUtils), middleware.AuthorizeAdmin())
+			coupons.GET("/", s.CouponHandler.GetCoupons)
+			coupons.POST("/", s.CouponHandler.CreateCoupon)
+			coupons.DELETE("/:coupon_id", s.CouponHandler.DeactivateCoupon)
+		}
+
+		// Tax rule management routes (admin only)
+		taxRules := v1.Group("/tax-rules")
+		{
+			taxRules.Use(middleware.Authenticate(s.AuthUtils), middleware.AuthorizeAdmin())
+			taxRules.GET("/", s.TaxHandler.GetTaxRules)
+			taxRules.POST("/", s.TaxHandler.CreateTaxRule)
+			taxRules.PUT("/:tax_rule_id", s.TaxHandler.UpdateTaxRule)
+			taxRules.DELETE("/:tax_rule_id", s.TaxHandler.DeleteTaxRule)
+		}
+
 		// Library routes (protected)
 		library := v1.Group("/library")
 		{
-			library.Use(middleware.Authenticate())
+			library.Use(middleware.Authenticate(s.AuthUtils))
 			library.GET("/:user_id", s.LibraryHandler.GetLibrary)
 		}
 
@@ -189,10 +397,21 @@ func (s *Server) SetupRoutes() {
 
 			// Protected routes
 			authenticatedReviews := reviews.Group("/")
-			authenticatedReviews.Use(middleware.Authenticate())
+			authenticatedReviews.Use(middleware.Authenticate(s.AuthUtils))
 			authenticatedReviews.POST("/", s.ReviewHandler.CreateReview)
 			authenticatedReviews.PATCH("/:review_id/user/:user_id", s.ReviewHandler.UpdateReview)
 			authenticatedReviews.DELETE("/:review_id/user/:user_id", s.ReviewHandler.DeleteReview)
+			authenticatedReviews.POST("/:review_id/report", s.ReviewHandler.ReportReview)
+			authenticatedReviews.POST("/:review_id/vote", s.ReviewHandler.VoteReview)
+			authenticatedReviews.PUT("/:review_id/reply", s.ReviewHandler.ReplyToReview)
+			authenticatedReviews.DELETE("/:review_id/reply", s.ReviewHandler.DeleteReply)
+
+			// Admin-only moderation routes
+			adminRoutes := reviews.Group("/")
+			adminRoutes.Use(middleware.Authenticate(s.AuthUtils), middleware.AuthorizeAdmin())
+			adminRoutes.GET("/moderation", s.ReviewHandler.GetModerationQueue)
+			adminRoutes.POST("/:review_id/approve", s.ReviewHandler.ApproveReview)
+			adminRoutes.POST("/:review_id/hide", s.ReviewHandler.HideReview)
 		}
 	}
 }
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"uniStore/Backend/internal/domain/services"
+	"uniStore/Backend/internal/interfaces/dto"
+)
+
+// TaxHandler handles HTTP requests related to tax rule management
+type TaxHandler struct {
+	taxService services.TaxService
+}
+
+// NewTaxHandler creates a new tax handler
+func NewTaxHandler(taxService services.TaxService) *TaxHandler {
+	return &TaxHandler{
+		taxService: taxService,
+	}
+}
+
+// GetTaxRules retrieves tax rules
+// @Summary Get tax rules
+// @Description Returns the tax charged to buyers of each region (admin only)
+// @Tags Taxes
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.TaxRuleDTO "List of tax rules"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/tax-rules [get]
+func (h *TaxHandler) GetTaxRules(c *gin.Context) {
+	rules, err := h.taxService.GetTaxRules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// CreateTaxRule creates a tax rule
+// @Summary Create a tax rule
+// @Description Sets the tax, such as VAT, charged to buyers of a region. The rate is in basis points; inclusive rules are taken out of prices, the others are added on top (admin only)
+// @Tags Taxes
+// @Accept json
+// @Produce json
+// @Param rule body dto.TaxRuleCreateDTO true "Tax rule"
+// @Success 201 {object} dto.TaxRuleDTO "Tax rule created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 409 {object} map[string]interface{} "A tax rule already exists for this region"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/tax-rules [post]
+func (h *TaxHandler) CreateTaxRule(c *gin.Context) {
+	var ruleDTO dto.TaxRuleCreateDTO
+	if err := c.BindJSON(&ruleDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.taxService.CreateTaxRule(c.Request.Context(), &ruleDTO)
+	if err != nil {
+		switch err.Error() {
+		case "region is required":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "a tax rule already exists for this region":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateTaxRule updates a tax rule
+// @Summary Update a tax rule
+// @Description Updates the name, rate or inclusiveness of a tax rule. Orders already placed keep the tax they were charged (admin only)
+// @Tags Taxes
+// @Accept json
+// @Produce json
+// @Param tax_rule_id path int true "Tax rule ID"
+// @Param rule body dto.TaxRuleUpdateDTO true "Tax rule data"
+// @Success 200 {object} dto.TaxRuleDTO "Tax rule updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Tax rule not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/tax-rules/{tax_rule_id} [put]
+func (h *TaxHandler) UpdateTaxRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("tax_rule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tax rule ID"})
+		return
+	}
+
+	var ruleDTO dto.TaxRuleUpdateDTO
+	if err := c.BindJSON(&ruleDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.taxService.UpdateTaxRule(c.Request.Context(), id, &ruleDTO)
+	if err != nil {
+		if err.Error() == "tax rule not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tax rule not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteTaxRule deletes a tax rule
+// @Summary Delete a tax rule
+// @Description Deletes a tax rule; buyers of its region then pay no tax (admin only)
+// @Tags Taxes
+// @Accept json
+// @Produce json
+// @Param tax_rule_id path int true "Tax rule ID"
+// @Success 200 {object} map[string]interface{} "Tax rule deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid tax rule ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - admin only"
+// @Failure 404 {object} map[string]interface{} "Tax rule not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security ApiKeyAuth
+// @Router /api/v1/tax-rules/{tax_rule_id} [delete]
+func (h *TaxHandler) DeleteTaxRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("tax_rule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tax rule ID"})
+		return
+	}
+
+	if err := h.taxService.DeleteTaxRule(c.Request.Context(), id); err != nil {
+		if err.Error() == "tax rule not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tax rule not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tax rule deleted successfully"})
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"log/slog"
 	"net/http"
 	"strconv"
 
@@ -15,20 +16,33 @@ type UserHandler struct {
 	userService services.UserService
 	roleService services.RoleService
 	authService services.AuthService
+	cartService services.CartService
+	guestCarts  *GuestCartTokens
+	logger      *slog.Logger
 }
 
 // NewUserHandler creates a new user handler
-func NewUserHandler(userService services.UserService, roleService services.RoleService, authService services.AuthService) *UserHandler {
+func NewUserHandler(
+	userService services.UserService,
+	roleService services.RoleService,
+	authService services.AuthService,
+	cartService services.CartService,
+	guestCarts *GuestCartTokens,
+	logger *slog.Logger,
+) *UserHandler {
 	return &UserHandler{
 		userService: userService,
 		roleService: roleService,
 		authService: authService,
+		cartService: cartService,
+		guestCarts:  guestCarts,
+		logger:      logger,
 	}
 }
 
 // Register handles user registration
 // @Summary Registers a new User
-// @Description This endpoint allows you to register a new User by providing required fields
+// @Description This endpoint allows you to register a new User by providing required fields. A guest cart sent with the request is merged into the new user's cart.
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -58,7 +72,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 	}
 
 	// Register user using DTO
-	userResponseDTO, err := h.userService.Register(&userDTO)
+	userResponseDTO, err := h.userService.Register(c.Request.Context(), &userDTO)
 	if err != nil {
 		if err.Error() == "email already in use" || err.Error() == "nickname already in use" {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
@@ -68,6 +82,8 @@ func (h *UserHandler) Register(c *gin.Context) {
 		return
 	}
 
+	h.mergeGuestCart(c, userResponseDTO.ID)
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "User registered successfully",
 		"user":    userResponseDTO,
@@ -76,7 +92,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 
 // Login handles user login
 // @Summary Logs in a User and returns user data
-// @Description This endpoint allows the user to log in by providing email and password
+// @Description This endpoint allows the user to log in by providing email and password. A guest cart sent with the request is merged into the user's cart, leaving out games already in it or owned.
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -94,7 +110,7 @@ func (h *UserHandler) Login(c *gin.Context) {
 	}
 
 	// Login user with DTO
-	authResponse, err := h.userService.Login(&loginDTO)
+	authResponse, err := h.userService.Login(c.Request.Context(), &loginDTO)
 	if err != nil {
 		if err.Error() == "email or password is incorrect" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
@@ -104,9 +120,26 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
+	h.mergeGuestCart(c, authResponse.User.ID)
+
 	c.JSON(http.StatusOK, authResponse)
 }
 
+// mergeGuestCart moves the request's guest cart, if any, into the user's cart. A failed merge
+// leaves the guest cart in place and does not fail the login or signup.
+func (h *UserHandler) mergeGuestCart(c *gin.Context, userID int) {
+	token := h.guestCarts.Read(c)
+	if token == "" {
+		return
+	}
+
+	if err := h.cartService.MergeGuestCart(c.Request.Context(), token, userID); err != nil {
+		h.logger.WarnContext(c.Request.Context(), "Failed to merge guest cart", "user_id", userID, "error", err)
+		return
+	}
+	h.guestCarts.Clear(c)
+}
+
 // RefreshToken handles token refresh
 // @Summary Refreshes an access token
 // @Description This endpoint refreshes an access token using a refresh token
@@ -130,7 +163,7 @@ func (h *UserHandler) RefreshToken(c *gin.Context) {
 	}
 
 	// Refresh token using user service
-	authResponse, err := h.userService.RefreshToken(requestBody.RefreshToken)
+	authResponse, err := h.userService.RefreshToken(c.Request.Context(), requestBody.RefreshToken)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
 		return
@@ -166,13 +199,13 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 	}
 
 	// Check if the user is authorized to access this resource
-	if err := h.authService.MatchUserTypeToID(id, c.GetString("role")); err != nil {
+	if err := h.authService.MatchUserTypeToID(c.Request.Context(), id, c.GetString("role")); err != nil {
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Get user by ID
-	userResponseDTO, err := h.userService.GetUserByID(id)
+	userResponseDTO, err := h.userService.GetUserByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -214,7 +247,7 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 	}
 
 	// Get all users with pagination
-	users, err := h.userService.GetAllUsers(limit, offset)
+	users, err := h.userService.GetAllUsers(c.Request.Context(), limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -225,7 +258,7 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 
 // UpdateUser handles updating a user
 // @Summary Update a User
-// @Description Updates a User's information
+// @Description Updates a User's information. Users can set their region and currency only while they have none; changing them takes an admin, as they decide the tax charged and the prices paid.
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -234,7 +267,7 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 // @Success 200 {object} map[string]interface{} "User updated successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid input or user ID"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 403 {object} map[string]interface{} "Forbidden, or region or currency change by a non-admin"
 // @Failure 500 {object} map[string]interface{} "Failed to update user"
 // @Security ApiKeyAuth
 // @Router /api/v1/users/{user_id} [patch]
@@ -247,7 +280,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}
 
 	// Check if the user is authorized to access this resource
-	if err := h.authService.MatchUserTypeToID(id, c.GetString("role")); err != nil {
+	if err := h.authService.MatchUserTypeToID(c.Request.Context(), id, c.GetString("role")); err != nil {
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
@@ -264,9 +297,18 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}
 
 	// Update user using DTO
-	updatedUser, err := h.userService.UpdateUser(id, &userDTO)
+	updatedUser, err := h.userService.UpdateUser(c.Request.Context(), id, c.GetString("role") == "admin", &userDTO)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch err.Error() {
+		case "unsupported currency":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "only an admin can change your region":
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only an admin can change your region"})
+		case "only an admin can change your currency":
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only an admin can change your currency"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -275,3 +317,115 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		"user":    updatedUser,
 	})
 }
+
+// GetPointsHistory handles listing a user's loyalty points ledger
+// @Summary Get loyalty points history
+// @Description Returns every credit and debit of the user's loyalty points, newest first
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param limit query int false "Limit number of results" default(20)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {array} dto.PointsLedgerDTO "Points ledger entries"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID or query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Failed to fetch points history"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{user_id}/points [get]
+func (h *UserHandler) GetPointsHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	// Only allow users to see their own points or admin to see any
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if tokenUserID.(int) != id && c.GetString("role") != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized access to another user's resource"})
+		return
+	}
+
+	// Parse pagination parameters
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+		return
+	}
+
+	entries, err := h.userService.GetPointsHistory(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetStoreCredit handles showing a user's store credit
+// @Summary Get store credit
+// @Description Returns the user's store credit balance in each currency and its credits and debits, newest first
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param limit query int false "Limit number of ledger entries" default(20)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} dto.StoreCreditDTO "Store credit balances and ledger entries"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID or query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Failed to fetch store credit"
+// @Security ApiKeyAuth
+// @Router /api/v1/users/{user_id}/credit [get]
+func (h *UserHandler) GetStoreCredit(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	// Only allow users to see their own credit or admin to see any
+	tokenUserID, ok := c.Get("userID")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if tokenUserID.(int) != id && c.GetString("role") != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized access to another user's resource"})
+		return
+	}
+
+	// Parse pagination parameters
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+		return
+	}
+
+	credit, err := h.userService.GetStoreCredit(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, credit)
+}
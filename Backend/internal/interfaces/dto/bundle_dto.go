@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// BundleCreateDTO represents data needed for creating a bundle
+type BundleCreateDTO struct {
+	Name        string  `json:"name" binding:"required,max=255"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price" binding:"min=0"`             // Price of the whole bundle in major units
+	Currency    string  `json:"currency"`                          // Currency of the price; the store currency by default
+	GameIDs     []int   `json:"game_ids" binding:"required,min=2"` // Games in the bundle
+}
+
+// BundleUpdateDTO represents data needed for updating a bundle; omitted fields are left unchanged
+type BundleUpdateDTO struct {
+	Name        string   `json:"name" binding:"max=255"`
+	Description string   `json:"description"`
+	Price       *float64 `json:"price" binding:"omitempty,min=0"`
+	Currency    string   `json:"currency"`
+	GameIDs     []int    `json:"game_ids" binding:"omitempty,min=2"` // Replaces the bundle's games
+	Active      *bool    `json:"active"`
+}
+
+// BundleDTO represents a bundle for API responses
+type BundleDTO struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Price       int64      `json:"price_minor"` // In minor units of Currency
+	Currency    string     `json:"currency"`
+	GamesValue  int64      `json:"games_value_minor"` // Combined price of the games bought separately, in Currency
+	Active      bool       `json:"active"`
+	Games       []*GameDTO `json:"games"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// MarshalJSON adds the prices in major units under their usual names
+func (d BundleDTO) MarshalJSON() ([]byte, error) {
+	type bundle BundleDTO
+	return json.Marshal(struct {
+		bundle
+		Price      float64 `json:"price"`
+		GamesValue float64 `json:"games_value"`
+	}{bundle(d), models.MajorUnits(d.Price, d.Currency), models.MajorUnits(d.GamesValue, d.Currency)})
+}
+
+// BundleDTOFromModel converts Bundle model to BundleDTO
+func BundleDTOFromModel(bundle *models.Bundle) *BundleDTO {
+	dto := &BundleDTO{
+		ID:          bundle.ID,
+		Name:        bundle.Name,
+		Description: bundle.Description,
+		Price:       bundle.Price,
+		Currency:    bundle.Currency,
+		Active:      bundle.Active,
+		Games:       make([]*GameDTO, 0, len(bundle.Games)),
+		CreatedAt:   bundle.CreatedAt,
+		UpdatedAt:   bundle.UpdatedAt,
+	}
+
+	for _, game := range bundle.Games {
+		dto.Games = append(dto.Games, GameDTOFromModel(game))
+		price, _ := game.PriceIn(bundle.Currency)
+		dto.GamesValue += price
+	}
+
+	return dto
+}
+
+// BundleDTOsFromModels converts a slice of Bundle models to a slice of BundleDTOs
+func BundleDTOsFromModels(bundles []*models.Bundle) []*BundleDTO {
+	dtos := make([]*BundleDTO, len(bundles))
+	for i, bundle := range bundles {
+		dtos[i] = BundleDTOFromModel(bundle)
+	}
+	return dtos
+}
@@ -1,14 +1,80 @@
 package dto
 
 import (
+	"encoding/json"
+
 	"uniStore/Backend/internal/domain/models"
 )
 
 // CartItemDTO represents a cart item response
 type CartItemDTO struct {
-	ID       int      `json:"id"`
-	Game     *GameDTO `json:"game,omitempty"`
-	Quantity int      `json:"quantity"`
+	ID       int          `json:"id"`
+	Game     *GameDTO     `json:"game,omitempty"`
+	Bundle   *BundleDTO   `json:"bundle,omitempty"`
+	Price    int64        `json:"price_minor"` // Unit price for the cart owner, in minor units of the cart currency
+	Currency string       `json:"currency"`    // The cart currency
+	Quantity int          `json:"quantity"`
+	Gift     *CartGiftDTO `json:"gift,omitempty"`
+
+	NetAmount   int64 `json:"net_amount_minor"` // Line total after its share of the coupon, before tax
+	TaxAmount   int64 `json:"tax_amount_minor"`
+	GrossAmount int64 `json:"gross_amount_minor"` // Line total after its share of the coupon, with tax
+
+	Warnings []CartItemWarningDTO `json:"warnings,omitempty"` // Changes since the item was added
+}
+
+// MarshalJSON adds the amounts in major units under their usual names
+func (d CartItemDTO) MarshalJSON() ([]byte, error) {
+	type cartItem CartItemDTO
+	return json.Marshal(struct {
+		cartItem
+		Price       float64 `json:"price"`
+		NetAmount   float64 `json:"net_amount"`
+		TaxAmount   float64 `json:"tax_amount"`
+		GrossAmount float64 `json:"gross_amount"`
+	}{
+		cartItem(d),
+		models.MajorUnits(d.Price, d.Currency),
+		models.MajorUnits(d.NetAmount, d.Currency),
+		models.MajorUnits(d.TaxAmount, d.Currency),
+		models.MajorUnits(d.GrossAmount, d.Currency),
+	})
+}
+
+// Cart item warning codes
+const (
+	CartWarningPriceChanged = "price_changed"
+	CartWarningUnavailable  = "unavailable"
+	CartWarningRestricted   = "restricted"
+)
+
+// CartItemWarningDTO represents something the user should know about a cart item before checking out
+type CartItemWarningDTO struct {
+	Code             string `json:"code"`
+	Message          string `json:"message"`
+	PreviousPrice    *int64 `json:"previous_price_minor,omitempty"` // Price in minor units when the item was added, for price changes
+	PreviousCurrency string `json:"previous_currency,omitempty"`    // Currency of the previous price
+}
+
+// MarshalJSON adds the previous price in major units under its usual name
+func (d CartItemWarningDTO) MarshalJSON() ([]byte, error) {
+	type cartItemWarning CartItemWarningDTO
+	var previousPrice *float64
+	if d.PreviousPrice != nil {
+		price := models.MajorUnits(*d.PreviousPrice, d.PreviousCurrency)
+		previousPrice = &price
+	}
+	return json.Marshal(struct {
+		cartItemWarning
+		PreviousPrice *float64 `json:"previous_price,omitempty"`
+	}{cartItemWarning(d), previousPrice})
+}
+
+// CartGiftDTO represents the gift details of a cart item
+type CartGiftDTO struct {
+	RecipientID       int    `json:"recipient_id"`
+	RecipientNickname string `json:"recipient_nickname,omitempty"`
+	Message           string `json:"message,omitempty"`
 }
 
 // CartResponseDTO represents a cart response
@@ -17,13 +83,79 @@ type CartResponseDTO struct {
 	UserID    int              `json:"user_id"`
 	User      *UserResponseDTO `json:"user,omitempty"`
 	Items     []CartItemDTO    `json:"items"`
-	TotalCost float64          `json:"total_cost"`
+	Currency  string           `json:"currency"` // Currency of every amount of the cart
+	Subtotal  int64            `json:"subtotal_minor"`
+	Coupon    *CartCouponDTO   `json:"coupon,omitempty"`
+	Tax       *TaxDTO          `json:"tax,omitempty"` // Tax of the user's region, if any
+	NetTotal  int64            `json:"net_total_minor"`
+	TaxTotal  int64            `json:"tax_total_minor"`
+	TotalCost int64            `json:"total_cost_minor"` // With tax
+	Version   string           `json:"version"`          // Changes whenever the amount to be charged changes; required to check out
+
+	RegionRequired bool `json:"region_required,omitempty"` // The user must set their region before checking out
+}
+
+// MarshalJSON adds the amounts in major units under their usual names
+func (d CartResponseDTO) MarshalJSON() ([]byte, error) {
+	type cart CartResponseDTO
+	return json.Marshal(struct {
+		cart
+		Subtotal  float64 `json:"subtotal"`
+		NetTotal  float64 `json:"net_total"`
+		TaxTotal  float64 `json:"tax_total"`
+		TotalCost float64 `json:"total_cost"`
+	}{
+		cart(d),
+		models.MajorUnits(d.Subtotal, d.Currency),
+		models.MajorUnits(d.NetTotal, d.Currency),
+		models.MajorUnits(d.TaxTotal, d.Currency),
+		models.MajorUnits(d.TotalCost, d.Currency),
+	})
+}
+
+// GuestCartDTO represents the cart of a visitor who is not logged in
+type GuestCartDTO struct {
+	Token     string        `json:"-"` // Sent to the visitor signed, in a cookie or header
+	Items     []CartItemDTO `json:"items"`
+	Currency  string        `json:"currency"`         // The store currency; games not sold in it are priced 0
+	TotalCost int64         `json:"total_cost_minor"` // Before tax, which depends on the region known once logged in
+}
+
+// MarshalJSON adds the total in major units under its usual name
+func (d GuestCartDTO) MarshalJSON() ([]byte, error) {
+	type guestCart GuestCartDTO
+	return json.Marshal(struct {
+		guestCart
+		TotalCost float64 `json:"total_cost"`
+	}{guestCart(d), models.MajorUnits(d.TotalCost, d.Currency)})
+}
+
+// CartCouponDTO represents the coupon applied to a cart
+type CartCouponDTO struct {
+	Code     string `json:"code"`
+	Discount int64  `json:"discount_minor"`
+	Currency string `json:"currency"`
+	Error    string `json:"error,omitempty"` // Why the coupon currently gives no discount
+}
+
+// MarshalJSON adds the discount in major units under its usual name
+func (d CartCouponDTO) MarshalJSON() ([]byte, error) {
+	type cartCoupon CartCouponDTO
+	return json.Marshal(struct {
+		cartCoupon
+		Discount float64 `json:"discount"`
+	}{cartCoupon(d), models.MajorUnits(d.Discount, d.Currency)})
+}
+
+// CartCouponApplyDTO represents data for applying a coupon to a cart
+type CartCouponApplyDTO struct {
+	Code string `json:"code" binding:"required"`
 }
 
 // CartItemCreateDTO represents data for adding an item to cart
 type CartItemCreateDTO struct {
 	GameID   int `json:"game_id" binding:"required"`
-	Quantity int `json:"quantity" binding:"required,min=1"`
+	Quantity int `json:"quantity" binding:"required,min=1,max=1"` // Digital games are a single copy
 }
 
 // CartItemUpdateDTO represents data for updating a cart item
@@ -31,7 +163,14 @@ type CartItemUpdateDTO struct {
 	Quantity int `json:"quantity" binding:"required,min=1"`
 }
 
-// CartItemDTOFromModel converts a CartItem model and Game model to CartItemDTO
+// CartGiftCreateDTO represents data for marking a cart item as a gift
+type CartGiftCreateDTO struct {
+	Recipient string `json:"recipient" binding:"required"` // Nickname or email of the recipient
+	Message   string `json:"message" binding:"max=500"`
+}
+
+// CartItemDTOFromModel converts a CartItem model and Game model to CartItemDTO.
+// The price is left for the caller, who knows the buyer's currency.
 func CartItemDTOFromModel(cartItem *models.CartItem, game *models.Game) *CartItemDTO {
 	dto := &CartItemDTO{
 		ID:       cartItem.ID,
@@ -43,23 +182,38 @@ func CartItemDTOFromModel(cartItem *models.CartItem, game *models.Game) *CartIte
 		dto.Game = GameDTOFromModel(game)
 	}
 
+	// Add bundle data if available
+	if cartItem.Bundle != nil {
+		dto.Bundle = BundleDTOFromModel(cartItem.Bundle)
+	}
+
+	// Add gift details if the item is a gift
+	if cartItem.GiftRecipientID != nil {
+		dto.Gift = &CartGiftDTO{
+			RecipientID: *cartItem.GiftRecipientID,
+			Message:     cartItem.GiftMessage,
+		}
+		if cartItem.GiftRecipient != nil {
+			dto.Gift.RecipientNickname = cartItem.GiftRecipient.Nickname
+		}
+	}
+
 	return dto
 }
 
 // CartResponseDTOFromModel converts a ShoppingCart model and cart items to CartResponseDTO
 func CartResponseDTOFromModel(cart *models.ShoppingCart, cartItems []*models.CartItem, cartItemDTOs []CartItemDTO) *CartResponseDTO {
 	// Calculate total cost
-	var totalCost float64
+	var totalCost int64
 	for _, item := range cartItemDTOs {
-		if item.Game != nil {
-			totalCost += item.Game.Price * float64(item.Quantity)
-		}
+		totalCost += item.Price * int64(item.Quantity)
 	}
 
 	dto := &CartResponseDTO{
 		ID:        cart.ID,
 		UserID:    cart.UserID,
 		Items:     cartItemDTOs,
+		Subtotal:  totalCost,
 		TotalCost: totalCost,
 	}
 
@@ -70,3 +224,24 @@ func CartResponseDTOFromModel(cart *models.ShoppingCart, cartItems []*models.Car
 
 	return dto
 }
+
+// GuestCartDTOFromModel converts a GuestCart model to GuestCartDTO, priced in the currency
+func GuestCartDTOFromModel(cart *models.GuestCart, currency string) *GuestCartDTO {
+	dto := &GuestCartDTO{
+		Token:    cart.Token,
+		Items:    make([]CartItemDTO, 0, len(cart.Items)),
+		Currency: currency,
+	}
+
+	for _, item := range cart.Items {
+		itemDTO := CartItemDTO{ID: item.ID, Currency: currency, Quantity: 1}
+		if item.Game != nil {
+			itemDTO.Game = GameDTOFromModel(item.Game)
+			itemDTO.Price, _ = item.Game.SalePriceIn(currency)
+		}
+		dto.Items = append(dto.Items, itemDTO)
+		dto.TotalCost += itemDTO.Price
+	}
+
+	return dto
+}
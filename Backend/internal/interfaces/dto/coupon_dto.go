@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// CouponCreateDTO represents data needed for creating a coupon
+type CouponCreateDTO struct {
+	Code           string     `json:"code" binding:"required,max=64"`
+	Type           string     `json:"type" binding:"required,oneof=percent fixed"`
+	Value          float64    `json:"value" binding:"required,gt=0"`               // Whole percentage, or fixed amount off in major units
+	MinTotal       float64    `json:"min_total" binding:"min=0"`                   // Minimum cart subtotal in major units
+	Currency       string     `json:"currency"`                                    // Currency of a fixed amount and the minimum; the store currency by default
+	MaxUses        int        `json:"max_uses" binding:"min=0"`                    // Total uses allowed; 0 means unlimited
+	MaxUsesPerUser *int       `json:"max_uses_per_user" binding:"omitempty,min=0"` // Uses allowed per user, 1 by default; 0 means unlimited
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	CategoryIDs    []int      `json:"category_ids"`  // Restrict the coupon to games in these categories
+	DeveloperIDs   []int      `json:"developer_ids"` // Or to games by these developers
+}
+
+// CouponDTO represents a coupon for API responses
+type CouponDTO struct {
+	ID             int             `json:"id"`
+	Code           string          `json:"code"`
+	Type           string          `json:"type"`
+	Value          int64           `json:"-"` // Percentage, or fixed amount off in minor units
+	MinTotal       int64           `json:"min_total_minor"`
+	Currency       string          `json:"currency"`
+	MaxUses        int             `json:"max_uses"`
+	MaxUsesPerUser int             `json:"max_uses_per_user"`
+	Uses           int             `json:"uses"`
+	StartsAt       *time.Time      `json:"starts_at,omitempty"`
+	EndsAt         *time.Time      `json:"ends_at,omitempty"`
+	Active         bool            `json:"active"`
+	Categories     []*CategoryDTO  `json:"categories,omitempty"`
+	Developers     []*DeveloperDTO `json:"developers,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// MarshalJSON adds the amounts in major units under their usual names; a percentage is left as it is
+// and only a fixed amount is also given in minor units
+func (d CouponDTO) MarshalJSON() ([]byte, error) {
+	type coupon CouponDTO
+	value, valueMinor := float64(d.Value), (*int64)(nil)
+	if d.Type == models.CouponTypeFixed {
+		value, valueMinor = models.MajorUnits(d.Value, d.Currency), &d.Value
+	}
+	return json.Marshal(struct {
+		coupon
+		Value      float64 `json:"value"`
+		ValueMinor *int64  `json:"value_minor,omitempty"`
+		MinTotal   float64 `json:"min_total"`
+	}{coupon(d), value, valueMinor, models.MajorUnits(d.MinTotal, d.Currency)})
+}
+
+// CouponDTOFromModel converts Coupon model to CouponDTO
+func CouponDTOFromModel(coupon *models.Coupon) *CouponDTO {
+	dto := &CouponDTO{
+		ID:             coupon.ID,
+		Code:           coupon.Code,
+		Type:           coupon.Type,
+		Value:          coupon.Value,
+		MinTotal:       coupon.MinTotal,
+		Currency:       coupon.Currency,
+		MaxUses:        coupon.MaxUses,
+		MaxUsesPerUser: coupon.MaxUsesPerUser,
+		Uses:           coupon.Uses,
+		StartsAt:       coupon.StartsAt,
+		EndsAt:         coupon.EndsAt,
+		Active:         coupon.Active,
+		CreatedAt:      coupon.CreatedAt,
+	}
+
+	// Add restrictions if available
+	for _, category := range coupon.Categories {
+		dto.Categories = append(dto.Categories, CategoryDTOFromModel(category))
+	}
+	for _, developer := range coupon.Developers {
+		dto.Developers = append(dto.Developers, DeveloperDTOFromModel(developer))
+	}
+
+	return dto
+}
+
+// CouponDTOsFromModels converts a slice of Coupon models to a slice of CouponDTOs
+func CouponDTOsFromModels(coupons []*models.Coupon) []*CouponDTO {
+	dtos := make([]*CouponDTO, len(coupons))
+	for i, coupon := range coupons {
+		dtos[i] = CouponDTOFromModel(coupon)
+	}
+	return dtos
+}
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"uniStore/Backend/internal/domain/models"
 )
 
@@ -20,6 +22,13 @@ type DeveloperUpdateDTO struct {
 	WebsiteURL  string `json:"website_url"`
 }
 
+// DeveloperMemberDTO represents a user account linked to a developer
+type DeveloperMemberDTO struct {
+	DeveloperID int              `json:"developer_id"`
+	User        *UserResponseDTO `json:"user,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
 // ToModel converts DeveloperCreateDTO to Developer model
 func (dto *DeveloperCreateDTO) ToModel() *models.Developer {
 	return &models.Developer{
@@ -40,3 +49,18 @@ func (dto *DeveloperUpdateDTO) ToUpdateModel(id int) *models.Developer {
 		WebsiteURL:  dto.WebsiteURL,
 	}
 }
+
+// DeveloperMemberDTOsFromModels converts a slice of DeveloperMember models to a slice of DeveloperMemberDTOs
+func DeveloperMemberDTOsFromModels(members []*models.DeveloperMember) []*DeveloperMemberDTO {
+	dtos := make([]*DeveloperMemberDTO, len(members))
+	for i, member := range members {
+		dtos[i] = &DeveloperMemberDTO{
+			DeveloperID: member.DeveloperID,
+			CreatedAt:   member.CreatedAt,
+		}
+		if member.User != nil {
+			dtos[i].User = UserResponseDTOFromModel(member.User)
+		}
+	}
+	return dtos
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"time"
 
 	"uniStore/Backend/internal/domain/models"
@@ -9,26 +10,55 @@ import (
 
 // GameCreateDTO represents data needed for creating a new game
 type GameCreateDTO struct {
-	Title       string    `json:"title" binding:"required"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price" binding:"required,gte=0"`
-	ReleaseDate time.Time `json:"release_date"`
-	DeveloperID int       `json:"developer_id" binding:"required"`
-	CategoryID  int       `json:"category_id" binding:"required"`
-	ImageData   string    `json:"image_data"`
-	ImageName   string    `json:"image_name"`
+	Title       string            `json:"title" binding:"required"`
+	Description string            `json:"description"`
+	Price       float64           `json:"price" binding:"gte=0"`            // Base price in major units, e.g. 19.99
+	Currency    string            `json:"currency"`                         // Currency of the base price; the store currency by default
+	Prices      []GamePriceSetDTO `json:"prices" binding:"omitempty,dive"`  // Regional prices in other currencies
+	Discount    int               `json:"discount" binding:"gte=0,lte=100"` // Percent off every price
+	ReleaseDate time.Time         `json:"release_date"`
+	DeveloperID int               `json:"developer_id" binding:"required"`
+	CategoryID  int               `json:"category_id" binding:"required"`
+	ImageData   string            `json:"image_data"`
+	ImageName   string            `json:"image_name"`
 }
 
 // GameUpdateDTO represents data needed for updating a game
 type GameUpdateDTO struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price" binding:"omitempty,gte=0"`
-	ReleaseDate time.Time `json:"release_date"`
-	DeveloperID int       `json:"developer_id"`
-	CategoryID  int       `json:"category_id"`
-	ImageData   string    `json:"image_data"`
-	ImageName   string    `json:"image_name"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Price       float64           `json:"price" binding:"omitempty,gte=0"`            // Base price in major units
+	Currency    string            `json:"currency"`                                   // Currency of the base price
+	Prices      []GamePriceSetDTO `json:"prices" binding:"omitempty,dive"`            // Replaces the regional prices; an empty list removes them
+	Discount    *int              `json:"discount" binding:"omitempty,gte=0,lte=100"` // Percent off every price; 0 ends the sale
+	ReleaseDate time.Time         `json:"release_date"`
+	DeveloperID int               `json:"developer_id"`
+	CategoryID  int               `json:"category_id"`
+	ImageData   string            `json:"image_data"`
+	ImageName   string            `json:"image_name"`
+}
+
+// GamePriceSetDTO represents a game's regional price in a currency
+type GamePriceSetDTO struct {
+	Currency string  `json:"currency" binding:"required,len=3"`
+	Price    float64 `json:"price" binding:"gte=0"` // In major units of the currency
+}
+
+// GamePriceDTO represents a game's price in a currency for API responses
+type GamePriceDTO struct {
+	Currency  string `json:"currency"`
+	Price     int64  `json:"price_minor"`      // In minor units of the currency
+	SalePrice int64  `json:"sale_price_minor"` // Price after the discount
+}
+
+// MarshalJSON adds the prices in major units under their usual names
+func (d GamePriceDTO) MarshalJSON() ([]byte, error) {
+	type gamePrice GamePriceDTO
+	return json.Marshal(struct {
+		gamePrice
+		Price     float64 `json:"price"`
+		SalePrice float64 `json:"sale_price"`
+	}{gamePrice(d), models.MajorUnits(d.Price, d.Currency), models.MajorUnits(d.SalePrice, d.Currency)})
 }
 
 // GameSearchDTO represents search criteria for games
@@ -44,7 +74,11 @@ type GameDTO struct {
 	ID          int            `json:"id"`
 	Title       string         `json:"title"`
 	Description string         `json:"description"`
-	Price       float64        `json:"price"`
+	Price       int64          `json:"price_minor"`        // Base price in minor units of Currency
+	Currency    string         `json:"currency"`           // Currency of the base price
+	Discount    int            `json:"discount,omitempty"` // Percent off every price
+	SalePrice   int64          `json:"sale_price_minor"`   // Base price after the discount
+	Prices      []GamePriceDTO `json:"prices,omitempty"`   // Regional prices in other currencies
 	ReleaseDate time.Time      `json:"release_date"`
 	Developer   *DeveloperDTO  `json:"developer,omitempty"`
 	Category    *CategoryDTO   `json:"category,omitempty"`
@@ -55,6 +89,16 @@ type GameDTO struct {
 	UpdatedAt   time.Time      `json:"updated_at"`
 }
 
+// MarshalJSON adds the prices in major units under their usual names
+func (d GameDTO) MarshalJSON() ([]byte, error) {
+	type game GameDTO
+	return json.Marshal(struct {
+		game
+		Price     float64 `json:"price"`
+		SalePrice float64 `json:"sale_price"`
+	}{game(d), models.MajorUnits(d.Price, d.Currency), models.MajorUnits(d.SalePrice, d.Currency)})
+}
+
 // DeveloperDTO represents developer data for API response
 type DeveloperDTO struct {
 	ID          int       `json:"id"`
@@ -86,7 +130,8 @@ func (dto *GameCreateDTO) ToModel() *models.Game {
 	return &models.Game{
 		Title:       dto.Title,
 		Description: dto.Description,
-		Price:       dto.Price,
+		Currency:    dto.Currency,
+		Discount:    dto.Discount,
 		ReleaseDate: dto.ReleaseDate,
 		DeveloperID: dto.DeveloperID,
 		CategoryID:  dto.CategoryID,
@@ -107,7 +152,7 @@ func (dto *GameUpdateDTO) ToUpdateModel(id int) *models.Game {
 		ID:          id,
 		Title:       dto.Title,
 		Description: dto.Description,
-		Price:       dto.Price,
+		Currency:    dto.Currency,
 		ReleaseDate: dto.ReleaseDate,
 		DeveloperID: dto.DeveloperID,
 		CategoryID:  dto.CategoryID,
@@ -128,6 +173,9 @@ func GameDTOFromModel(model *models.Game) *GameDTO {
 		Title:       model.Title,
 		Description: model.Description,
 		Price:       model.Price,
+		Currency:    model.Currency,
+		Discount:    model.Discount,
+		SalePrice:   models.PercentOff(model.Price, int64(model.Discount)),
 		ReleaseDate: model.ReleaseDate,
 		ImageData:   imageDataStr,
 		ImageName:   model.ImageName,
@@ -135,6 +183,15 @@ func GameDTOFromModel(model *models.Game) *GameDTO {
 		UpdatedAt:   model.UpdatedAt,
 	}
 
+	// Add regional prices if available
+	for _, price := range model.Prices {
+		dto.Prices = append(dto.Prices, GamePriceDTO{
+			Currency:  price.Currency,
+			Price:     price.Price,
+			SalePrice: models.PercentOff(price.Price, int64(model.Discount)),
+		})
+	}
+
 	// Add developer data if available
 	if model.Developer != nil {
 		dto.Developer = DeveloperDTOFromModel(model.Developer)
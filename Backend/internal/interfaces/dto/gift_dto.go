@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// GiftDTO represents a received gift for API responses
+type GiftDTO struct {
+	ID          int              `json:"id"`
+	Sender      *UserResponseDTO `json:"sender,omitempty"`
+	Game        *GameDTO         `json:"game,omitempty"`
+	Message     string           `json:"message,omitempty"`
+	Status      string           `json:"status"`
+	RespondedAt *time.Time       `json:"responded_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+// GiftDTOFromModel converts Gift model to GiftDTO
+func GiftDTOFromModel(gift *models.Gift) *GiftDTO {
+	dto := &GiftDTO{
+		ID:          gift.ID,
+		Message:     gift.Message,
+		Status:      gift.Status,
+		RespondedAt: gift.RespondedAt,
+		CreatedAt:   gift.CreatedAt,
+	}
+
+	// Add sender data if available
+	if gift.Sender != nil {
+		dto.Sender = UserResponseDTOFromModel(gift.Sender)
+	}
+
+	// Add full game data if available
+	if gift.Game != nil {
+		dto.Game = GameDTOFromModel(gift.Game)
+	}
+
+	return dto
+}
+
+// GiftDTOsFromModels converts a slice of Gift models to a slice of GiftDTOs
+func GiftDTOsFromModels(gifts []*models.Gift) []*GiftDTO {
+	dtos := make([]*GiftDTO, len(gifts))
+	for i, gift := range gifts {
+		dtos[i] = GiftDTOFromModel(gift)
+	}
+	return dtos
+}
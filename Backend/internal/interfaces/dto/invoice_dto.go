@@ -0,0 +1,15 @@
+package dto
+
+// Invoice document formats
+const (
+	InvoiceFormatPDF  = "pdf"
+	InvoiceFormatHTML = "html"
+)
+
+// InvoiceDocumentDTO represents a rendered invoice
+type InvoiceDocumentDTO struct {
+	Number      string
+	UserID      int // Buyer, who may download it
+	ContentType string
+	Content     []byte
+}
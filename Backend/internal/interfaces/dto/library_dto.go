@@ -10,6 +10,7 @@ import (
 type LibraryItemDTO struct {
 	ID        int       `json:"id"`
 	Game      *GameDTO  `json:"game,omitempty"`
+	Locked    bool      `json:"locked"` // Pre-ordered, playable from the game's release date
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -26,6 +27,7 @@ type LibraryResponseDTO struct {
 func LibraryItemDTOFromModel(libraryItem *models.LibraryItem, game *models.Game) *LibraryItemDTO {
 	dto := &LibraryItemDTO{
 		ID:        libraryItem.ID,
+		Locked:    libraryItem.Locked,
 		CreatedAt: libraryItem.CreatedAt,
 	}
 
@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// NotificationListQueryDTO represents filtering and pagination of a user's notifications
+type NotificationListQueryDTO struct {
+	Unread bool `form:"unread"` // Only unread notifications
+	Limit  int  `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int  `form:"offset,default=0" binding:"min=0"`
+}
+
+// NotificationDTO represents a notification for API responses
+type NotificationDTO struct {
+	ID        int        `json:"id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Message   string     `json:"message"`
+	GameID    *int       `json:"game_id,omitempty"`
+	GameTitle string     `json:"game_title,omitempty"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationPageDTO represents a page of notifications for API responses
+type NotificationPageDTO struct {
+	Items  []*NotificationDTO `json:"items"`
+	Unread int64              `json:"unread"` // Unread notifications in total
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
+
+// NotificationPreferenceDTO represents a user's notification preferences
+type NotificationPreferenceDTO struct {
+	PriceDrops bool `json:"price_drops"` // Price drops and sales of wishlisted games
+	Releases   bool `json:"releases"`    // Releases of wishlisted games
+	Email      bool `json:"email"`       // Also send notifications by email
+}
+
+// NotificationPreferenceUpdateDTO represents changes to a user's notification preferences
+type NotificationPreferenceUpdateDTO struct {
+	PriceDrops *bool `json:"price_drops"`
+	Releases   *bool `json:"releases"`
+	Email      *bool `json:"email"`
+}
+
+// NotificationDTOFromModel converts a Notification model to NotificationDTO
+func NotificationDTOFromModel(notification *models.Notification) *NotificationDTO {
+	dto := &NotificationDTO{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		GameID:    notification.GameID,
+		Read:      notification.ReadAt != nil,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+
+	if notification.Game != nil {
+		dto.GameTitle = notification.Game.Title
+	}
+
+	return dto
+}
+
+// NotificationDTOsFromModels converts a slice of Notification models to NotificationDTOs
+func NotificationDTOsFromModels(notifications []*models.Notification) []*NotificationDTO {
+	dtos := make([]*NotificationDTO, len(notifications))
+	for i, notification := range notifications {
+		dtos[i] = NotificationDTOFromModel(notification)
+	}
+	return dtos
+}
+
+// NotificationPreferenceDTOFromModel converts a NotificationPreference model to NotificationPreferenceDTO
+func NotificationPreferenceDTOFromModel(preference *models.NotificationPreference) *NotificationPreferenceDTO {
+	return &NotificationPreferenceDTO{
+		PriceDrops: preference.PriceDrops,
+		Releases:   preference.Releases,
+		Email:      preference.Email,
+	}
+}
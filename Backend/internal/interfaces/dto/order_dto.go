@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"uniStore/Backend/internal/domain/models"
@@ -8,24 +9,100 @@ import (
 
 // OrderItemDTO represents an order item for API responses
 type OrderItemDTO struct {
-	ID        int       `json:"id"`
-	OrderID   int       `json:"order_id"`
-	Game      *GameDTO  `json:"game,omitempty"`
-	Price     float64   `json:"price"`
-	Quantity  int       `json:"quantity"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              int        `json:"id"`
+	OrderID         int        `json:"order_id"`
+	Game            *GameDTO   `json:"game,omitempty"`
+	Bundle          *BundleDTO `json:"bundle,omitempty"`
+	Price           int64      `json:"price_minor"` // In minor units of the order currency
+	Currency        string     `json:"currency"`    // The order currency
+	Quantity        int        `json:"quantity"`
+	NetAmount       int64      `json:"net_amount_minor"` // Line total after its share of the coupon, before tax
+	TaxAmount       int64      `json:"tax_amount_minor"`
+	GrossAmount     int64      `json:"gross_amount_minor"` // Line total after its share of the coupon, with tax
+	PreOrder        bool       `json:"pre_order,omitempty"`
+	GiftRecipientID *int       `json:"gift_recipient_id,omitempty"`
+	GiftMessage     string     `json:"gift_message,omitempty"`
+	RefundedAt      *time.Time `json:"refunded_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// MarshalJSON adds the amounts in major units under their usual names
+func (d OrderItemDTO) MarshalJSON() ([]byte, error) {
+	type orderItem OrderItemDTO
+	return json.Marshal(struct {
+		orderItem
+		Price       float64 `json:"price"`
+		NetAmount   float64 `json:"net_amount"`
+		TaxAmount   float64 `json:"tax_amount"`
+		GrossAmount float64 `json:"gross_amount"`
+	}{
+		orderItem(d),
+		models.MajorUnits(d.Price, d.Currency),
+		models.MajorUnits(d.NetAmount, d.Currency),
+		models.MajorUnits(d.TaxAmount, d.Currency),
+		models.MajorUnits(d.GrossAmount, d.Currency),
+	})
 }
 
 // OrderResponseDTO represents an order for API responses
 type OrderResponseDTO struct {
-	ID        int              `json:"id"`
-	UserID    int              `json:"user_id"`
-	User      *UserResponseDTO `json:"user,omitempty"`
-	TotalCost float64          `json:"total_cost"`
-	Status    string           `json:"status"`
-	CreatedAt time.Time        `json:"created_at"`
-	UpdatedAt time.Time        `json:"updated_at"`
-	Items     []OrderItemDTO   `json:"items"`
+	ID             int              `json:"id"`
+	UserID         int              `json:"user_id"`
+	User           *UserResponseDTO `json:"user,omitempty"`
+	Currency       string           `json:"currency"` // Currency of every amount of the order
+	Subtotal       int64            `json:"subtotal_minor"`
+	CouponCode     string           `json:"coupon_code,omitempty"`
+	CouponDiscount int64            `json:"coupon_discount_minor"`
+	Tax            *TaxDTO          `json:"tax,omitempty"` // Tax charged, if any
+	NetTotal       int64            `json:"net_total_minor"`
+	TaxTotal       int64            `json:"tax_total_minor"`
+	GrossTotal     int64            `json:"gross_total_minor"` // With tax, before points and store credit
+	PointsRedeemed int              `json:"points_redeemed"`
+	PointsDiscount int64            `json:"points_discount_minor"`
+	PointsEarned   int              `json:"points_earned"`
+	CreditPaid     int64            `json:"credit_paid_minor"` // Store credit spent on the order
+	TotalCost      int64            `json:"total_cost_minor"`
+	RefundedAmount int64            `json:"refunded_amount_minor"`
+	Status         string           `json:"status"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	Items          []OrderItemDTO   `json:"items"`
+}
+
+// MarshalJSON adds the amounts in major units under their usual names
+func (d OrderResponseDTO) MarshalJSON() ([]byte, error) {
+	type order OrderResponseDTO
+	major := func(amount int64) float64 { return models.MajorUnits(amount, d.Currency) }
+	return json.Marshal(struct {
+		order
+		Subtotal       float64 `json:"subtotal"`
+		CouponDiscount float64 `json:"coupon_discount"`
+		NetTotal       float64 `json:"net_total"`
+		TaxTotal       float64 `json:"tax_total"`
+		GrossTotal     float64 `json:"gross_total"`
+		PointsDiscount float64 `json:"points_discount"`
+		CreditPaid     float64 `json:"credit_paid"`
+		TotalCost      float64 `json:"total_cost"`
+		RefundedAmount float64 `json:"refunded_amount"`
+	}{
+		order(d),
+		major(d.Subtotal),
+		major(d.CouponDiscount),
+		major(d.NetTotal),
+		major(d.TaxTotal),
+		major(d.GrossTotal),
+		major(d.PointsDiscount),
+		major(d.CreditPaid),
+		major(d.TotalCost),
+		major(d.RefundedAmount),
+	})
+}
+
+// OrderCheckoutDTO represents options for placing an order from the cart
+type OrderCheckoutDTO struct {
+	Points      int    `json:"points" binding:"min=0"` // Loyalty points to redeem
+	UseCredit   bool   `json:"use_credit"`             // Pay what store credit in the order currency covers
+	CartVersion string `json:"cart_version"`           // Version of the cart the user reviewed; may be sent as If-Match instead
 }
 
 // OrderCreateDTO represents data needed for creating a new order directly (not from cart)
@@ -33,23 +110,30 @@ type OrderCreateDTO struct {
 	UserID int `json:"user_id" binding:"required"`
 	Items  []struct {
 		GameID   int `json:"game_id" binding:"required"`
-		Quantity int `json:"quantity" binding:"required,min=1"`
+		Quantity int `json:"quantity" binding:"omitempty,min=1,max=1"` // Digital games are a single copy
 	} `json:"items" binding:"required"`
 }
 
 // OrderUpdateDTO represents data needed for updating an order status
 type OrderUpdateDTO struct {
-	Status string `json:"status" binding:"required"`
+	Status string `json:"status" binding:"required,oneof=paid cancelled refunded"`
 }
 
 // OrderItemDTOFromModel converts OrderItem model to OrderItemDTO
 func OrderItemDTOFromModel(orderItem *models.OrderItem) *OrderItemDTO {
 	dto := &OrderItemDTO{
-		ID:        orderItem.ID,
-		OrderID:   orderItem.OrderID,
-		Price:     orderItem.Price,
-		Quantity:  orderItem.Quantity,
-		CreatedAt: orderItem.CreatedAt,
+		ID:              orderItem.ID,
+		OrderID:         orderItem.OrderID,
+		Price:           orderItem.Price,
+		Quantity:        orderItem.Quantity,
+		NetAmount:       orderItem.NetAmount,
+		TaxAmount:       orderItem.TaxAmount,
+		GrossAmount:     orderItem.GrossAmount,
+		PreOrder:        orderItem.PreOrder,
+		GiftRecipientID: orderItem.GiftRecipientID,
+		GiftMessage:     orderItem.GiftMessage,
+		RefundedAt:      orderItem.RefundedAt,
+		CreatedAt:       orderItem.CreatedAt,
 	}
 
 	// Add full game data if available
@@ -57,6 +141,11 @@ func OrderItemDTOFromModel(orderItem *models.OrderItem) *OrderItemDTO {
 		dto.Game = GameDTOFromModel(orderItem.Game)
 	}
 
+	// Add bundle data if available
+	if orderItem.Bundle != nil {
+		dto.Bundle = BundleDTOFromModel(orderItem.Bundle)
+	}
+
 	return dto
 }
 
@@ -65,17 +154,30 @@ func OrderResponseDTOFromModel(order *models.Order, orderItems []*models.OrderIt
 	itemDTOs := make([]OrderItemDTO, len(orderItems))
 	for i, item := range orderItems {
 		itemDTO := OrderItemDTOFromModel(item)
+		itemDTO.Currency = order.Currency
 		itemDTOs[i] = *itemDTO
 	}
 
 	dto := &OrderResponseDTO{
-		ID:        order.ID,
-		UserID:    order.UserID,
-		TotalCost: order.TotalCost,
-		Status:    order.Status,
-		CreatedAt: order.CreatedAt,
-		UpdatedAt: order.UpdatedAt,
-		Items:     itemDTOs,
+		ID:             order.ID,
+		UserID:         order.UserID,
+		Currency:       order.Currency,
+		Subtotal:       order.Subtotal,
+		CouponDiscount: order.CouponDiscount,
+		Tax:            TaxDTOFromOrder(order),
+		NetTotal:       order.NetTotal,
+		TaxTotal:       order.TaxTotal,
+		GrossTotal:     order.GrossTotal,
+		PointsRedeemed: order.PointsRedeemed,
+		PointsDiscount: order.PointsDiscount,
+		PointsEarned:   order.PointsEarned,
+		CreditPaid:     order.CreditPaid,
+		TotalCost:      order.TotalCost,
+		RefundedAmount: order.RefundedAmount,
+		Status:         order.Status,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+		Items:          itemDTOs,
 	}
 
 	// Add full user data if available
@@ -83,6 +185,11 @@ func OrderResponseDTOFromModel(order *models.Order, orderItems []*models.OrderIt
 		dto.User = UserResponseDTOFromModel(order.User)
 	}
 
+	// Add the coupon code if available
+	if order.Coupon != nil {
+		dto.CouponCode = order.Coupon.Code
+	}
+
 	return dto
 }
 
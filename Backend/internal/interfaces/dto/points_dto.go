@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// PointsLedgerDTO represents a loyalty points ledger entry for API responses
+type PointsLedgerDTO struct {
+	ID        int       `json:"id"`
+	Amount    int       `json:"amount"`
+	Balance   int       `json:"balance"`
+	Reason    string    `json:"reason"`
+	OrderID   *int      `json:"order_id,omitempty"`
+	GameID    *int      `json:"game_id,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PointsLedgerDTOsFromModels converts a slice of PointsLedger models to a slice of PointsLedgerDTOs
+func PointsLedgerDTOsFromModels(entries []*models.PointsLedger) []*PointsLedgerDTO {
+	dtos := make([]*PointsLedgerDTO, len(entries))
+	for i, entry := range entries {
+		dtos[i] = &PointsLedgerDTO{
+			ID:        entry.ID,
+			Amount:    entry.Amount,
+			Balance:   entry.Balance,
+			Reason:    entry.Reason,
+			OrderID:   entry.OrderID,
+			GameID:    entry.GameID,
+			Note:      entry.Note,
+			CreatedAt: entry.CreatedAt,
+		}
+	}
+	return dtos
+}
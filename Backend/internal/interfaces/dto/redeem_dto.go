@@ -0,0 +1,112 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// RedeemCodeGenerateDTO represents data needed for generating a batch of redeem codes
+type RedeemCodeGenerateDTO struct {
+	GameID         *int       `json:"game_id"`                                 // Game granted by the codes
+	CreditAmount   float64    `json:"credit_amount" binding:"min=0"`           // Or store credit, in major units
+	CreditCurrency string     `json:"credit_currency"`                         // Currency of the credit; the store currency by default
+	Count          int        `json:"count" binding:"required,min=1,max=1000"` // Number of codes to generate
+	MaxUses        int        `json:"max_uses" binding:"omitempty,min=1"`      // Redemptions allowed per code, 1 by default
+	ExpiresAt      *time.Time `json:"expires_at"`
+	Note           string     `json:"note" binding:"max=255"`
+}
+
+// RedeemDTO represents a code entered by a user
+type RedeemDTO struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// RedeemCodeUseDTO represents a redemption of a code for API responses
+type RedeemCodeUseDTO struct {
+	UserID     int       `json:"user_id"`
+	RedeemedAt time.Time `json:"redeemed_at"`
+}
+
+// RedeemCodeDTO represents a redeem code for API responses
+type RedeemCodeDTO struct {
+	ID             int                `json:"id"`
+	Code           string             `json:"code"`
+	Game           *GameDTO           `json:"game,omitempty"`
+	CreditAmount   int64              `json:"credit_amount_minor"`
+	CreditCurrency string             `json:"credit_currency,omitempty"`
+	CreditPoints   int                `json:"credit_points,omitempty"` // Only on codes generated before store credit had its own balance
+	MaxUses        int                `json:"max_uses"`
+	Uses           int                `json:"uses"`
+	ExpiresAt      *time.Time         `json:"expires_at,omitempty"`
+	Note           string             `json:"note,omitempty"`
+	Redemptions    []RedeemCodeUseDTO `json:"redemptions,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+// MarshalJSON adds the credit in major units under its usual name
+func (d RedeemCodeDTO) MarshalJSON() ([]byte, error) {
+	type code RedeemCodeDTO
+	return json.Marshal(struct {
+		code
+		CreditAmount float64 `json:"credit_amount"`
+	}{code(d), models.MajorUnits(d.CreditAmount, d.CreditCurrency)})
+}
+
+// RedeemResultDTO represents what a user received by redeeming a code
+type RedeemResultDTO struct {
+	Game           *GameDTO `json:"game,omitempty"`
+	CreditAmount   int64    `json:"credit_amount_minor"`
+	CreditCurrency string   `json:"credit_currency,omitempty"`
+	CreditPoints   int      `json:"credit_points,omitempty"`
+}
+
+// MarshalJSON adds the credit in major units under its usual name
+func (d RedeemResultDTO) MarshalJSON() ([]byte, error) {
+	type result RedeemResultDTO
+	return json.Marshal(struct {
+		result
+		CreditAmount float64 `json:"credit_amount"`
+	}{result(d), models.MajorUnits(d.CreditAmount, d.CreditCurrency)})
+}
+
+// RedeemCodeDTOFromModel converts RedeemCode model to RedeemCodeDTO
+func RedeemCodeDTOFromModel(code *models.RedeemCode) *RedeemCodeDTO {
+	dto := &RedeemCodeDTO{
+		ID:             code.ID,
+		Code:           code.Code,
+		CreditAmount:   code.CreditAmount,
+		CreditCurrency: code.CreditCurrency,
+		CreditPoints:   code.CreditPoints,
+		MaxUses:        code.MaxUses,
+		Uses:           code.Uses,
+		ExpiresAt:      code.ExpiresAt,
+		Note:           code.Note,
+		CreatedAt:      code.CreatedAt,
+	}
+
+	// Add full game data if available
+	if code.Game != nil {
+		dto.Game = GameDTOFromModel(code.Game)
+	}
+
+	// Add redemptions if available
+	for _, use := range code.RedeemCodeUses {
+		dto.Redemptions = append(dto.Redemptions, RedeemCodeUseDTO{
+			UserID:     use.UserID,
+			RedeemedAt: use.CreatedAt,
+		})
+	}
+
+	return dto
+}
+
+// RedeemCodeDTOsFromModels converts a slice of RedeemCode models to a slice of RedeemCodeDTOs
+func RedeemCodeDTOsFromModels(codes []*models.RedeemCode) []*RedeemCodeDTO {
+	dtos := make([]*RedeemCodeDTO, len(codes))
+	for i, code := range codes {
+		dtos[i] = RedeemCodeDTOFromModel(code)
+	}
+	return dtos
+}
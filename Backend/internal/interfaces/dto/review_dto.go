@@ -6,9 +6,9 @@ import (
 	"uniStore/Backend/internal/domain/models"
 )
 
-// ReviewCreateDTO represents data needed for creating a new review
+// ReviewCreateDTO represents data needed for creating a new review.
+// The author is taken from the authentication token, not from the body.
 type ReviewCreateDTO struct {
-	UserID  int    `json:"user_id" binding:"required"`
 	GameID  int    `json:"game_id" binding:"required"`
 	Rating  int    `json:"rating" binding:"required,min=1,max=5"`
 	Comment string `json:"comment"`
@@ -20,21 +20,98 @@ type ReviewUpdateDTO struct {
 	Comment string `json:"comment"`
 }
 
+// ReviewListQueryDTO represents filtering, sorting and pagination of a game's reviews
+type ReviewListQueryDTO struct {
+	Sort   string `form:"sort,default=helpful"`
+	Rating int    `form:"rating" binding:"omitempty,min=1,max=5"`
+	Limit  int    `form:"limit,default=10" binding:"min=1,max=100"`
+	Offset int    `form:"offset,default=0" binding:"min=0"`
+}
+
+// ReviewModerationQueryDTO represents pagination of the review moderation queue
+type ReviewModerationQueryDTO struct {
+	Limit  int `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int `form:"offset,default=0" binding:"min=0"`
+}
+
+// ReviewVoteDTO represents a helpfulness vote on a review
+type ReviewVoteDTO struct {
+	Helpful *bool `json:"helpful" binding:"required"`
+}
+
+// ReviewReportCreateDTO represents data needed for reporting a review
+type ReviewReportCreateDTO struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// ReviewReplyCreateDTO represents data needed for replying to a review
+type ReviewReplyCreateDTO struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+// ReviewReplyDTO represents a developer reply for API responses
+type ReviewReplyDTO struct {
+	ID        int           `json:"id"`
+	Developer *DeveloperDTO `json:"developer,omitempty"`
+	Body      string        `json:"body"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
 // ReviewResponseDTO represents a review for API responses
 type ReviewResponseDTO struct {
+	ID               int              `json:"id"`
+	User             *UserResponseDTO `json:"user,omitempty"`
+	Game             *GameDTO         `json:"game,omitempty"`
+	Rating           int              `json:"rating"`
+	Comment          string           `json:"comment"`
+	VerifiedPurchase bool             `json:"verified_purchase"`
+	Status           string           `json:"status"`
+	HelpfulCount     int              `json:"helpful_count"`
+	UnhelpfulCount   int              `json:"unhelpful_count"`
+	Reply            *ReviewReplyDTO  `json:"reply,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+}
+
+// ReviewPageDTO represents a page of reviews for API responses
+type ReviewPageDTO struct {
+	Items  []*ReviewResponseDTO `json:"items"`
+	Total  int64                `json:"total"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+// ReviewReportDTO represents a review report for API responses
+type ReviewReportDTO struct {
 	ID        int              `json:"id"`
+	ReviewID  int              `json:"review_id"`
 	User      *UserResponseDTO `json:"user,omitempty"`
-	Game      *GameDTO         `json:"game,omitempty"`
-	Rating    int              `json:"rating"`
-	Comment   string           `json:"comment"`
+	Reason    string           `json:"reason"`
+	Resolved  bool             `json:"resolved"`
 	CreatedAt time.Time        `json:"created_at"`
-	UpdatedAt time.Time        `json:"updated_at"`
 }
 
-// ToModel converts ReviewCreateDTO to Review model
-func (dto *ReviewCreateDTO) ToModel() *models.Review {
+// ReviewModerationDTO represents a review in the moderation queue with its open reports
+type ReviewModerationDTO struct {
+	Review  *ReviewResponseDTO `json:"review"`
+	Reports []ReviewReportDTO  `json:"reports"`
+}
+
+// ToQuery converts ReviewListQueryDTO to a repository review query
+func (dto *ReviewListQueryDTO) ToQuery() models.ReviewQuery {
+	return models.ReviewQuery{
+		Rating: dto.Rating,
+		Sort:   dto.Sort,
+		Limit:  dto.Limit,
+		Offset: dto.Offset,
+	}
+}
+
+// ToModel converts ReviewCreateDTO to Review model authored by the given user
+func (dto *ReviewCreateDTO) ToModel(userID int) *models.Review {
 	return &models.Review{
-		UserID:      dto.UserID,
+		UserID:      userID,
 		GameID:      dto.GameID,
 		Rating:      dto.Rating,
 		Description: dto.Comment,
@@ -54,11 +131,15 @@ func (dto *ReviewUpdateDTO) ToUpdateModel(id int) *models.Review {
 // ReviewResponseDTOFromModel converts Review model to ReviewResponseDTO
 func ReviewResponseDTOFromModel(review *models.Review) *ReviewResponseDTO {
 	dto := &ReviewResponseDTO{
-		ID:        review.ID,
-		Rating:    review.Rating,
-		Comment:   review.Description,
-		CreatedAt: review.CreatedAt,
-		UpdatedAt: review.UpdatedAt,
+		ID:               review.ID,
+		Rating:           review.Rating,
+		Comment:          review.Description,
+		VerifiedPurchase: review.VerifiedPurchase,
+		Status:           review.Status,
+		HelpfulCount:     review.HelpfulCount,
+		UnhelpfulCount:   review.UnhelpfulCount,
+		CreatedAt:        review.CreatedAt,
+		UpdatedAt:        review.UpdatedAt,
 	}
 
 	// Add full user data if available
@@ -71,6 +152,11 @@ func ReviewResponseDTOFromModel(review *models.Review) *ReviewResponseDTO {
 		dto.Game = GameDTOFromModel(review.Game)
 	}
 
+	// Add developer reply if available
+	if review.Reply != nil {
+		dto.Reply = ReviewReplyDTOFromModel(review.Reply)
+	}
+
 	return dto
 }
 
@@ -82,3 +168,51 @@ func ReviewResponseDTOsFromModels(reviews []*models.Review) []*ReviewResponseDTO
 	}
 	return dtos
 }
+
+// ReviewReplyDTOFromModel converts ReviewReply model to ReviewReplyDTO
+func ReviewReplyDTOFromModel(reply *models.ReviewReply) *ReviewReplyDTO {
+	dto := &ReviewReplyDTO{
+		ID:        reply.ID,
+		Body:      reply.Body,
+		CreatedAt: reply.CreatedAt,
+		UpdatedAt: reply.UpdatedAt,
+	}
+
+	// Add developer data if available
+	if reply.Developer != nil {
+		dto.Developer = DeveloperDTOFromModel(reply.Developer)
+	}
+
+	return dto
+}
+
+// ReviewReportDTOFromModel converts ReviewReport model to ReviewReportDTO
+func ReviewReportDTOFromModel(report *models.ReviewReport) *ReviewReportDTO {
+	dto := &ReviewReportDTO{
+		ID:        report.ID,
+		ReviewID:  report.ReviewID,
+		Reason:    report.Reason,
+		Resolved:  report.Resolved,
+		CreatedAt: report.CreatedAt,
+	}
+
+	// Add reporter data if available
+	if report.User != nil {
+		dto.User = UserResponseDTOFromModel(report.User)
+	}
+
+	return dto
+}
+
+// ReviewModerationDTOFromModel converts Review model with preloaded reports to ReviewModerationDTO
+func ReviewModerationDTOFromModel(review *models.Review) *ReviewModerationDTO {
+	reports := make([]ReviewReportDTO, len(review.Reports))
+	for i, report := range review.Reports {
+		reports[i] = *ReviewReportDTOFromModel(report)
+	}
+
+	return &ReviewModerationDTO{
+		Review:  ReviewResponseDTOFromModel(review),
+		Reports: reports,
+	}
+}
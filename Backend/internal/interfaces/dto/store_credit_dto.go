@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// StoreCreditBalanceDTO represents a user's store credit in one currency for API responses
+type StoreCreditBalanceDTO struct {
+	Currency string `json:"currency"`
+	Balance  int64  `json:"balance_minor"`
+}
+
+// MarshalJSON adds the balance in major units under its usual name
+func (d StoreCreditBalanceDTO) MarshalJSON() ([]byte, error) {
+	type balance StoreCreditBalanceDTO
+	return json.Marshal(struct {
+		balance
+		Balance float64 `json:"balance"`
+	}{balance(d), models.MajorUnits(d.Balance, d.Currency)})
+}
+
+// StoreCreditLedgerDTO represents a store credit ledger entry for API responses
+type StoreCreditLedgerDTO struct {
+	ID           int       `json:"id"`
+	Currency     string    `json:"currency"`
+	Amount       int64     `json:"amount_minor"`
+	Balance      int64     `json:"balance_minor"`
+	Reason       string    `json:"reason"`
+	RedeemCodeID *int      `json:"redeem_code_id,omitempty"`
+	OrderID      *int      `json:"order_id,omitempty"`
+	Note         string    `json:"note,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// MarshalJSON adds the amounts in major units under their usual names
+func (d StoreCreditLedgerDTO) MarshalJSON() ([]byte, error) {
+	type entry StoreCreditLedgerDTO
+	return json.Marshal(struct {
+		entry
+		Amount  float64 `json:"amount"`
+		Balance float64 `json:"balance"`
+	}{entry(d), models.MajorUnits(d.Amount, d.Currency), models.MajorUnits(d.Balance, d.Currency)})
+}
+
+// StoreCreditDTO represents a user's store credit balances and ledger for API responses
+type StoreCreditDTO struct {
+	Balances []StoreCreditBalanceDTO `json:"balances"`
+	Entries  []*StoreCreditLedgerDTO `json:"entries"`
+}
+
+// StoreCreditDTOFromModels converts store credit balances and ledger entries to a StoreCreditDTO
+func StoreCreditDTOFromModels(balances []*models.StoreCredit, entries []*models.StoreCreditLedger) *StoreCreditDTO {
+	dto := &StoreCreditDTO{
+		Balances: make([]StoreCreditBalanceDTO, len(balances)),
+		Entries:  make([]*StoreCreditLedgerDTO, len(entries)),
+	}
+	for i, balance := range balances {
+		dto.Balances[i] = StoreCreditBalanceDTO{Currency: balance.Currency, Balance: balance.Balance}
+	}
+	for i, entry := range entries {
+		dto.Entries[i] = &StoreCreditLedgerDTO{
+			ID:           entry.ID,
+			Currency:     entry.Currency,
+			Amount:       entry.Amount,
+			Balance:      entry.Balance,
+			Reason:       entry.Reason,
+			RedeemCodeID: entry.RedeemCodeID,
+			OrderID:      entry.OrderID,
+			Note:         entry.Note,
+			CreatedAt:    entry.CreatedAt,
+		}
+	}
+	return dto
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"uniStore/Backend/internal/domain/models"
+)
+
+// TaxRuleCreateDTO represents data needed for creating a tax rule
+type TaxRuleCreateDTO struct {
+	Region    string `json:"region" binding:"required,max=8"` // Region code, as on users
+	Name      string `json:"name" binding:"required,max=32"`  // Shown to buyers, e.g. "VAT"
+	Rate      int    `json:"rate" binding:"min=0,max=10000"`  // In basis points, 2000 is 20%
+	Inclusive *bool  `json:"inclusive"`                       // Prices already include the tax; true by default
+}
+
+// TaxRuleUpdateDTO represents data needed for updating a tax rule
+type TaxRuleUpdateDTO struct {
+	Name      string `json:"name" binding:"omitempty,max=32"`
+	Rate      *int   `json:"rate" binding:"omitempty,min=0,max=10000"`
+	Inclusive *bool  `json:"inclusive"`
+}
+
+// TaxRuleDTO represents a tax rule for API responses
+type TaxRuleDTO struct {
+	ID        int       `json:"id"`
+	Region    string    `json:"region"`
+	Name      string    `json:"name"`
+	Rate      int       `json:"rate"`
+	Inclusive bool      `json:"inclusive"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TaxDTO represents the tax charged on a cart or order
+type TaxDTO struct {
+	Region    string `json:"region"`
+	Name      string `json:"name"`
+	Rate      int    `json:"rate"`      // In basis points
+	Inclusive bool   `json:"inclusive"` // Prices already included the tax
+}
+
+// TaxRuleDTOFromModel converts TaxRule model to TaxRuleDTO
+func TaxRuleDTOFromModel(rule *models.TaxRule) *TaxRuleDTO {
+	return &TaxRuleDTO{
+		ID:        rule.ID,
+		Region:    rule.Region,
+		Name:      rule.Name,
+		Rate:      rule.Rate,
+		Inclusive: rule.Inclusive,
+		CreatedAt: rule.CreatedAt,
+		UpdatedAt: rule.UpdatedAt,
+	}
+}
+
+// TaxRuleDTOsFromModels converts a slice of TaxRule models to a slice of TaxRuleDTOs
+func TaxRuleDTOsFromModels(rules []*models.TaxRule) []*TaxRuleDTO {
+	dtos := make([]*TaxRuleDTO, len(rules))
+	for i, rule := range rules {
+		dtos[i] = TaxRuleDTOFromModel(rule)
+	}
+	return dtos
+}
+
+// TaxDTOFromRule converts the tax rule applied to a cart to TaxDTO; nil when no tax applies
+func TaxDTOFromRule(rule *models.TaxRule) *TaxDTO {
+	if rule == nil {
+		return nil
+	}
+	return &TaxDTO{
+		Region:    rule.Region,
+		Name:      rule.Name,
+		Rate:      rule.Rate,
+		Inclusive: rule.Inclusive,
+	}
+}
+
+// TaxDTOFromOrder converts the tax recorded on an order to TaxDTO; nil when none was charged
+func TaxDTOFromOrder(order *models.Order) *TaxDTO {
+	if order.TaxRegion == "" {
+		return nil
+	}
+	return &TaxDTO{
+		Region:    order.TaxRegion,
+		Name:      order.TaxName,
+		Rate:      order.TaxRate,
+		Inclusive: order.TaxInclusive,
+	}
+}
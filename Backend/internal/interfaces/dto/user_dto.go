@@ -18,6 +18,7 @@ type UserSignupDTO struct {
 	Email           string `json:"email" binding:"required,email"`
 	Password        string `json:"password" binding:"required,min=6"`
 	ConfirmPassword string `json:"confirm_password" binding:"required,eqfield=Password"`
+	Region          string `json:"region" binding:"omitempty,iso3166_1_alpha2"` // Country the user shops from, e.g. DE; decides their tax and is needed to check out
 }
 
 // UserUpdateDTO represents data needed for user update
@@ -26,6 +27,8 @@ type UserUpdateDTO struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 	RoleID   int    `json:"role_id"`
+	Region   string `json:"region" binding:"omitempty,iso3166_1_alpha2"` // Only an admin can change a region already set
+	Currency string `json:"currency" binding:"omitempty,len=3"`          // Currency to pay in instead of the region's; only an admin can change one already set
 }
 
 // UserResponseDTO represents a user for API responses (no sensitive data)
@@ -35,6 +38,8 @@ type UserResponseDTO struct {
 	Email     string    `json:"email"`
 	Role      *RoleDTO  `json:"role,omitempty"`
 	Points    int       `json:"points"`
+	Region    string    `json:"region,omitempty"`
+	Currency  string    `json:"currency,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -62,6 +67,7 @@ func (dto *UserSignupDTO) ToModel() *models.User {
 		Email:    dto.Email,
 		Password: dto.Password, // Password will be hashed in service layer
 		RoleID:   2,            // Default role ID (user)
+		Region:   dto.Region,
 	}
 }
 
@@ -73,6 +79,8 @@ func (dto *UserUpdateDTO) ToUpdateModel(id int) *models.User {
 		Email:    dto.Email,
 		Password: dto.Password, // Password will be hashed in service layer
 		RoleID:   dto.RoleID,
+		Region:   dto.Region,
+		Currency: dto.Currency,
 	}
 }
 
@@ -83,6 +91,8 @@ func UserResponseDTOFromModel(user *models.User) *UserResponseDTO {
 		Nickname:  user.Nickname,
 		Email:     user.Email,
 		Points:    user.Points,
+		Region:    user.Region,
+		Currency:  user.Currency,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
@@ -23,12 +23,9 @@ func NewAuthMiddleware(authService services.AuthService) *AuthMiddleware {
 	}
 }
 
-// Authenticate is a middleware for authenticating requests
-func Authenticate() gin.HandlerFunc {
+// Authenticate is a middleware for authenticating requests with tokens issued by authUtils
+func Authenticate(authUtils *utils.AuthUtils) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Initialize auth utils
-		authUtils := utils.NewAuthUtils()
-
 		// Get the Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
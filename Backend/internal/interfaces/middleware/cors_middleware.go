@@ -1,25 +1,29 @@
 package middleware
 
 import (
+	"slices"
+
 	"github.com/gin-gonic/gin"
 )
 
-// CORSMiddleware adds CORS headers to the response
-func CORSMiddleware() gin.HandlerFunc {
+// CORSMiddleware adds CORS headers to the response. Only the allowed origins may call the
+// API with credentials; an empty list or "*" allows any origin.
+func CORSMiddleware(allowedOrigins []string) gin.HandlerFunc {
+	anyOrigin := len(allowedOrigins) == 0 || slices.Contains(allowedOrigins, "*")
+
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
 
 		// Устанавливаем CORS заголовки
-		if origin != "" {
+		c.Writer.Header().Add("Vary", "Origin")
+		if origin != "" && (anyOrigin || slices.Contains(allowedOrigins, origin)) {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-		} else {
-			c.Writer.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 		}
 
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Cart-Token, If-Match")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
-		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, X-Cart-Token, ETag")
 
 		// Обрабатываем OPTIONS запросы
 		if c.Request.Method == "OPTIONS" {
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireReady turns requests away with 503 while the instance is starting or shutting
+// down, so the load balancer retries them on another instance
+func RequireReady(isReady func() bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isReady() {
+			c.Header("Retry-After", "1")
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Service is not ready"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
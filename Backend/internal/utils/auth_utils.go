@@ -2,7 +2,6 @@ package utils
 
 import (
 	"errors"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -10,13 +9,17 @@ import (
 
 // AuthUtils provides utilities for authentication
 type AuthUtils struct {
-	secretKey string
+	secretKey  string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
 }
 
-// NewAuthUtils creates a new AuthUtils instance
-func NewAuthUtils() *AuthUtils {
+// NewAuthUtils creates a new AuthUtils instance signing tokens with the secret
+func NewAuthUtils(secretKey string, accessTTL, refreshTTL time.Duration) *AuthUtils {
 	return &AuthUtils{
-		secretKey: "your-secret-key", // In production, this should be loaded from an environment variable
+		secretKey:  secretKey,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
 	}
 }
 
@@ -30,7 +33,7 @@ func (a *AuthUtils) GenerateToken(email, nickname, role string, id int) (string,
 	claims["nickname"] = nickname
 	claims["role"] = role
 	claims["user_id"] = id
-	claims["exp"] = time.Now().Add(time.Hour * 24).Unix() // 24 hours expiration
+	claims["exp"] = time.Now().Add(a.accessTTL).Unix()
 
 	accessToken, err := token.SignedString([]byte(a.secretKey))
 	if err != nil {
@@ -42,7 +45,7 @@ func (a *AuthUtils) GenerateToken(email, nickname, role string, id int) (string,
 	refreshClaims := refreshToken.Claims.(jwt.MapClaims)
 	refreshClaims["email"] = email
 	refreshClaims["user_id"] = id
-	refreshClaims["exp"] = time.Now().Add(time.Hour * 24 * 7).Unix() // 7 days expiration for refresh token
+	refreshClaims["exp"] = time.Now().Add(a.refreshTTL).Unix()
 
 	refreshTokenString, err := refreshToken.SignedString([]byte(a.secretKey))
 	if err != nil {
@@ -99,8 +102,3 @@ func (a *AuthUtils) RefreshToken(tokenString string) (string, string, error) {
 	// Generate new tokens
 	return a.GenerateToken(email, nickname, role, int(userID))
 }
-
-// IsProd checks if the application is running in production mode
-func IsProd() bool {
-	return os.Getenv("APP_ENV") == "production"
-}
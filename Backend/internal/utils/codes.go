@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+// codeAlphabet leaves out characters that are easily confused, such as 0/O and 1/I
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GenerateCode returns a random code made of groups of characters separated by dashes, e.g. ABCD-EFGH-JKLM
+func GenerateCode(groups, groupLength int) (string, error) {
+	max := big.NewInt(int64(len(codeAlphabet)))
+	parts := make([]string, groups)
+	for i := range parts {
+		part := make([]byte, groupLength)
+		for j := range part {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			part[j] = codeAlphabet[n.Int64()]
+		}
+		parts[i] = string(part)
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+// NormalizeCode upper-cases a user-entered code and strips surrounding whitespace
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
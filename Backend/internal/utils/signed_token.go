@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// TokenSigner signs opaque tokens handed to clients so that they cannot be forged
+type TokenSigner struct {
+	secret []byte
+}
+
+// NewTokenSigner creates a new TokenSigner. Every instance serving the same clients must use the same secret.
+func NewTokenSigner(secret string) *TokenSigner {
+	return &TokenSigner{secret: []byte(secret)}
+}
+
+// Sign returns the token followed by its signature, e.g. <token>.<signature>
+func (s *TokenSigner) Sign(token string) string {
+	return token + "." + s.signature(token)
+}
+
+// Verify returns the token of a signed value, or false if the signature does not match
+func (s *TokenSigner) Verify(signed string) (string, bool) {
+	token, signature, found := strings.Cut(signed, ".")
+	if !found || token == "" {
+		return "", false
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(token))) {
+		return "", false
+	}
+	return token, true
+}
+
+// signature returns the URL-safe HMAC-SHA256 signature of the token
+func (s *TokenSigner) signature(token string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateRandomToken returns a random hex token of n bytes
+func GenerateRandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
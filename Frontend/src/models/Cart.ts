@@ -4,6 +4,7 @@ export interface ShoppingCart {
   id: number;
   userID: number;
   cartItems: CartItem[];
+  version?: string; // Changes whenever the amount to be charged changes; sent back to check out
 }
 
 export interface CartItem {
@@ -2,8 +2,8 @@ import api from './api';
 import { Order } from '../models/Order';
 
 export const OrderService = {
-  createOrder: async (userId: number) => {
-    const response = await api.post<Order>(`/orders/${userId}/create/`);
+  createOrder: async (userId: number, cartVersion?: string) => {
+    const response = await api.post<Order>(`/orders/${userId}/create/`, { cart_version: cartVersion });
     return response.data;
   },
 
@@ -1,7 +1,8 @@
-import { takeLatest, put, call, all, fork } from 'redux-saga/effects';
+import { takeLatest, put, call, all, fork, select } from 'redux-saga/effects';
 import { PayloadAction } from '@reduxjs/toolkit';
 import { OrderService } from '@/services/OrderService';
 import { Order } from '@/models/Order';
+import { ShoppingCart } from '@/models/Cart';
 import {
   createOrderRequest,
   createOrderSuccess,
@@ -13,16 +14,19 @@ import {
   getUserOrdersSuccess,
   getUserOrdersFailure,
 } from '@/store/slices/ordersSlice';
-import { clearCartSuccess } from '@/store/slices/cartSlice';
+import { getCartRequest } from '@/store/slices/cartSlice';
+import { selectCart } from '@/store/selectors/cartSelectors';
 
 // Worker Sagas
 function* createOrderSaga(action: PayloadAction<number>) {
   try {
-    const order: Order = yield call(OrderService.createOrder, action.payload);
+    // Check out the cart as the user last saw it; the order is refused if it changed since
+    const cart: ShoppingCart | null = yield select(selectCart);
+    const order: Order = yield call(OrderService.createOrder, action.payload, cart?.version);
     yield put(createOrderSuccess(order));
     
-    // Clear the cart after successful order creation
-    yield put(clearCartSuccess());
+    // Reload the cart: only the ordered items were taken out of it
+    yield put(getCartRequest(action.payload));
   } catch (error: any) {
     yield put(createOrderFailure(error.response?.data?.message || 'Failed to create order'));
   }